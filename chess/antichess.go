@@ -0,0 +1,71 @@
+package chess
+
+import "fmt"
+
+// also known as losing chess. Captures are compulsory, the king is an ordinary piece (there is no check and no
+// castling) and a player wins by losing all pieces or by being stale mated. Pawns only promote to queens, as in the rest of
+// this package, so promoting to a king, which antichess allows and which often decides the game, is not possible
+type Antichess struct {
+	Standard
+}
+
+func (Antichess) Name() string {
+	return "Antichess"
+}
+
+func (Antichess) KingIsRoyal() bool {
+	return false
+}
+
+// a move that does not capture is only allowed if no piece of the same colour can capture
+func (Antichess) AllowMove(b *Board, p *Piece, move Move) error {
+	if p.Type == King {
+		if _, isCastling := castlingSideFor(p, move.To.Column, move.To.Row, b); isCastling {
+			return illegalMove(ForbiddenByVariant, "castling is not allowed in antichess")
+		}
+	}
+	result, err := p.Move(move.To.Column, move.To.Row, b, true)
+	if err != nil || result.Action == Take {
+		return nil // not a move the piece can make (the move itself reports why) or a capture
+	}
+	if b.canCapture(p.Colour) {
+		return illegalMove(ForbiddenByVariant, "captures are compulsory in antichess")
+	}
+	return nil
+}
+
+// the player to move wins when it has no pieces left or no legal moves
+func (Antichess) GameOver(g *Game) (Result, bool) {
+	if g.Board.piecesInPlay(g.NextToMove) == 0 {
+		return winFor(g.NextToMove, VariantRule, fmt.Sprintf("%v has lost all pieces", g.NextToMove)), true
+	}
+	if len(g.LegalMoves()) == 0 {
+		return winFor(g.NextToMove, Stalemate, fmt.Sprintf("%v is stale mated", g.NextToMove)), true
+	}
+	return Result{}, false
+}
+
+// returns true if any piece of the colour can take an enemy piece
+func (b *Board) canCapture(colour Colour) bool {
+	for move, result := range b.GetAllMovesFor(colour) {
+		if move.Kind != Drop && result.Action == Take {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the number of pieces the colour has on the board
+func (b *Board) piecesInPlay(colour Colour) int {
+	pieces := b.WhitePieces
+	if colour == Black {
+		pieces = b.BlackPieces
+	}
+	count := 0
+	for _, piece := range pieces {
+		if piece.InPlay {
+			count++
+		}
+	}
+	return count
+}
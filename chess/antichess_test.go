@@ -0,0 +1,70 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAntichess_captures_are_compulsory(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Antichess{}, "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2")
+	if _, err := game.move(Move{From: Square{"G", 1}, To: Square{"F", 3}}, White); !errors.Is(err, ErrForbiddenByVariant) {
+		t.Errorf("Expected a move that does not capture to be forbidden, got %v", err)
+	}
+	moves := game.LegalMoves()
+	if len(moves) != 1 {
+		t.Errorf("Expected taking on D5 to be the only legal move, got %v", moves)
+	}
+	if _, err := game.move(Move{From: Square{"E", 4}, To: Square{"D", 5}}, White); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+}
+
+func TestAntichess_king_can_be_taken(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Antichess{}, "4k3/8/8/8/8/8/8/4RK2 b - - 0 1")
+	if isCheck, _ := game.Board.kingIsInCheck(Black); isCheck {
+		t.Errorf("Expected no check in antichess")
+	}
+	// moving into the line of the rook is fine, the king is not royal
+	if _, err := game.move(Move{From: Square{"E", 8}, To: Square{"E", 7}}, Black); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if _, err := game.move(Move{From: Square{"E", 1}, To: Square{"E", 7}}, White); err != nil {
+		t.Errorf("Expected the rook to take the king, got %v", err)
+	}
+	if result, over := (Antichess{}).GameOver(game); !over || result.Outcome != BlackWon || result.Termination != VariantRule {
+		t.Errorf("Expected Black to win by losing all pieces, got %+v", result)
+	}
+}
+
+func TestAntichess_castling_is_not_allowed(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Antichess{}, "4k3/pppppppp/8/8/8/8/PPPPPPPP/4K2R w K - 0 1")
+	if _, err := game.move(Move{From: Square{"E", 1}, To: Square{"G", 1}}, White); !errors.Is(err, ErrForbiddenByVariant) {
+		t.Errorf("Expected castling to be forbidden, got %v", err)
+	}
+}
+
+func TestAntichess_stale_mate_wins(t *testing.T) {
+	defer quiet()()
+	// the black pawn is blocked and has nothing to take
+	game := newVariantGameFromFEN(t, Antichess{}, "8/8/8/8/8/p7/P7/8 b - - 0 1")
+	if result, over := (Antichess{}).GameOver(game); !over || result.Outcome != BlackWon || result.Termination != Stalemate {
+		t.Errorf("Expected Black to win by being stale mated, got %+v", result)
+	}
+}
+
+func TestPerft_antichess(t *testing.T) {
+	defer quiet()()
+	position, err := ParseFEN(StartingPositionFEN)
+	if err != nil {
+		t.Fatalf("Failed to parse FEN, %v", err)
+	}
+	position.Board.variant = Antichess{}
+	for i, expected := range []int{20, 400, 8067} {
+		if nodes := Perft(position.Board, White, i+1); nodes != expected {
+			t.Errorf("Expected antichess perft(%v) to be %v, got %v", i+1, expected, nodes)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package chess
+
+import "fmt"
+
+// captures explode, removing the capturer and every piece but pawns on the 8 surrounding squares. Kings can not
+// capture, touching kings can not check each other and exploding the enemy king wins the game
+type Atomic struct {
+	Standard
+}
+
+func (Atomic) Name() string {
+	return "Atomic"
+}
+
+// kings can not capture, they would explode themselves
+func (Atomic) AllowMove(b *Board, p *Piece, move Move) error {
+	if p.Type != King {
+		return nil
+	}
+	if enemyAtTarget, _ := b.targetSquareOccupiedByEnemy(move.To.Column, move.To.Row, p); enemyAtTarget {
+		return illegalMove(ForbiddenByVariant, "kings can not capture in atomic")
+	}
+	return nil
+}
+
+func (Atomic) AfterCapture(b *Board, capturer *Piece, captured *Piece) {
+	centre := capturer.CurrentSquare
+	capturer.InPlay = false
+	for _, pieces := range [][]Piece{b.WhitePieces, b.BlackPieces} {
+		for i := range pieces {
+			piece := &pieces[i]
+			if piece.InPlay && piece.Type != Pawn && b.squaresTouch(piece.CurrentSquare, centre) {
+				piece.InPlay = false
+			}
+		}
+	}
+}
+
+// returns true if the squares are next to each other (including diagonally)
+func (b *Board) squaresTouch(a Square, c Square) bool {
+	columnDiff := b.getColumnIndex(a.Column) - b.getColumnIndex(c.Column)
+	rowDiff := a.Row - c.Row
+	return columnDiff >= -1 && columnDiff <= 1 && rowDiff >= -1 && rowDiff <= 1 && a != c
+}
+
+// kings can not capture, so neither a touching nor any other enemy king gives check
+func (Atomic) KingIsInCheck(b *Board, colour Colour) (bool, []Piece) {
+	king, enemyKing := b.getKing(colour), b.getKing(opponentOf(colour))
+	if king.Type != King || !king.InPlay {
+		return false, []Piece{}
+	}
+	if enemyKing.Type == King && enemyKing.InPlay && b.squaresTouch(king.CurrentSquare, enemyKing.CurrentSquare) {
+		return false, []Piece{}
+	}
+	_, attackers := b.kingAttackers(colour)
+	checking := make([]Piece, 0)
+	for _, attacker := range attackers {
+		if attacker.Type != King {
+			checking = append(checking, attacker)
+		}
+	}
+	return len(checking) > 0, checking
+}
+
+// the move is made on a copy of the board, it is legal if the own king survives and either the enemy king
+// explodes or the own king is not in check
+func (a Atomic) MoveIsLegal(b *Board, p *Piece, target Square) bool {
+	if king := b.getKing(p.Colour); king.Type != King || !king.InPlay {
+		return false
+	}
+	if enemyKing := b.getKing(opponentOf(p.Colour)); enemyKing.Type != King || !enemyKing.InPlay {
+		return false // the game is over
+	}
+	clone := b.Clone()
+	_, piece := clone.GetPieceAtSquare(p.CurrentSquare.Column, p.CurrentSquare.Row)
+	occupied, captured := clone.GetPieceAtSquare(target.Column, target.Row)
+	if occupied && !piece.enemyTo(captured) {
+		return true // castling in chess960, or a move the piece can not make anyway
+	}
+	if !occupied && piece.Type == Pawn && target.Column != piece.CurrentSquare.Column {
+		occupied, captured = clone.GetPieceAtSquare(target.Column, piece.CurrentSquare.Row) // en passant
+		occupied = occupied && captured.Type == Pawn && piece.enemyTo(captured)
+	}
+	piece.CurrentSquare = target
+	if occupied {
+		captured.InPlay = false
+		a.AfterCapture(clone, piece, captured)
+	}
+	if king := clone.getKing(p.Colour); !king.InPlay {
+		return false
+	}
+	if enemyKing := clone.getKing(opponentOf(p.Colour)); !enemyKing.InPlay {
+		return true
+	}
+	isCheck, _ := a.KingIsInCheck(clone, p.Colour)
+	return !isCheck
+}
+
+func (Atomic) GameOver(g *Game) (Result, bool) {
+	for _, colour := range []Colour{White, Black} {
+		if king := g.Board.getKing(colour); king.Type != King || !king.InPlay {
+			return winFor(opponentOf(colour), VariantRule, fmt.Sprintf("%v king exploded", colour)), true
+		}
+	}
+	if len(g.LegalMoves()) == 0 {
+		if isCheck, _ := g.Board.kingIsInCheck(g.NextToMove); isCheck {
+			return winFor(opponentOf(g.NextToMove), Checkmate, fmt.Sprintf("%v is in mate", g.NextToMove)), true
+		}
+		return drawBy(Stalemate, "Stale mate"), true
+	}
+	if g.Board.hasInsufficientMaterial() {
+		return drawBy(InsufficientMaterial, "Insufficient material"), true
+	}
+	return Result{}, false
+}
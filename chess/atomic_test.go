@@ -0,0 +1,109 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAtomic_capture_explodes_surrounding_pieces_except_pawns(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Atomic{}, "4k3/8/8/2nrp3/1N6/8/8/4K3 w - - 0 1")
+	// the knight takes the rook, the knight on C5 explodes with it but the pawn on E5 survives
+	if err := playMoves(game, []Move{{From: Square{"B", 4}, To: Square{"D", 5}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	expectedStateOfBoard := `
+	.  .  .  .  ♚  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  ♟  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  ♔  .  .  .
+	`
+	if err := assertExpectedBoardState(expectedStateOfBoard, game.Board); err != nil {
+		t.Errorf("Failed to assert expected board state, %v (Visible whitespace is ignored, something else differs!", err.Error())
+	}
+}
+
+func TestAtomic_kings_can_not_capture(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Atomic{}, "4k3/8/8/8/8/8/3p4/4K3 w - - 0 1")
+	if _, err := game.move(Move{From: Square{"E", 1}, To: Square{"D", 2}}, White); !errors.Is(err, ErrForbiddenByVariant) {
+		t.Errorf("Expected the king not to be allowed to capture, got %v", err)
+	}
+}
+
+func TestAtomic_touching_kings_do_not_check(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Atomic{}, "8/8/8/8/8/3k4/8/r3K3 w - - 0 1")
+	if isCheck, _ := game.Board.kingIsInCheck(White); !isCheck {
+		t.Errorf("Expected the white king to be in check by the rook")
+	}
+	// moving next to the black king is a way out of check
+	if err := playMoves(game, []Move{{From: Square{"E", 1}, To: Square{"E", 2}}}); err != nil {
+		t.Errorf("Expected the king to be allowed to move next to the enemy king, got %v", err)
+	}
+	if isCheck, _ := game.Board.kingIsInCheck(White); isCheck {
+		t.Errorf("Expected touching kings not to be in check")
+	}
+}
+
+func TestAtomic_exploding_the_king_wins(t *testing.T) {
+	defer quiet()()
+	// capturing the pawn next to the king explodes the king, even though the own king is in check
+	game := newVariantGameFromFEN(t, Atomic{}, "4k3/3p4/8/8/8/8/3Q4/r3K3 w - - 0 1")
+	if err := playMoves(game, []Move{{From: Square{"D", 2}, To: Square{"D", 7}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	if !isGameOver(game) {
+		t.Errorf("Expected the game to be over")
+	}
+	if winner, ok := game.result.Winner(); !ok || winner != White || game.result.Reason != "Black king exploded" {
+		t.Errorf("Expected White to win by exploding the king, got %+v", game.result)
+	}
+}
+
+func TestAtomic_exploding_the_own_king_is_not_legal(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Atomic{}, "4k3/8/8/8/8/8/3p4/3QK3 w - - 0 1")
+	if _, err := game.move(Move{From: Square{"D", 1}, To: Square{"D", 2}}, White); !errors.Is(err, ErrLeavesKingInCheck) {
+		t.Errorf("Expected a capture next to the own king to be illegal, got %v", err)
+	}
+}
+
+// known perft numbers for atomic chess (as used by python-chess)
+func TestPerft_atomic(t *testing.T) {
+	defer quiet()()
+	cases := []struct {
+		name     string
+		fen      string
+		expected []int // by depth, starting at depth 1
+	}{
+		{"programfox 1", "rn2kb1r/1pp1p2p/p2q1pp1/3P4/2P3b1/4PN2/PP3PPP/R2QKB1R b KQkq - 0 1", []int{40, 1238, 45237}},
+		{"programfox 2", "rn1qkb1r/p5pp/2p5/3p4/N3P3/5P2/PPP4P/R1BQK3 w Qkq - 0 1", []int{28, 833, 23353}},
+	}
+	if !testing.Short() {
+		cases = append(cases, struct {
+			name     string
+			fen      string
+			expected []int
+		}{"starting position", StartingPositionFEN, []int{20, 400, 8902, 197326}})
+	}
+	for _, c := range cases {
+		position, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Errorf("Failed to parse FEN, %v", err)
+			continue
+		}
+		position.Board.variant = Atomic{}
+		for i, expected := range c.expected {
+			if nodes := Perft(position.Board, position.NextToMove, i+1); nodes != expected {
+				t.Errorf("Expected atomic perft(%v) of %v to be %v, got %v", i+1, c.name, expected, nodes)
+			}
+		}
+	}
+}
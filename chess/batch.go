@@ -0,0 +1,199 @@
+package chess
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// creates the player for one side of a game. Every game gets new players, so players need not be safe for
+// concurrent use
+type PlayerFactory func(colour Colour) Player
+
+// a BoardVisualizer that shows nothing, for games nobody watches
+type NullVisualizer struct{}
+
+func (v *NullVisualizer) VisualizeState(b *Board) {}
+
+type BatchOptions struct {
+	Games    int
+	Parallel int // the number of games played at the same time, 1 if not set
+	White    PlayerFactory
+	Black    PlayerFactory
+	// creates each game, a standard game from the starting position if nil. The visualizer should usually be a
+	// NullVisualizer
+	NewGame func(white Player, black Player, visualizer BoardVisualizer) (*Game, error)
+	PGN     io.Writer         // every game is written to it when not nil, in the order the games finish
+	Tags    map[string]string // PGN tags written with every game, e.g. Event, White and Black. Round is the game number
+}
+
+// the outcome of a batch of games
+type BatchStats struct {
+	Games        int
+	WhiteWins    int
+	BlackWins    int
+	Draws        int
+	Terminations map[Termination]int
+	Plies        int // half moves in all games
+	Duration     time.Duration
+}
+
+// plays the games of the batch on Parallel goroutines and returns the statistics. The first error creating or
+// writing a game stops the batch
+func RunBatch(options BatchOptions) (BatchStats, error) {
+	if options.Games < 1 {
+		return BatchStats{}, fmt.Errorf("a batch needs at least one game, got %v", options.Games)
+	}
+	if options.White == nil || options.Black == nil {
+		return BatchStats{}, fmt.Errorf("a batch needs a player factory for both colours")
+	}
+	newGame := options.NewGame
+	if newGame == nil {
+		newGame = newStandardGame
+	}
+
+	stats := BatchStats{Terminations: map[Termination]int{}}
+	var mu sync.Mutex
+	started := time.Now()
+	err := playInParallel(options.Games, options.Parallel, func(i int) error {
+		game, err := newGame(options.White(White), options.Black(Black), &NullVisualizer{})
+		if err != nil {
+			return err
+		}
+		game.Start()
+		mu.Lock()
+		defer mu.Unlock()
+		return stats.add(game, i+1, options)
+	})
+	stats.Duration = time.Since(started)
+	return stats, err
+}
+
+func newStandardGame(white Player, black Player, visualizer BoardVisualizer) (*Game, error) {
+	return NewGame(white, black, visualizer), nil
+}
+
+// calls play with 0 to count-1 on parallel goroutines (at least one). No more calls are started once play has
+// returned an error and the first error is returned
+func playInParallel(count int, parallel int, play func(i int) error) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+	var mu sync.Mutex
+	var firstErr error
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallel; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := play(i); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := 0; i < count && !failed(); i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return firstErr
+}
+
+// counts the finished game and writes it to the PGN writer of the options
+func (s *BatchStats) add(game *Game, round int, options BatchOptions) error {
+	s.Games++
+	s.Plies += len(game.History)
+	result := game.Result()
+	switch result.Outcome {
+	case WhiteWon:
+		s.WhiteWins++
+	case BlackWon:
+		s.BlackWins++
+	case Drawn:
+		s.Draws++
+	}
+	s.Terminations[result.Termination]++
+	if options.PGN == nil {
+		return nil
+	}
+	tags := map[string]string{"Round": fmt.Sprint(round)}
+	for name, value := range options.Tags {
+		tags[name] = value
+	}
+	return WritePGN(options.PGN, game, tags)
+}
+
+// returns the average number of full moves per game
+func (s BatchStats) AverageLength() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Plies) / 2 / float64(s.Games)
+}
+
+// returns the number of games finished per second
+func (s BatchStats) GamesPerSecond() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Games) / s.Duration.Seconds()
+}
+
+// returns the share of the games (0 to 1) with the outcome
+func (s BatchStats) Rate(outcome Outcome) float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	switch outcome {
+	case WhiteWon:
+		return float64(s.WhiteWins) / float64(s.Games)
+	case BlackWon:
+		return float64(s.BlackWins) / float64(s.Games)
+	case Drawn:
+		return float64(s.Draws) / float64(s.Games)
+	default:
+		return 0
+	}
+}
+
+// returns a report of the batch, one statistic per line
+func (s BatchStats) String() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Games: %v in %v (%.1f games/s)\n", s.Games, s.Duration.Round(time.Millisecond), s.GamesPerSecond())
+	fmt.Fprintf(&text, "White: %v wins, %v draws, %v losses (%.1f%% / %.1f%% / %.1f%%)\n",
+		s.WhiteWins, s.Draws, s.BlackWins, 100*s.Rate(WhiteWon), 100*s.Rate(Drawn), 100*s.Rate(BlackWon))
+	fmt.Fprintf(&text, "Black: %v wins, %v draws, %v losses (%.1f%% / %.1f%% / %.1f%%)\n",
+		s.BlackWins, s.Draws, s.WhiteWins, 100*s.Rate(BlackWon), 100*s.Rate(Drawn), 100*s.Rate(WhiteWon))
+	fmt.Fprintf(&text, "Average length: %.1f moves\n", s.AverageLength())
+	terminations := make([]Termination, 0, len(s.Terminations))
+	for termination := range s.Terminations {
+		terminations = append(terminations, termination)
+	}
+	sort.Slice(terminations, func(i, j int) bool {
+		if s.Terminations[terminations[i]] != s.Terminations[terminations[j]] {
+			return s.Terminations[terminations[i]] > s.Terminations[terminations[j]]
+		}
+		return terminations[i] < terminations[j]
+	})
+	text.WriteString("Terminations:\n")
+	for _, termination := range terminations {
+		fmt.Fprintf(&text, "  %v: %v (%.1f%%)\n", termination, s.Terminations[termination],
+			100*float64(s.Terminations[termination])/float64(s.Games))
+	}
+	return text.String()
+}
@@ -0,0 +1,78 @@
+package chess
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func foolsMatePlayer(colour Colour) Player {
+	if colour == White {
+		return &scriptedPlayer{colour: White, moves: []Move{
+			{From: Square{"F", 2}, To: Square{"F", 3}},
+			{From: Square{"G", 2}, To: Square{"G", 4}},
+		}}
+	}
+	return &scriptedPlayer{colour: Black, moves: []Move{
+		{From: Square{"E", 7}, To: Square{"E", 5}},
+		{From: Square{"D", 8}, To: Square{"H", 4}},
+	}}
+}
+
+func TestRunBatch(t *testing.T) {
+	defer quiet()()
+	var pgn bytes.Buffer
+	stats, err := RunBatch(BatchOptions{
+		Games:    10,
+		Parallel: 3,
+		White:    foolsMatePlayer,
+		Black:    foolsMatePlayer,
+		PGN:      &pgn,
+		Tags:     map[string]string{"Event": "Batch test"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to run the batch, %v", err)
+	}
+	if stats.Games != 10 || stats.BlackWins != 10 || stats.Terminations[Checkmate] != 10 {
+		t.Errorf("Expected 10 checkmates by black, got %+v", stats)
+	}
+	if stats.AverageLength() != 2 || stats.Rate(BlackWon) != 1 || stats.Rate(Drawn) != 0 {
+		t.Errorf("Expected 2 moves per game and only black wins, got %v", stats)
+	}
+	if !strings.Contains(stats.String(), "Checkmate: 10 (100.0%)") {
+		t.Errorf("Expected the termination breakdown in the report, got\n%v", stats)
+	}
+
+	games, err := ParsePGN(&pgn)
+	if err != nil {
+		t.Fatalf("Failed to parse the written PGN, %v", err)
+	}
+	if len(games) != 10 {
+		t.Fatalf("Expected 10 games in the PGN, got %v", len(games))
+	}
+	rounds := map[string]bool{}
+	for _, game := range games {
+		rounds[game.Tags["Round"]] = true
+		if game.Tags["Event"] != "Batch test" || game.Result != "0-1" || strings.Join(game.Moves, " ") != "f3 e5 g4 Qh4#" {
+			t.Errorf("Unexpected game %+v", game)
+		}
+	}
+	if len(rounds) != 10 {
+		t.Errorf("Expected every game to have its own round, got %v", rounds)
+	}
+}
+
+func TestRunBatch_stops_on_the_first_error(t *testing.T) {
+	defer quiet()()
+	_, err := RunBatch(BatchOptions{
+		Games: 5,
+		White: foolsMatePlayer,
+		Black: foolsMatePlayer,
+		NewGame: func(white Player, black Player, visualizer BoardVisualizer) (*Game, error) {
+			return NewGameFromFEN(white, black, visualizer, "not a fen")
+		},
+	})
+	if err == nil {
+		t.Errorf("Expected the invalid FEN to stop the batch")
+	}
+}
@@ -1,22 +1,18 @@
 package chess
 
 import (
-	"errors"
 	"fmt"
 )
 
 func moveBishop(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
-	if p.Type != bishop {
+	if p.Type != Bishop {
 		return nil, fmt.Errorf("MoveBishop called on piece of type %v", p.Type)
 	}
 	if !p.InPlay {
-		return nil, errors.New("Piece is not in play")
-	}
-	if !dryRun && !p.MoveIsLegal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("move is not legal")
+		return nil, illegalMove(PieceNotInPlay, "Piece is not in play")
 	}
 	if !p.moveIsDiagonal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("bishops can only move diagonally")
+		return nil, illegalMove(InvalidPieceMove, "bishops can only move diagonally")
 	}
 	if _, err := p.moveJumpsOverPieces(targetColumn, targetRow, b); err != nil {
 		return nil, err
@@ -24,7 +20,7 @@ func moveBishop(targetColumn string, targetRow int, b *Board, p *Piece, dryRun b
 
 	occupied, pieceAtTarget := b.GetPieceAtSquare(targetColumn, targetRow)
 	if occupied && !p.enemyTo(pieceAtTarget) {
-		return nil, fmt.Errorf("%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
+		return nil, illegalMove(SquareOccupied, "%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
 	} else if occupied && p.enemyTo(pieceAtTarget) {
 		if !dryRun {
 			p.takeAt(targetColumn, targetRow, pieceAtTarget, b)
@@ -5,7 +5,7 @@ import (
 )
 
 func TestMoveBishop_can_move_diagonally(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// CREATE START SCENARIO (move pawn out of the way to test the bishop)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
 	// bP  bP  bP  bP  ..  bP  bP  bP
@@ -15,7 +15,7 @@ func TestMoveBishop_can_move_diagonally(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
 	moves := []Move{whiteMove1}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -45,7 +45,7 @@ func TestMoveBishop_can_move_diagonally(t *testing.T) {
 }
 
 func TestMoveBishop_can_NOT_move_straight(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// CREATE START SCENARIO (get bishop out to B5)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
 	// bP  bP  bP  bP  bP  bP  bP  bP
@@ -55,8 +55,8 @@ func TestMoveBishop_can_NOT_move_straight(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  ..  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "F", Row: 1}, Square{Column: "B", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "F", Row: 1}, To: Square{Column: "B", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -76,7 +76,7 @@ func TestMoveBishop_can_NOT_move_straight(t *testing.T) {
 }
 
 func TestGetValidBishopMoves(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// CREATE START SCENARIO (get bishop out to B5)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
 	// bP  bP  bP  bP  bP  bP  bP  bP
@@ -86,8 +86,8 @@ func TestGetValidBishopMoves(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  ..  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "F", Row: 1}, Square{Column: "B", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "F", Row: 1}, To: Square{Column: "B", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -124,7 +124,7 @@ func TestGetValidBishopMoves(t *testing.T) {
 	if potentialTakes[0].GetValue() != 1 {
 		t.Errorf("Expected potential take to be worth 1, got %v", potentialTakes[0].GetValue())
 	}
-	if potentialTakes[0].Colour != Black || potentialTakes[0].Type != pawn ||
+	if potentialTakes[0].Colour != Black || potentialTakes[0].Type != Pawn ||
 		potentialTakes[0].CurrentSquare.Column != "D" || potentialTakes[0].CurrentSquare.Row != 7 {
 		t.Errorf("Expected potential take to be a black pawn at E4, got %v", potentialTakes[0])
 
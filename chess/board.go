@@ -22,11 +22,32 @@ type Board struct {
 	whitesLastMove LastMove
 	blacksLastMove LastMove
 	columns        []string
-}
+	// the columns of the rooks each colour may castle with, indexed by colour and castleSide
+	castlingRookColumns [2][2]string
+	chess960            bool      // castling is done by moving the king onto its own rook
+	variant             Variant   // nil means standard chess
+	pockets             [2][6]int // the number of pieces each colour can drop, indexed by colour and piece type
+	lastMoveColour      Colour    // the colour that made the most recent move
+}
+type MoveKind int64
+
+const (
+	PieceMove MoveKind = iota // a piece on the board moves from one square to another
+	Drop                      // a piece from the pocket is put on an empty square (crazyhouse)
+)
+
 type Move struct {
-	From Square
-	To   Square
+	From  Square
+	To    Square
+	Kind  MoveKind
+	Piece PieceType // the piece to drop when Kind is Drop
 }
+
+// creates a move that drops a piece from the pocket on the given square
+func NewDrop(pieceType PieceType, to Square) Move {
+	return Move{To: to, Kind: Drop, Piece: pieceType}
+}
+
 type MoveResultAction int64
 
 const (
@@ -39,12 +60,16 @@ type MoveResult struct {
 	Piece  *Piece // taken piece so we can calculate and compare value of moves
 }
 
-func newBoard() *Board {
-	board := &Board{}
-	board.init()
+var standardBackRow = [8]PieceType{Rook, Knight, Bishop, Queen, King, Bishop, Knight, Rook}
+
+// creates and returns a board with the pieces in the standard starting position
+func NewBoard() *Board {
+	board := newEmptyBoard()
+	board.placePiecesOnBoard(standardBackRow)
 	return board
 }
-func (b *Board) init() {
+func newEmptyBoard() *Board {
+	b := &Board{}
 	b.columns = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
 	rows := []int{8, 7, 6, 5, 4, 3, 2, 1}
 
@@ -53,7 +78,8 @@ func (b *Board) init() {
 			b.Squares[i*8+j] = Square{column, row}
 		}
 	}
-	b.placePiecesOnBoard()
+	b.castlingRookColumns = [2][2]string{{"H", "A"}, {"H", "A"}}
+	return b
 }
 
 func (b *Board) getPosition() string {
@@ -66,6 +92,9 @@ func (b *Board) getPosition() string {
 			position += "--"
 		}
 	}
+	if b.pockets != [2][6]int{} {
+		position += b.pocketsFEN()
+	}
 	return position
 }
 func (b *Board) targetSquareOccupiedByEnemy(targetColumn string, targetRow int, p *Piece) (bool, *Piece) {
@@ -97,7 +126,43 @@ func (b *Board) isStaleMate(colour Colour) bool {
 	// if no valid moves are found, then it is a stale mate
 	return true
 }
+
+// returns true if neither side can possibly mate (K vs K, K and a minor piece vs K or only bishops on the same square colour)
+func (b *Board) hasInsufficientMaterial() bool {
+	var minorPieces []Piece
+	for _, pieces := range [][]Piece{b.WhitePieces, b.BlackPieces} {
+		for _, piece := range pieces {
+			if !piece.InPlay || piece.Type == King {
+				continue
+			}
+			if piece.Type != Knight && piece.Type != Bishop {
+				return false
+			}
+			minorPieces = append(minorPieces, piece)
+		}
+	}
+	if len(minorPieces) <= 1 {
+		return true
+	}
+	squareColour := -1
+	for _, piece := range minorPieces {
+		if piece.Type != Bishop {
+			return false
+		}
+		pieceSquareColour := (b.getColumnIndex(piece.CurrentSquare.Column) + piece.CurrentSquare.Row) % 2
+		if squareColour != -1 && pieceSquareColour != squareColour {
+			return false
+		}
+		squareColour = pieceSquareColour
+	}
+	return true
+}
 func (b *Board) kingIsInMate(colour Colour) bool {
+	if b.variant != nil {
+		// variants can change what a legal move is (e.g. drops that block a check), so try them all
+		isCheck, _ := b.kingIsInCheck(colour)
+		return isCheck && len(b.LegalMovesFor(colour)) == 0
+	}
 	king := b.getKing(colour)
 
 	isCheck, enemies := b.kingIsInCheck(colour)
@@ -209,8 +274,71 @@ func couldAnyBlockCheckAt(pieces []Piece, targetColumn string, targetRow int, b
 	}
 	return false
 }
+
+// returns the variant the board is set up for
+func (b *Board) getVariant() Variant {
+	if b.variant == nil {
+		return Standard{}
+	}
+	return b.variant
+}
+
+// lets the variant react to a capture, e.g. explosions in atomic
+func (b *Board) afterCapture(capturer *Piece, captured *Piece) {
+	if handler, ok := b.variant.(CaptureHandler); ok {
+		handler.AfterCapture(b, capturer, captured)
+	}
+}
+
+// returns true if the king may not be left in check, which is the case unless the variant says otherwise
+func (b *Board) kingIsRoyal() bool {
+	return b.variant == nil || b.variant.KingIsRoyal()
+}
+
+// temporarily moves the piece to the target square (taking any enemy there), calls check and restores the board
+func (b *Board) withTempMove(p *Piece, target Square, check func() bool) bool {
+	currentSquare := p.CurrentSquare
+	targetSquareOccupied, pieceAtTargetSquare := b.GetPieceAtSquare(target.Column, target.Row)
+	if !targetSquareOccupied && p.Type == Pawn && target.Column != currentSquare.Column {
+		// en passant, the pawn that is taken is beside the pawn, not on the target square
+		targetSquareOccupied, pieceAtTargetSquare = b.GetPieceAtSquare(target.Column, currentSquare.Row)
+		targetSquareOccupied = targetSquareOccupied && pieceAtTargetSquare.Type == Pawn
+	}
+	enemyAtTargetSquare := targetSquareOccupied && p.enemyTo(pieceAtTargetSquare)
+	if enemyAtTargetSquare {
+		pieceAtTargetSquare.InPlay = false // temp take
+	}
+	p.CurrentSquare = target // temp move
+	result := check()
+	if enemyAtTargetSquare {
+		pieceAtTargetSquare.InPlay = true // reset temp take
+	}
+	p.CurrentSquare = currentSquare // reset temp move
+	return result
+}
+
+// returns true if the king of the given colour is in check, taking the rules of the variant into account
+func (b *Board) IsCheck(colour Colour) bool {
+	isCheck, _ := b.kingIsInCheck(colour)
+	return isCheck
+}
+
 func (b *Board) kingIsInCheck(colour Colour) (bool, []Piece) {
+	if !b.kingIsRoyal() {
+		return false, []Piece{} // there is no check when kings are ordinary pieces
+	}
+	if rules, ok := b.variant.(CheckRules); ok {
+		return rules.KingIsInCheck(b, colour)
+	}
+	return b.kingAttackers(colour)
+}
+
+// returns true and the enemy pieces that could move to the square of the king of the given colour
+func (b *Board) kingAttackers(colour Colour) (bool, []Piece) {
 	king := b.getKing(colour)
+	if king.Type != King || !king.InPlay {
+		return false, []Piece{} // a king that is not on the board can not be in check (e.g. white in horde)
+	}
 	dryRun := true
 	isCheck := false
 	enemies := make([]Piece, 0)
@@ -246,33 +374,12 @@ func (b *Board) checkPathForOccupiedSquaresStraightLeft(targetColumn string, tar
 	for i := currentColumnIndex - 1; i > columnIndex; i-- { // always at least one square between
 		occupied, _ := b.GetPieceAtSquare(b.columns[i], targetRow)
 		if occupied {
-			return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
+			return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
 		}
 		squaresInBetween = append(squaresInBetween, Square{Column: b.columns[i], Row: targetRow})
 	}
 	return squaresInBetween, nil
 }
-func (b *Board) checkPathForSquaresUnderAttackStraightLeft(targetColumn string, targetRow int, p *Piece) error {
-	// starting from current position, check if any square is under attack
-	currentColumnIndex := b.getColumnIndex(p.CurrentSquare.Column)
-	columnIndex := b.getColumnIndex(targetColumn)
-	for i := currentColumnIndex - 1; i > columnIndex; i-- { // always at least one square between
-		var activeEnemies []Piece
-		if p.Colour == White {
-			activeEnemies = b.BlackPieces
-		} else {
-			activeEnemies = b.WhitePieces
-		}
-		for _, enemy := range activeEnemies {
-			if enemy.InPlay {
-				if enemy.couldMoveTo(b.columns[i], targetRow, b) {
-					return fmt.Errorf("enemy %v can go to %v%v", p.Type, targetColumn, targetRow)
-				}
-			}
-		}
-	}
-	return nil
-}
 func (b *Board) checkPathForOccupiedSquaresStraigthRight(targetColumn string, targetRow int, p *Piece) ([]Square, error) {
 	// starting from current position, check if any pieces in the way
 	currentColumnIndex := b.getColumnIndex(p.CurrentSquare.Column)
@@ -281,40 +388,19 @@ func (b *Board) checkPathForOccupiedSquaresStraigthRight(targetColumn string, ta
 	for i := currentColumnIndex + 1; i < columnIndex; i++ { // always at least one square between
 		occupied, _ := b.GetPieceAtSquare(b.columns[i], targetRow)
 		if occupied {
-			return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
+			return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
 		}
 		squaresInBetween = append(squaresInBetween, Square{Column: b.columns[i], Row: targetRow})
 	}
 	return squaresInBetween, nil
 }
-func (b *Board) checkPathForSquaresUnderAttackStraightRight(targetColumn string, targetRow int, p *Piece) error {
-	// starting from current position, check if any square is under attack
-	currentColumnIndex := b.getColumnIndex(p.CurrentSquare.Column)
-	columnIndex := b.getColumnIndex(targetColumn)
-	for i := currentColumnIndex + 1; i < columnIndex; i++ { // always at least one square between
-		var activeEnemies []Piece
-		if p.Colour == White {
-			activeEnemies = b.BlackPieces
-		} else {
-			activeEnemies = b.WhitePieces
-		}
-		for _, enemy := range activeEnemies {
-			if enemy.InPlay {
-				if enemy.couldMoveTo(b.columns[i], targetRow, b) {
-					return fmt.Errorf("enemy %v can go to %v%v", p.Type, targetColumn, targetRow)
-				}
-			}
-		}
-	}
-	return nil
-}
 func (b *Board) checkPathForOccupiedSquaresStraightDown(targetColumn string, targetRow int, p *Piece) ([]Square, error) {
 	// starting from current position, check if any pieces in the way
 	var squaresInBetween []Square
 	for i := p.CurrentSquare.Row - 1; i > targetRow; i-- { // always at least one square between
 		occupied, _ := b.GetPieceAtSquare(targetColumn, i)
 		if occupied {
-			return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
+			return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
 		}
 		squaresInBetween = append(squaresInBetween, Square{Column: targetColumn, Row: i})
 	}
@@ -326,7 +412,7 @@ func (b *Board) checkPathForOccupiedSquaresStraightUp(targetColumn string, targe
 	for i := p.CurrentSquare.Row + 1; i < targetRow; i++ { // always at least one square between
 		occupied, _ := b.GetPieceAtSquare(targetColumn, i)
 		if occupied {
-			return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
+			return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
 		}
 		squaresInBetween = append(squaresInBetween, Square{Column: targetColumn, Row: i})
 	}
@@ -345,7 +431,7 @@ func (b *Board) checkPathForOccupiedSquaresUpRight(targetColumn string, targetRo
 		if checkRow > p.CurrentSquare.Row {
 			occupied, by := b.GetPieceAtSquare(b.columns[index], checkRow)
 			if occupied {
-				return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces %v", p.Type, targetColumn, targetRow, by)
+				return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces %v", p.Type, targetColumn, targetRow, by)
 			}
 			squaresInBetween = append(squaresInBetween, Square{Column: b.columns[index], Row: checkRow})
 		}
@@ -365,7 +451,7 @@ func (b *Board) checkPathForOccupiedSquaresDownRight(targetColumn string, target
 		if checkRow < p.CurrentSquare.Row {
 			occupied, _ := b.GetPieceAtSquare(b.columns[index], checkRow)
 			if occupied {
-				return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
+				return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
 			}
 			squaresInBetween = append(squaresInBetween, Square{Column: b.columns[index], Row: checkRow})
 		}
@@ -386,7 +472,7 @@ func (b *Board) checkPathForOccupiedSquaresUpLeft(targetColumn string, targetRow
 			occupied, _ := b.GetPieceAtSquare(b.columns[index], checkRow)
 			if occupied {
 
-				return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
+				return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
 			}
 			squaresInBetween = append(squaresInBetween, Square{Column: b.columns[index], Row: checkRow})
 		}
@@ -406,7 +492,7 @@ func (b *Board) checkPathForOccupiedSquaresDownLeft(targetColumn string, targetR
 		if checkRow < p.CurrentSquare.Row {
 			occupied, _ := b.GetPieceAtSquare(b.columns[index], checkRow)
 			if occupied {
-				return nil, fmt.Errorf("%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
+				return nil, illegalMove(PathBlocked, "%v cant move to square %v%v, cannot jump over other pieces", p.Type, targetColumn, targetRow)
 			}
 			squaresInBetween = append(squaresInBetween, Square{Column: b.columns[index], Row: checkRow})
 		}
@@ -422,16 +508,30 @@ func (b *Board) getSquare(column string, row int) (Square, error) {
 	}
 	return Square{}, errors.New("Square not found")
 }
+
+// returns the most recent move made on the board, the second return value is false if no move has been made
+func (b *Board) PreviousMove() (Move, bool) {
+	lastMove := b.whitesLastMove
+	if b.lastMoveColour == Black {
+		lastMove = b.blacksLastMove
+	}
+	if lastMove.Move == nil {
+		return Move{}, false
+	}
+	return *lastMove.Move, true
+}
+
+// returns the king of the given colour, or an empty piece (check Type) if the colour has no king (e.g. white in horde)
 func (b *Board) getKing(colour Colour) *Piece {
 	if colour == White {
 		for i, piece := range b.WhitePieces {
-			if piece.Type == king {
+			if piece.Type == King {
 				return &b.WhitePieces[i]
 			}
 		}
 	} else {
 		for i, piece := range b.BlackPieces {
-			if piece.Type == king {
+			if piece.Type == King {
 				return &b.BlackPieces[i]
 			}
 		}
@@ -459,50 +559,47 @@ func (b *Board) getColumnIndex(targetColumn string) int {
 func (b *Board) getColumnStringByIndex(columnIndex int) string {
 	return b.columns[columnIndex]
 }
-func (board *Board) placePiecesOnBoard() {
+
+// places the pieces of the given back row (white on row 1 and black mirrored on row 8) and all pawns
+func (board *Board) placePiecesOnBoard(backRow [8]PieceType) {
 
 	isAlive := true
 	hasMoved := false
+	promoted := false
 	for _, square := range board.Squares {
-
-		if square.Row == 1 && (square.Column == "A" || square.Column == "H") { // place rooks
-			// place a white rook on the first square of the A and H columns
-			board.WhitePieces = append(board.WhitePieces, Piece{rook, square, White, isAlive, hasMoved})
-		} else if square.Row == 8 && (square.Column == "A" || square.Column == "H") {
-			// place a black rook on the eighth square of the A and H columns
-			board.BlackPieces = append(board.BlackPieces, Piece{rook, square, Black, isAlive, hasMoved})
-		} else if square.Row == 1 && (square.Column == "B" || square.Column == "G") { // place knight
-			// place a white knight on the first square of the B and G columns
-			board.WhitePieces = append(board.WhitePieces, Piece{knight, square, White, isAlive, hasMoved})
-		} else if square.Row == 8 && (square.Column == "B" || square.Column == "G") {
-			// place a black knight on the eighth square of the B and G columns
-			board.BlackPieces = append(board.BlackPieces, Piece{knight, square, Black, isAlive, hasMoved})
-		} else if square.Row == 1 && (square.Column == "C" || square.Column == "F") { // place bishops
-			// place a white bishop on the first square of the C and F columns
-			board.WhitePieces = append(board.WhitePieces, Piece{bishop, square, White, isAlive, hasMoved})
-		} else if square.Row == 8 && (square.Column == "C" || square.Column == "F") {
-			// place a black bishop on the eighth square of the C and F columns
-			board.BlackPieces = append(board.BlackPieces, Piece{bishop, square, Black, isAlive, hasMoved})
-		} else if square.Row == 1 && square.Column == "D" { // place queen
-			// place a white queen on the first square of the D column
-			board.WhitePieces = append(board.WhitePieces, Piece{queen, square, White, isAlive, hasMoved})
-		} else if square.Row == 8 && square.Column == "D" {
-			// place a black queen on the eighth square of the D column
-			board.BlackPieces = append(board.BlackPieces, Piece{queen, square, Black, isAlive, hasMoved})
-		} else if square.Row == 1 && square.Column == "E" { // place king
-			// place a white king on the first square of the E column
-			board.WhitePieces = append(board.WhitePieces, Piece{king, square, White, isAlive, hasMoved})
-		} else if square.Row == 8 && square.Column == "E" {
-			// place a black king on the eighth square of the E column
-			board.BlackPieces = append(board.BlackPieces, Piece{king, square, Black, isAlive, hasMoved})
-		} else if square.Row == 2 { // place pawns
-			// place a white pawn on the second square of every column
-			board.WhitePieces = append(board.WhitePieces, Piece{pawn, square, White, isAlive, hasMoved})
-		} else if square.Row == 7 {
-			// place a black pawn on the seventh square of every column
-			board.BlackPieces = append(board.BlackPieces, Piece{pawn, square, Black, isAlive, hasMoved})
+		pieceType := backRow[board.getColumnIndex(square.Column)]
+		switch square.Row {
+		case 1:
+			board.WhitePieces = append(board.WhitePieces, Piece{pieceType, square, White, isAlive, hasMoved, promoted})
+		case 2:
+			board.WhitePieces = append(board.WhitePieces, Piece{Pawn, square, White, isAlive, hasMoved, promoted})
+		case 7:
+			board.BlackPieces = append(board.BlackPieces, Piece{Pawn, square, Black, isAlive, hasMoved, promoted})
+		case 8:
+			board.BlackPieces = append(board.BlackPieces, Piece{pieceType, square, Black, isAlive, hasMoved, promoted})
 		}
 	}
+	// the rook left of the king castles queenside and the one right of it kingside
+	kingColumnIndex := -1
+	for i, pieceType := range backRow {
+		if pieceType == King {
+			kingColumnIndex = i
+		}
+	}
+	if kingColumnIndex == -1 {
+		return // no king, no castling
+	}
+	for i, pieceType := range backRow {
+		if pieceType != Rook {
+			continue
+		}
+		side := kingside
+		if i < kingColumnIndex {
+			side = queenside
+		}
+		board.castlingRookColumns[White][side] = board.columns[i]
+		board.castlingRookColumns[Black][side] = board.columns[i]
+	}
 }
 
 // returns the piece at the given square
@@ -532,27 +629,27 @@ func addToListIfValidSquare(b *Board, validSquares []Square, row int, columnInde
 func (b *Board) getMovesFor(p *Piece) map[Move]*MoveResult {
 	moves := map[Move]*MoveResult{}
 	switch p.Type {
-	case pawn:
+	case Pawn:
 		for move, result := range p.getValidPawnMoves(b) {
 			moves[move] = result
 		}
-	case knight:
+	case Knight:
 		for move, result := range p.getValidKnightMoves(b) {
 			moves[move] = result
 		}
-	case bishop:
+	case Bishop:
 		for move, result := range p.getValidBishopMoves(b) {
 			moves[move] = result
 		}
-	case rook:
+	case Rook:
 		for move, result := range p.getValidRookMoves(b) {
 			moves[move] = result
 		}
-	case queen:
+	case Queen:
 		for move, result := range p.getValidQueenMoves(b) {
 			moves[move] = result
 		}
-	case king:
+	case King:
 		for move, result := range p.getValidKingMoves(b) {
 			moves[move] = result
 		}
@@ -566,6 +663,9 @@ func (b *Board) GetAllMovesFor(Colour Colour) map[Move]*MoveResult {
 	moves := map[Move]*MoveResult{}
 	if Colour == White {
 		for _, piece := range b.WhitePieces {
+			if !piece.InPlay {
+				continue
+			}
 			m := b.getMovesFor(&piece)
 			for k := range m {
 				moves[k] = m[k]
@@ -574,11 +674,17 @@ func (b *Board) GetAllMovesFor(Colour Colour) map[Move]*MoveResult {
 		}
 	} else {
 		for _, piece := range b.BlackPieces {
+			if !piece.InPlay {
+				continue
+			}
 			m := b.getMovesFor(&piece)
 			for k := range m {
 				moves[k] = m[k]
 			}
 		}
 	}
+	for move, result := range b.getDropsFor(Colour) {
+		moves[move] = result
+	}
 	return moves
 }
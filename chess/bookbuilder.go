@@ -0,0 +1,232 @@
+package chess
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+type BookBuilderOptions struct {
+	MaxPly   int // the number of plies of each game that are added, 16 if not set
+	MinGames int // moves played in fewer games are left out, 1 if not set
+}
+
+// the results of the games a move was played in, from the view of the side that played it
+type BookMoveStats struct {
+	SAN    string
+	Move   uint16 // Polyglot encoding
+	Games  int
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+// the weight of the move in the book, 2 for each win and 1 for each draw as in Polyglot. Moves that only lost get
+// no weight and are never played from the book
+func (s BookMoveStats) weight() int {
+	return 2*s.Wins + s.Draws
+}
+
+type bookPosition struct {
+	fen   string
+	moves map[uint16]*BookMoveStats
+}
+
+// builds an opening book from games, counting how often each move was played in each position and how it scored
+type BookBuilder struct {
+	Options   BookBuilderOptions
+	Games     int // the games added
+	positions map[uint64]*bookPosition
+}
+
+func NewBookBuilder(options BookBuilderOptions) *BookBuilder {
+	if options.MaxPly < 1 {
+		options.MaxPly = 16
+	}
+	if options.MinGames < 1 {
+		options.MinGames = 1
+	}
+	return &BookBuilder{Options: options, positions: map[uint64]*bookPosition{}}
+}
+
+// adds the first MaxPly moves of the game. Polyglot books are for standard chess, so games of other variants are
+// rejected. Games without a result ("*") count towards the games but not the wins, draws and losses
+func (bb *BookBuilder) AddGame(pgn PGNGame) error {
+	if variant, ok := pgn.Tags["Variant"]; ok && !strings.EqualFold(variant, (Standard{}).Name()) {
+		return fmt.Errorf("%v games can not be added to a Polyglot book", variant)
+	}
+	start := pgn
+	start.Moves = nil
+	game, err := NewGameFromPGN(nil, nil, &NullVisualizer{}, start)
+	if err != nil {
+		return err
+	}
+	// the moves are resolved first, so a game that fails part-way adds nothing to the book
+	type bookMove struct {
+		key     uint64
+		fen     string
+		encoded uint16
+		san     string
+		colour  Colour
+	}
+	moves := []bookMove{}
+	for i, san := range pgn.Moves {
+		if i >= bb.Options.MaxPly {
+			break
+		}
+		colour := game.NextToMove
+		move, err := game.Board.ParseSAN(colour, san)
+		if err != nil {
+			return fmt.Errorf("move %v: %w", i+1, err)
+		}
+		encoded, err := game.Board.PolyglotMove(move)
+		if err != nil {
+			return fmt.Errorf("move %v: %w", i+1, err)
+		}
+		written, err := game.Board.MoveToSAN(colour, move)
+		if err != nil {
+			return fmt.Errorf("move %v: %w", i+1, err)
+		}
+		moves = append(moves, bookMove{key: game.Board.PolyglotKey(colour), fen: game.FEN(), encoded: encoded, san: written, colour: colour})
+		if _, err := game.move(move, colour); err != nil {
+			return fmt.Errorf("move %v %q: %w", i+1, san, err)
+		}
+	}
+	for _, m := range moves {
+		position, ok := bb.positions[m.key]
+		if !ok {
+			position = &bookPosition{fen: m.fen, moves: map[uint16]*BookMoveStats{}}
+			bb.positions[m.key] = position
+		}
+		stats, ok := position.moves[m.encoded]
+		if !ok {
+			stats = &BookMoveStats{SAN: m.san, Move: m.encoded}
+			position.moves[m.encoded] = stats
+		}
+		stats.Games++
+		switch {
+		case pgn.Result == "1/2-1/2":
+			stats.Draws++
+		case pgn.Result == "1-0" && m.colour == White, pgn.Result == "0-1" && m.colour == Black:
+			stats.Wins++
+		case pgn.Result == "1-0", pgn.Result == "0-1":
+			stats.Losses++
+		}
+	}
+	bb.Games++
+	return nil
+}
+
+// adds all games that can be added and returns the number that could not, with the first error
+func (bb *BookBuilder) AddGames(games []PGNGame) (int, error) {
+	skipped := 0
+	var firstErr error
+	for i, game := range games {
+		if err := bb.AddGame(game); err != nil {
+			skipped++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("game %v: %w", i+1, err)
+			}
+		}
+	}
+	return skipped, firstErr
+}
+
+// returns the moves of the position with the key that were played in at least MinGames games, most played first
+func (bb *BookBuilder) movesOf(key uint64) []*BookMoveStats {
+	moves := []*BookMoveStats{}
+	for _, stats := range bb.positions[key].moves {
+		if stats.Games >= bb.Options.MinGames {
+			moves = append(moves, stats)
+		}
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].Games != moves[j].Games {
+			return moves[i].Games > moves[j].Games
+		}
+		return moves[i].Move < moves[j].Move
+	})
+	return moves
+}
+
+// returns the keys of all positions in ascending order
+func (bb *BookBuilder) keys() []uint64 {
+	keys := make([]uint64, 0, len(bb.positions))
+	for key := range bb.positions {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// returns the book entries, moves without weight are left out. The weights of a position are scaled down together
+// when the largest does not fit in 16 bits
+func (bb *BookBuilder) Entries() []PolyglotEntry {
+	entries := []PolyglotEntry{}
+	for _, key := range bb.keys() {
+		moves := bb.movesOf(key)
+		largest := 0
+		for _, stats := range moves {
+			if stats.weight() > largest {
+				largest = stats.weight()
+			}
+		}
+		scale := 1.0
+		if largest > math.MaxUint16 {
+			scale = float64(math.MaxUint16) / float64(largest)
+		}
+		for _, stats := range moves {
+			weight := uint16(math.Round(float64(stats.weight()) * scale))
+			if weight == 0 {
+				continue
+			}
+			entries = append(entries, PolyglotEntry{Key: key, Move: stats.Move, Weight: weight})
+		}
+	}
+	return entries
+}
+
+// writes the book in the Polyglot format
+func (bb *BookBuilder) WritePolyglot(w io.Writer) error {
+	return WritePolyglotBook(w, bb.Entries())
+}
+
+type jsonBookMove struct {
+	SAN    string `json:"san"`
+	Games  int    `json:"games"`
+	Wins   int    `json:"wins"`
+	Draws  int    `json:"draws"`
+	Losses int    `json:"losses"`
+	Weight int    `json:"weight"`
+}
+
+type jsonBookPosition struct {
+	Key   string         `json:"key"`
+	FEN   string         `json:"fen"`
+	Moves []jsonBookMove `json:"moves"`
+}
+
+// writes the book as JSON, a list of positions with their Polyglot key, FEN and moves with the results. Unlike the
+// Polyglot format it keeps the moves without weight
+func (bb *BookBuilder) WriteJSON(w io.Writer) error {
+	positions := []jsonBookPosition{}
+	for _, key := range bb.keys() {
+		moves := bb.movesOf(key)
+		if len(moves) == 0 {
+			continue
+		}
+		position := jsonBookPosition{Key: fmt.Sprintf("%016x", key), FEN: bb.positions[key].fen}
+		for _, stats := range moves {
+			position.Moves = append(position.Moves, jsonBookMove{
+				SAN: stats.SAN, Games: stats.Games, Wins: stats.Wins, Draws: stats.Draws, Losses: stats.Losses, Weight: stats.weight(),
+			})
+		}
+		positions = append(positions, position)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(positions)
+}
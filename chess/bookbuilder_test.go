@@ -0,0 +1,155 @@
+package chess
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const bookBuilderPGN = `[Result "1-0"]
+
+1. e4 e5 2. Nf3 Nc6 1-0
+
+[Result "1/2-1/2"]
+
+1. e4 c5 2. Nf3 1/2-1/2
+
+[Result "0-1"]
+
+1. d4 d5 0-1
+
+[Result "*"]
+
+1. e4 e5 *
+`
+
+func buildTestBook(t *testing.T, options BookBuilderOptions) *BookBuilder {
+	defer quiet()()
+	games, err := ParsePGN(strings.NewReader(bookBuilderPGN))
+	if err != nil {
+		t.Fatalf("Failed to parse the games, %v", err)
+	}
+	builder := NewBookBuilder(options)
+	if skipped, err := builder.AddGames(games); skipped != 0 || err != nil {
+		t.Fatalf("Expected all games to be added, %v skipped, %v", skipped, err)
+	}
+	return builder
+}
+
+func TestBookBuilder_counts_moves_and_results(t *testing.T) {
+	builder := buildTestBook(t, BookBuilderOptions{MaxPly: 2})
+	start, _ := ParseFEN(StartingPositionFEN)
+	moves := builder.movesOf(start.Board.PolyglotKey(White))
+	expected := []BookMoveStats{
+		{SAN: "e4", Games: 3, Wins: 1, Draws: 1, Losses: 0},
+		{SAN: "d4", Games: 1, Wins: 0, Draws: 0, Losses: 1},
+	}
+	if len(moves) != len(expected) {
+		t.Fatalf("Expected %v moves from the start, got %v", len(expected), len(moves))
+	}
+	for i, move := range moves {
+		move.Move = 0
+		if *move != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], *move)
+		}
+	}
+	if builder.Games != 4 {
+		t.Errorf("Expected 4 games, got %v", builder.Games)
+	}
+	// Nf3 is the third ply and beyond the depth
+	if len(builder.positions) != 3 {
+		t.Errorf("Expected 3 positions, got %v", len(builder.positions))
+	}
+}
+
+func TestBookBuilder_writes_a_book_the_book_player_reads(t *testing.T) {
+	builder := buildTestBook(t, BookBuilderOptions{})
+	var data bytes.Buffer
+	if err := builder.WritePolyglot(&data); err != nil {
+		t.Fatalf("Failed to write the book, %v", err)
+	}
+	book, err := ReadPolyglotBook(&data)
+	if err != nil {
+		t.Fatalf("Failed to read the book, %v", err)
+	}
+	start, _ := ParseFEN(StartingPositionFEN)
+	moves := book.Moves(start.Board, White)
+	// d4 only lost, so it has no weight and is left out
+	if len(moves) != 1 || moves[0].Move != (Move{From: Square{"E", 2}, To: Square{"E", 4}}) || moves[0].Weight != 3 {
+		t.Errorf("Expected only e4 with weight 3, got %+v", moves)
+	}
+	// black lost after 1. e4 e5 and drew after 1. e4 c5
+	afterE4 := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	func() {
+		defer quiet()()
+		if err := playMoves(afterE4, []Move{{From: Square{"E", 2}, To: Square{"E", 4}}}); err != nil {
+			t.Fatalf("Failed to move, %v", err)
+		}
+	}()
+	moves = book.Moves(afterE4.Board, Black)
+	if len(moves) != 1 || moves[0].Move != (Move{From: Square{"C", 7}, To: Square{"C", 5}}) {
+		t.Errorf("Expected only c5, got %+v", moves)
+	}
+}
+
+func TestBookBuilder_leaves_out_rare_moves(t *testing.T) {
+	builder := buildTestBook(t, BookBuilderOptions{MinGames: 2})
+	for _, entry := range builder.Entries() {
+		found := false
+		for _, stats := range builder.positions[entry.Key].moves {
+			if stats.Move == entry.Move {
+				found = true
+				if stats.Games < 2 {
+					t.Errorf("Expected %v to be left out, it was played in %v game", stats.SAN, stats.Games)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Unexpected entry %+v", entry)
+		}
+	}
+	// e5 was played twice too, but lost one game and the other has no result
+	if entries := builder.Entries(); len(entries) != 1 {
+		t.Errorf("Expected only e4 in the book, got %v entries", len(entries))
+	}
+}
+
+func TestBookBuilder_WriteJSON(t *testing.T) {
+	builder := buildTestBook(t, BookBuilderOptions{MaxPly: 1})
+	var data bytes.Buffer
+	if err := builder.WriteJSON(&data); err != nil {
+		t.Fatalf("Failed to write the book, %v", err)
+	}
+	var positions []jsonBookPosition
+	if err := json.Unmarshal(data.Bytes(), &positions); err != nil {
+		t.Fatalf("Failed to read the book, %v", err)
+	}
+	if len(positions) != 1 || positions[0].FEN != StartingPositionFEN || positions[0].Key != "463b96181691fc9c" {
+		t.Fatalf("Expected the starting position only, got %+v", positions)
+	}
+	expected := []jsonBookMove{{SAN: "e4", Games: 3, Wins: 1, Draws: 1, Weight: 3}, {SAN: "d4", Games: 1, Losses: 1}}
+	if len(positions[0].Moves) != 2 || positions[0].Moves[0] != expected[0] || positions[0].Moves[1] != expected[1] {
+		t.Errorf("Expected %+v, got %+v", expected, positions[0].Moves)
+	}
+}
+
+func TestBookBuilder_rejects_other_variants(t *testing.T) {
+	builder := NewBookBuilder(BookBuilderOptions{})
+	err := builder.AddGame(PGNGame{Tags: map[string]string{"Variant": "Atomic"}, Moves: []string{"e4"}, Result: "*"})
+	if err == nil {
+		t.Errorf("Expected atomic games to be rejected")
+	}
+}
+
+func TestBookBuilder_adds_nothing_of_a_game_with_a_bad_move(t *testing.T) {
+	defer quiet()()
+	builder := NewBookBuilder(BookBuilderOptions{})
+	skipped, err := builder.AddGames([]PGNGame{{Moves: []string{"e4", "e5", "Nf3", "Ke3"}, Result: "1-0"}})
+	if skipped != 1 || err == nil || !strings.Contains(err.Error(), "move 4") {
+		t.Errorf("Expected the game to be skipped at move 4, %v skipped, %v", skipped, err)
+	}
+	if builder.Games != 0 || len(builder.positions) != 0 || len(builder.Entries()) != 0 {
+		t.Errorf("Expected the skipped game to add nothing, got %v games and %v positions", builder.Games, len(builder.positions))
+	}
+}
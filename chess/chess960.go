@@ -0,0 +1,96 @@
+package chess
+
+import (
+	"fmt"
+)
+
+// the placement of the two knights on the five squares left after placing the bishops and the queen
+var chess960KnightPlacements = [10][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+
+// returns the back row of the chess960 starting position with the given index (0-959, 518 is the standard setup)
+func Chess960BackRow(index int) ([8]PieceType, error) {
+	var backRow [8]PieceType
+	if index < 0 || index > 959 {
+		return backRow, fmt.Errorf("chess960 starting position must be between 0 and 959, got %v", index)
+	}
+	placed := [8]bool{}
+	place := func(columnIndex int, pieceType PieceType) {
+		backRow[columnIndex] = pieceType
+		placed[columnIndex] = true
+	}
+	// returns the column index of the nth empty square
+	nthEmpty := func(n int) int {
+		for i := range placed {
+			if !placed[i] {
+				if n == 0 {
+					return i
+				}
+				n--
+			}
+		}
+		return -1
+	}
+	n := index
+	place(2*(n%4)+1, Bishop) // light squared bishop on B, D, F or H
+	n /= 4
+	place(2*(n%4), Bishop) // dark squared bishop on A, C, E or G
+	n /= 4
+	place(nthEmpty(n%6), Queen)
+	n /= 6
+	knights := chess960KnightPlacements[n]
+	first, second := nthEmpty(knights[0]), nthEmpty(knights[1])
+	place(first, Knight)
+	place(second, Knight)
+	// the king always ends up between the rooks
+	place(nthEmpty(0), Rook)
+	place(nthEmpty(0), King)
+	place(nthEmpty(0), Rook)
+	return backRow, nil
+}
+
+// creates and returns a chess960 board with the starting position with the given index (0-959)
+func NewChess960Board(index int) (*Board, error) {
+	backRow, err := Chess960BackRow(index)
+	if err != nil {
+		return nil, err
+	}
+	board := newEmptyBoard()
+	board.placePiecesOnBoard(backRow)
+	board.chess960 = true
+	return board, nil
+}
+
+// creates and returns a new chess960 game with the starting position with the given index (0-959)
+func NewChess960Game(white Player, black Player, stateVisualizer BoardVisualizer, index int) (*Game, error) {
+	board, err := NewChess960Board(index)
+	if err != nil {
+		return nil, err
+	}
+	return NewGameFromPosition(white, black, stateVisualizer, board, White)
+}
+
+// returns true if castling is done by moving the king onto its own rook (chess960)
+func (b *Board) IsChess960() bool {
+	return b.chess960
+}
+
+// returns the move the king of the given colour would make to castle, in standard chess the king moves two squares
+// and in chess960 the king moves onto the square of its own rook
+func (b *Board) CastlingMove(colour Colour, kingSide bool) (Move, error) {
+	king := b.getKing(colour)
+	if king.Type != King {
+		return Move{}, fmt.Errorf("%v has no king", colour)
+	}
+	side := queenside
+	if kingSide {
+		side = kingside
+	}
+	rook, err := selectRookForCastling(king, side, b)
+	if err != nil {
+		return Move{}, illegalMove(CastlingNotAllowed, "castling not allowed, err: %v", err)
+	}
+	if b.chess960 {
+		return Move{From: king.CurrentSquare, To: rook.CurrentSquare}, nil
+	}
+	return Move{From: king.CurrentSquare, To: Square{Column: castlingTargets[side].kingColumn, Row: king.CurrentSquare.Row}}, nil
+}
@@ -0,0 +1,156 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestChess960BackRow(t *testing.T) {
+	defer quiet()()
+	cases := map[int]string{
+		0:   "BBQNNRKR",
+		518: "RNBQKBNR",
+		959: "RKRNNQBB",
+	}
+	letters := map[PieceType]string{Pawn: "P", Rook: "R", Knight: "N", Bishop: "B", Queen: "Q", King: "K"}
+	for index, expected := range cases {
+		backRow, err := Chess960BackRow(index)
+		if err != nil {
+			t.Errorf("Failed to create back row %v, %v", index, err)
+			continue
+		}
+		actual := ""
+		for _, pieceType := range backRow {
+			actual += letters[pieceType]
+		}
+		if actual != expected {
+			t.Errorf("Expected back row %v to be %v, got %v", index, expected, actual)
+		}
+	}
+	if _, err := Chess960BackRow(960); err == nil {
+		t.Errorf("Expected an error for index 960")
+	}
+}
+
+func TestNewChess960Board_all_positions_are_valid(t *testing.T) {
+	defer quiet()()
+	for index := 0; index < 960; index++ {
+		board, err := NewChess960Board(index)
+		if err != nil {
+			t.Errorf("Failed to create board %v, %v", index, err)
+			return
+		}
+		if err := board.Validate(White); err != nil {
+			t.Errorf("Expected position %v to be valid, got %v", index, err)
+		}
+		position := &Position{Board: board, NextToMove: White, FullmoveNumber: 1}
+		if fen := position.FEN(); fen[len(fen)-13:] != " w KQkq - 0 1" {
+			t.Errorf("Expected position %v to have full castling rights, got %v", index, fen)
+		}
+	}
+}
+
+func TestMoveKing_chess960_castling_onto_own_rook(t *testing.T) {
+	defer quiet()()
+	position, err := ParseFEN("4k3/8/8/8/8/8/8/RK5R w HA - 0 1")
+	if err != nil {
+		t.Errorf("Failed to parse FEN, %v", err)
+		return
+	}
+	board := position.Board
+	if !board.IsChess960() {
+		t.Errorf("Expected a king on B1 with castling rights to make a chess960 board")
+	}
+	// queenside, the king moves from B1 to C1 and the rook from A1 to D1
+	_, king := board.GetPieceAtSquare("B", 1)
+	if _, err := king.Move("A", 1, board, false); err != nil {
+		t.Errorf("Expected no error when castling queenside, but got %v", err.Error())
+		return
+	}
+	expectedStateOfBoard := `
+	.  .  .  .  ♚  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  ♔  ♖  .  .  .  ♖
+	`
+	if err := assertExpectedBoardState(expectedStateOfBoard, board); err != nil {
+		t.Errorf("Failed to assert expected board state, %v (Visible whitespace is ignored, something else differs!", err.Error())
+	}
+
+	// kingside, the king moves from B1 to G1 and the rook from H1 to F1
+	position, _ = ParseFEN("4k3/8/8/8/8/8/8/RK5R w HA - 0 1")
+	board = position.Board
+	_, king = board.GetPieceAtSquare("B", 1)
+	move, err := board.CastlingMove(White, true)
+	if err != nil || move.To != (Square{"H", 1}) {
+		t.Errorf("Expected the castling move to target the rook on H1, got %v %v", move, err)
+		return
+	}
+	if _, err := king.Move(move.To.Column, move.To.Row, board, false); err != nil {
+		t.Errorf("Expected no error when castling kingside, but got %v", err.Error())
+		return
+	}
+	expectedStateOfBoard = `
+	.  .  .  .  ♚  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	♖  .  .  .  .  ♖  ♔  .
+	`
+	if err := assertExpectedBoardState(expectedStateOfBoard, board); err != nil {
+		t.Errorf("Failed to assert expected board state, %v (Visible whitespace is ignored, something else differs!", err.Error())
+	}
+}
+
+func TestMoveKing_chess960_castling_where_king_does_not_move(t *testing.T) {
+	defer quiet()()
+	position, err := ParseFEN("4k3/8/8/8/8/8/8/6KR w H - 0 1")
+	if err != nil {
+		t.Errorf("Failed to parse FEN, %v", err)
+		return
+	}
+	board := position.Board
+	_, king := board.GetPieceAtSquare("G", 1)
+	if _, err := king.Move("H", 1, board, false); err != nil {
+		t.Errorf("Expected no error when castling kingside, but got %v", err.Error())
+		return
+	}
+	if found, rook := board.GetPieceAtSquare("F", 1); !found || rook.Type != Rook {
+		t.Errorf("Expected the rook to end up on F1")
+	}
+	if king.CurrentSquare != (Square{"G", 1}) {
+		t.Errorf("Expected the king to stay on G1, got %v", king.CurrentSquare)
+	}
+}
+
+func TestMoveKing_chess960_castling_not_allowed(t *testing.T) {
+	defer quiet()()
+	cases := []struct {
+		fen      string
+		from     Square
+		to       Square
+		expected string
+	}{
+		{"4k3/8/8/8/8/8/8/RKN4R w HA - 0 1", Square{"B", 1}, Square{"A", 1}, "castling not allowed, err: pieces between king and rook"},
+		{"4k3/8/8/8/8/8/5r2/1K5R w H - 0 1", Square{"B", 1}, Square{"H", 1}, "castling not allowed, err: king passes through a square that is attacked by an enemy piece"},
+		{"4k3/8/8/8/8/8/8/RK5R w H - 0 1", Square{"B", 1}, Square{"A", 1}, "castling not allowed, err: king or rook has previously moved"},
+	}
+	for _, c := range cases {
+		position, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Errorf("Failed to parse FEN, %v", err)
+			continue
+		}
+		_, king := position.Board.GetPieceAtSquare(c.from.Column, c.from.Row)
+		_, err = king.Move(c.to.Column, c.to.Row, position.Board, false)
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("Expected %q for %v, got %v", c.expected, c.fen, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package chess
+
+import (
+	"fmt"
+	"time"
+)
+
+// a side's time budget for the whole game, without increment. The time a player spends picking its moves (incl.
+// illegal ones) is taken from it and the player loses on time when it runs out
+type clock struct {
+	limited bool
+	left    time.Duration
+}
+
+// limits the time white and black may spend on all of their moves, a limit of 0 leaves that side without a clock.
+// Giving the sides different limits plays a game with time odds
+func (g *Game) SetTimeControl(white time.Duration, black time.Duration) {
+	g.clocks = [2]clock{{limited: white > 0, left: white}, {limited: black > 0, left: black}}
+}
+
+// returns the time colour has left, the second return value is false if colour plays without a clock
+func (g *Game) TimeLeft(colour Colour) (time.Duration, bool) {
+	c := g.clocks[colour]
+	return c.left, c.limited
+}
+
+func (g *Game) now() time.Time {
+	if g.clock != nil {
+		return g.clock()
+	}
+	return time.Now()
+}
+
+// takes the time since started from the clock of colour and finishes the game if it ran out. Returns true if the
+// flag fell
+func (g *Game) flagFell(colour Colour, started time.Time) bool {
+	c := &g.clocks[colour]
+	if !c.limited || g.finished {
+		return false
+	}
+	c.left -= g.now().Sub(started)
+	if c.left > 0 {
+		return false
+	}
+	c.left = 0
+	fmt.Printf("%v ran out of time!\n", colour)
+	g.visualize()
+	g.finish(g.timeoutResult(colour))
+	return true
+}
+
+// the opponent wins on time, unless it is standard chess and the opponent has only its king or neither side can mate
+func (g *Game) timeoutResult(colour Colour) Result {
+	reason := fmt.Sprintf("%v ran out of time", colour)
+	if _, standard := g.variant.(Standard); standard {
+		if g.Board.hasInsufficientMaterial() || onlyKingLeft(g.Board, opponentOf(colour)) {
+			return drawBy(Timeout, reason+", the opponent can not mate")
+		}
+	}
+	return winFor(opponentOf(colour), Timeout, reason)
+}
+
+func onlyKingLeft(b *Board, colour Colour) bool {
+	pieces := b.WhitePieces
+	if colour == Black {
+		pieces = b.BlackPieces
+	}
+	for _, piece := range pieces {
+		if piece.InPlay && piece.Type != King {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package chess
+
+import (
+	"testing"
+	"time"
+)
+
+// a scripted player that spends thinks on the fake clock for each move
+type thinkingPlayer struct {
+	scriptedPlayer
+	thinks time.Duration
+	now    *time.Time
+}
+
+func (p *thinkingPlayer) PickMove(g *Game) (*Move, error) {
+	*p.now = p.now.Add(p.thinks)
+	return p.scriptedPlayer.PickMove(g)
+}
+
+func newClockedGame(t *testing.T, white *thinkingPlayer, black *thinkingPlayer, fen string) *Game {
+	now := time.Now()
+	white.now, black.now = &now, &now
+	game, err := NewGameFromFEN(white, black, &noopVisualizer{}, fen)
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	game.clock = func() time.Time { return now }
+	return game
+}
+
+func knightShuffle(colour Colour, n int) []Move {
+	row, back := 1, 3
+	if colour == Black {
+		row, back = 8, 6
+	}
+	moves := []Move{}
+	for i := 0; i < n; i++ {
+		moves = append(moves, Move{From: Square{"G", row}, To: Square{"F", back}}, Move{From: Square{"F", back}, To: Square{"G", row}})
+	}
+	return moves
+}
+
+func TestTimeControl_flag_falls(t *testing.T) {
+	defer quiet()()
+	white := &thinkingPlayer{scriptedPlayer: scriptedPlayer{colour: White, moves: knightShuffle(White, 10)}, thinks: 3 * time.Second}
+	black := &thinkingPlayer{scriptedPlayer: scriptedPlayer{colour: Black, moves: knightShuffle(Black, 10)}, thinks: time.Minute}
+	game := newClockedGame(t, white, black, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	game.SetTimeControl(10*time.Second, 0)
+	result := game.Start()
+	if result.Outcome != BlackWon || result.Termination != Timeout {
+		t.Errorf("Expected black to win on time, got %v", result)
+	}
+	if len(game.History) != 6 {
+		t.Errorf("Expected white to lose on its fourth move, got %v moves", len(game.History))
+	}
+	if left, limited := game.TimeLeft(White); !limited || left != 0 {
+		t.Errorf("Expected white to have no time left, got %v (limited %v)", left, limited)
+	}
+	if _, limited := game.TimeLeft(Black); limited {
+		t.Errorf("Expected black to play without a clock")
+	}
+}
+
+func TestTimeControl_draw_when_the_opponent_can_not_mate(t *testing.T) {
+	defer quiet()()
+	white := &thinkingPlayer{scriptedPlayer: scriptedPlayer{colour: White, moves: []Move{{From: Square{"A", 1}, To: Square{"A", 2}}}}, thinks: 2 * time.Second}
+	black := &thinkingPlayer{scriptedPlayer: scriptedPlayer{colour: Black}, thinks: time.Second}
+	game := newClockedGame(t, white, black, "4k3/8/8/8/8/8/8/R3K3 w - - 0 1")
+	game.SetTimeControl(time.Second, time.Second)
+	if result := game.Start(); result.Outcome != Drawn || result.Termination != Timeout {
+		t.Errorf("Expected a draw when the lone king runs out of time, got %v", result)
+	}
+
+	white = &thinkingPlayer{scriptedPlayer: scriptedPlayer{colour: White, moves: []Move{{From: Square{"A", 1}, To: Square{"A", 2}}}}, thinks: time.Second}
+	black = &thinkingPlayer{scriptedPlayer: scriptedPlayer{colour: Black, moves: []Move{{From: Square{"E", 8}, To: Square{"E", 7}}}}, thinks: 2 * time.Second}
+	game = newClockedGame(t, white, black, "4k3/8/8/8/8/8/8/R3K3 w - - 0 1")
+	game.SetTimeControl(5*time.Second, time.Second)
+	if result := game.Start(); result.Outcome != WhiteWon || result.Termination != Timeout {
+		t.Errorf("Expected white to win when black runs out of time, got %v", result)
+	}
+}
+
+func TestNewHandicapGame_time_odds(t *testing.T) {
+	defer quiet()()
+	handicap := Handicap{Name: "Time", GiverTime: time.Minute, ReceiverTime: 5 * time.Minute}
+	game, err := NewHandicapGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, handicap, Black)
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	if left, limited := game.TimeLeft(Black); !limited || left != time.Minute {
+		t.Errorf("Expected the giver to have a minute, got %v (limited %v)", left, limited)
+	}
+	if left, limited := game.TimeLeft(White); !limited || left != 5*time.Minute {
+		t.Errorf("Expected the receiver to have five minutes, got %v (limited %v)", left, limited)
+	}
+	if fen := game.FEN(); fen != "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1" {
+		t.Errorf("Expected time odds to keep all pieces, got %q", fen)
+	}
+	handicap.GiverTime = -time.Minute
+	if _, err := NewHandicapGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, handicap, Black); err == nil {
+		t.Errorf("Expected an error for a negative time")
+	}
+}
@@ -0,0 +1,132 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+// captured pieces go to the capturers pocket (a promoted pawn goes back as a pawn) and instead of moving a
+// player may drop a piece from the pocket on any empty square, pawns not on the first or last row
+type Crazyhouse struct {
+	Standard
+}
+
+func (Crazyhouse) Name() string {
+	return "Crazyhouse"
+}
+
+func (Crazyhouse) AfterCapture(b *Board, capturer *Piece, captured *Piece) {
+	pieceType := captured.Type
+	if captured.promoted {
+		pieceType = Pawn
+	}
+	b.pockets[capturer.Colour][pieceType]++
+}
+
+// checkmate and stale mate, pieces keep coming back so there is no insufficient material
+func (Crazyhouse) GameOver(g *Game) (Result, bool) {
+	if len(g.LegalMoves()) > 0 {
+		return Result{}, false
+	}
+	if isCheck, _ := g.Board.kingIsInCheck(g.NextToMove); isCheck {
+		return winFor(opponentOf(g.NextToMove), Checkmate, fmt.Sprintf("%v is in mate", g.NextToMove)), true
+	}
+	return drawBy(Stalemate, "Stale mate"), true
+}
+
+// returns the pieces the colour can drop and how many of each
+func (b *Board) Pocket(colour Colour) map[PieceType]int {
+	pocket := map[PieceType]int{}
+	for pieceType, count := range b.pockets[colour] {
+		if count > 0 {
+			pocket[PieceType(pieceType)] = count
+		}
+	}
+	return pocket
+}
+
+// drops a piece from the pocket of colour on an empty square and returns a MoveResult or an error
+func (b *Board) Drop(colour Colour, pieceType PieceType, column string, row int, dryRun bool) (*MoveResult, error) {
+	column = strings.ToUpper(column)
+	square, err := b.getSquare(column, row)
+	if err != nil {
+		return nil, illegalMove(OffBoard, "square %v%v is not on the board", column, row)
+	}
+	if pieceType < Pawn || pieceType >= King || b.pockets[colour][pieceType] == 0 {
+		return nil, illegalMove(NotInPocket, "%v has no %v to drop", colour, pieceType)
+	}
+	if occupied, piece := b.GetPieceAtSquare(column, row); occupied {
+		return nil, illegalMove(SquareOccupied, "%v cant be dropped on %v%v, it is occupied by %v %v", pieceType, column, row, piece.Colour, piece.Type)
+	}
+	if pieceType == Pawn && (row == 1 || row == 8) {
+		return nil, illegalMove(InvalidPieceMove, "pawns cant be dropped on the first or last row")
+	}
+	// a drop never captures, so it is legal if the own king is not in check with the piece in place
+	clone := b.Clone()
+	clone.addPiece(droppedPiece(pieceType, colour, square))
+	if isCheck, _ := clone.kingIsInCheck(colour); isCheck {
+		return nil, illegalMove(LeavesKingInCheck, "dropping %v on %v%v leaves the king in check", pieceType, column, row)
+	}
+	if !dryRun {
+		b.pockets[colour][pieceType]--
+		piece := b.addPiece(droppedPiece(pieceType, colour, square))
+		lastMove := LastMove{piece, &Move{To: square, Kind: Drop, Piece: pieceType}}
+		if colour == White {
+			b.whitesLastMove = lastMove
+		} else {
+			b.blacksLastMove = lastMove
+		}
+		b.lastMoveColour = colour
+	}
+	return &MoveResult{Action: GoTo, Piece: nil}, nil
+}
+
+// dropped pawns on their starting row may move two squares, dropped rooks can not castle
+func droppedPiece(pieceType PieceType, colour Colour, square Square) Piece {
+	hasMoved := !(pieceType == Pawn && onStartingSquare(Pawn, colour, square))
+	return Piece{Type: pieceType, CurrentSquare: square, Colour: colour, InPlay: true, hasMoved: hasMoved}
+}
+
+// adds the piece to the pieces of its colour and returns a pointer to it
+func (b *Board) addPiece(piece Piece) *Piece {
+	if piece.Colour == White {
+		b.WhitePieces = append(b.WhitePieces, piece)
+		return &b.WhitePieces[len(b.WhitePieces)-1]
+	}
+	b.BlackPieces = append(b.BlackPieces, piece)
+	return &b.BlackPieces[len(b.BlackPieces)-1]
+}
+
+// returns all drops the colour could make, without checking if they leave the own king in check
+func (b *Board) getDropsFor(colour Colour) map[Move]*MoveResult {
+	drops := map[Move]*MoveResult{}
+	for pieceType, count := range b.pockets[colour] {
+		if count == 0 {
+			continue
+		}
+		for _, square := range b.Squares {
+			if occupied, _ := b.GetPieceAtSquare(square.Column, square.Row); occupied {
+				continue
+			}
+			if PieceType(pieceType) == Pawn && (square.Row == 1 || square.Row == 8) {
+				continue
+			}
+			drops[NewDrop(PieceType(pieceType), square)] = &MoveResult{Action: GoTo, Piece: nil}
+		}
+	}
+	return drops
+}
+
+// returns the pockets as written in crazyhouse FEN, e.g. [QNpp]
+func (b *Board) pocketsFEN() string {
+	var pockets strings.Builder
+	pockets.WriteString("[")
+	for _, colour := range []Colour{White, Black} {
+		for _, pieceType := range []PieceType{Queen, Rook, Bishop, Knight, Pawn} {
+			letter := (&Piece{Type: pieceType, Colour: colour}).fenLetter()
+			pockets.WriteString(strings.Repeat(letter, b.pockets[colour][pieceType]))
+		}
+	}
+	pockets.WriteString("]")
+	return pockets.String()
+}
@@ -0,0 +1,141 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCrazyhouse_capture_goes_to_the_capturers_pocket(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Crazyhouse{}, "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR[] w KQkq - 0 2")
+	if _, err := game.move(Move{From: Square{"E", 4}, To: Square{"D", 5}}, White); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	if pocket := game.Board.Pocket(White); pocket[Pawn] != 1 || len(pocket) != 1 {
+		t.Errorf("Expected White to have a pawn in its pocket, got %v", pocket)
+	}
+	if pocket := game.Board.Pocket(Black); len(pocket) != 0 {
+		t.Errorf("Expected Black's pocket to be empty, got %v", pocket)
+	}
+}
+
+func TestCrazyhouse_drop_takes_piece_from_pocket(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Crazyhouse{}, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[NN] w KQkq - 0 1")
+	if _, err := game.move(NewDrop(Knight, Square{"E", 4}), White); err != nil {
+		t.Errorf("Failed to drop, %v", err)
+		return
+	}
+	if found, piece := game.Board.GetPieceAtSquare("E", 4); !found || piece.Type != Knight || piece.Colour != White {
+		t.Errorf("Expected a white knight on E4")
+	}
+	if pocket := game.Board.Pocket(White); pocket[Knight] != 1 {
+		t.Errorf("Expected one knight left in the pocket, got %v", pocket)
+	}
+	if game.NextToMove != Black {
+		t.Errorf("Expected Black to move after the drop")
+	}
+}
+
+func TestCrazyhouse_illegal_drops(t *testing.T) {
+	defer quiet()()
+	b, err := ParseFEN("4k3/pppppppp/8/8/8/8/PPPPPPPP/4K3[Pq] w - - 0 1")
+	if err != nil {
+		t.Fatalf("Failed to parse FEN, %v", err)
+	}
+	board := b.Board
+	tests := []struct {
+		colour    Colour
+		pieceType PieceType
+		column    string
+		row       int
+		want      error
+	}{
+		{White, Pawn, "A", 8, ErrInvalidPieceMove},
+		{White, Pawn, "A", 1, ErrInvalidPieceMove},
+		{White, Pawn, "E", 2, ErrSquareOccupied},
+		{White, Knight, "E", 4, ErrNotInPocket},
+		{Black, Pawn, "E", 4, ErrNotInPocket},
+		{White, Pawn, "I", 4, ErrOffBoard},
+	}
+	for _, test := range tests {
+		if _, err := board.Drop(test.colour, test.pieceType, test.column, test.row, true); !errors.Is(err, test.want) {
+			t.Errorf("Expected dropping %v %v on %v%v to fail with %v, got %v", test.colour, test.pieceType, test.column, test.row, test.want, err)
+		}
+	}
+	if _, err := board.Drop(Black, Queen, "E", 4, true); err != nil {
+		t.Errorf("Expected Black to be able to drop a queen, got %v", err)
+	}
+}
+
+func TestCrazyhouse_drop_can_block_mate(t *testing.T) {
+	defer quiet()()
+	// the rook on A8 would mate, but a piece can be dropped in between
+	game := newVariantGameFromFEN(t, Crazyhouse{}, "R5k1/5ppp/8/8/8/8/8/6K1[n] b - - 0 1")
+	if game.Board.kingIsInMate(Black) {
+		t.Errorf("Expected Black not to be in mate with a knight in the pocket")
+	}
+	if _, err := game.move(NewDrop(Knight, Square{"A", 1}), Black); !errors.Is(err, ErrLeavesKingInCheck) {
+		t.Errorf("Expected a drop that does not block the check to fail, got %v", err)
+	}
+	if _, err := game.move(NewDrop(Knight, Square{"F", 8}), Black); err != nil {
+		t.Errorf("Expected the drop to block the check, got %v", err)
+	}
+	if result, over := (Crazyhouse{}).GameOver(game); over {
+		t.Errorf("Expected the game to go on, got %v", result)
+	}
+}
+
+func TestCrazyhouse_promoted_piece_goes_back_as_pawn(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Crazyhouse{}, "3qk3/8/8/8/8/8/8/3QK3[] w - - 0 1")
+	for i := range game.Board.WhitePieces {
+		if game.Board.WhitePieces[i].Type == Queen {
+			game.Board.WhitePieces[i].promoted = true
+		}
+	}
+	if _, err := game.move(Move{From: Square{"D", 1}, To: Square{"D", 8}}, White); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	if _, err := game.move(Move{From: Square{"E", 8}, To: Square{"D", 8}}, Black); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	if pocket := game.Board.Pocket(Black); pocket[Pawn] != 1 || pocket[Queen] != 0 {
+		t.Errorf("Expected the promoted queen to go to the pocket as a pawn, got %v", pocket)
+	}
+	if pocket := game.Board.Pocket(White); pocket[Queen] != 1 {
+		t.Errorf("Expected White to have a queen in its pocket, got %v", pocket)
+	}
+}
+
+func TestCrazyhouse_FEN_round_trip(t *testing.T) {
+	tests := []string{
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[] w KQkq - 0 1",
+		"r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R[QNpp] b KQkq - 2 3",
+		"4Q~k2/8/8/8/8/8/8/4K3[Rb] b - - 0 30",
+	}
+	for _, fen := range tests {
+		position, err := ParseFEN(fen)
+		if err != nil {
+			t.Errorf("Failed to parse %q, %v", fen, err)
+			continue
+		}
+		if position.Board.getVariant().Name() != "Crazyhouse" {
+			t.Errorf("Expected a pocket to make %q a crazyhouse position", fen)
+		}
+		if got := position.FEN(); got != fen {
+			t.Errorf("Expected %q, got %q", fen, got)
+		}
+	}
+	// the pocket may also be written as a 9th row
+	position, err := ParseFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR/Qn w KQkq - 0 1")
+	if err != nil {
+		t.Fatalf("Failed to parse FEN, %v", err)
+	}
+	if position.Board.Pocket(White)[Queen] != 1 || position.Board.Pocket(Black)[Knight] != 1 {
+		t.Errorf("Expected a queen and a knight in the pockets, got %v and %v", position.Board.Pocket(White), position.Board.Pocket(Black))
+	}
+}
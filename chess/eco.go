@@ -0,0 +1,139 @@
+package chess
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// the ECO table of lichess (github.com/lichess-org/chess-openings, public domain): code, name, moves and the EPD
+// of the position the moves lead to, one opening per line
+//
+//go:embed eco.tsv
+var ecoFiles embed.FS
+
+// an opening of the Encyclopaedia of Chess Openings, e.g. B20 Sicilian Defense 1. e4 c5
+type Opening struct {
+	ECO  string
+	Name string
+	PGN  string // the moves of the opening
+}
+
+func (o Opening) String() string {
+	return o.ECO + " " + o.Name
+}
+
+// classifies games by the positions they reach, so transpositions are recognised
+type ECOClassifier struct {
+	openings map[string]Opening // by the placement, side to move and castling rights of the position
+}
+
+var defaultECO struct {
+	once       sync.Once
+	classifier *ECOClassifier
+	err        error
+}
+
+// returns the classifier of the embedded ECO table
+func DefaultECOClassifier() (*ECOClassifier, error) {
+	defaultECO.once.Do(func() {
+		f, err := ecoFiles.Open("eco.tsv")
+		if err != nil {
+			defaultECO.err = err
+			return
+		}
+		defer f.Close()
+		defaultECO.classifier, defaultECO.err = ReadECOClassifier(f)
+	})
+	return defaultECO.classifier, defaultECO.err
+}
+
+// reads an ECO table of tab separated values with a header line naming the columns eco, name, pgn and epd
+func ReadECOClassifier(r io.Reader) (*ECOClassifier, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("the ECO table is empty")
+	}
+	columns := map[string]int{}
+	for i, name := range strings.Split(scanner.Text(), "\t") {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"eco", "name", "pgn", "epd"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the ECO table has no %v column", name)
+		}
+	}
+	classifier := &ECOClassifier{openings: map[string]Opening{}}
+	for line := 2; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < len(columns) {
+			return nil, fmt.Errorf("line %v of the ECO table has %v columns, expected %v", line, len(fields), len(columns))
+		}
+		key := ecoKey(fields[columns["epd"]])
+		if _, ok := classifier.openings[key]; !ok {
+			classifier.openings[key] = Opening{ECO: fields[columns["eco"]], Name: fields[columns["name"]], PGN: fields[columns["pgn"]]}
+		}
+	}
+	return classifier, scanner.Err()
+}
+
+// returns the placement, side to move and castling rights of the FEN or EPD. En passant squares are left out as
+// tables differ in when they write them
+func ecoKey(fen string) string {
+	fields := strings.Fields(fen)
+	if len(fields) > 3 {
+		fields = fields[:3]
+	}
+	return strings.Join(fields, " ")
+}
+
+// returns the number of openings in the table
+func (c *ECOClassifier) Len() int {
+	return len(c.openings)
+}
+
+// returns the opening of the position with colour to move, false if it is not in the table
+func (c *ECOClassifier) Find(b *Board, colour Colour) (Opening, bool) {
+	opening, ok := c.openings[ecoKey(b.fen(colour, 0, 1, false))]
+	return opening, ok
+}
+
+// returns the opening of the last position of the game that is in the table, false if there is none. Only standard
+// chess is classified
+func (c *ECOClassifier) Classify(g *Game) (Opening, bool) {
+	if g.Variant().Name() != (Standard{}).Name() || g.Board.IsChess960() {
+		return Opening{}, false
+	}
+	boards, err := g.Replay()
+	if err != nil {
+		return Opening{}, false
+	}
+	colour := g.startNextToMove
+	var found Opening
+	classified := false
+	for _, board := range boards {
+		if opening, ok := c.Find(board, colour); ok {
+			found, classified = opening, true
+		}
+		colour = opponentOf(colour)
+	}
+	return found, classified
+}
+
+// returns the opening of the game according to the embedded ECO table, false if it is not in the table
+func (g *Game) Opening() (Opening, bool) {
+	classifier, err := DefaultECOClassifier()
+	if err != nil {
+		return Opening{}, false
+	}
+	return classifier.Classify(g)
+}
@@ -0,0 +1,122 @@
+package chess
+
+import (
+	"strings"
+	"testing"
+)
+
+func gameFromSAN(t *testing.T, moves string) *Game {
+	defer quiet()()
+	game, err := NewGameFromPGN(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, PGNGame{Moves: strings.Fields(moves)})
+	if err != nil {
+		t.Fatalf("Failed to play %q, %v", moves, err)
+	}
+	return game
+}
+
+func TestDefaultECOClassifier(t *testing.T) {
+	classifier, err := DefaultECOClassifier()
+	if err != nil {
+		t.Fatalf("Failed to read the embedded ECO table, %v", err)
+	}
+	if classifier.Len() < 3000 {
+		t.Errorf("Expected the full ECO table, got %v openings", classifier.Len())
+	}
+}
+
+func TestECOClassifier_Classify(t *testing.T) {
+	tests := []struct {
+		moves    string
+		expected string
+	}{
+		{"e4 c5", "B20 Sicilian Defense"},
+		{"e4 e5 Nf3 Nc6 Bb5 a6", "C70 Ruy Lopez: Morphy Defense"},
+		// a transposition to 1. e4 c5 2. Nf3
+		{"Nf3 c5 e4", "B27 Sicilian Defense"},
+		// the deepest position in the table counts, moves after it do not change the opening
+		{"e4 e5 Nf3 Nc6 Bb5 a6 Ba4 h5 Kf1", "C70 Ruy Lopez: Morphy Defense"},
+	}
+	classifier, err := DefaultECOClassifier()
+	if err != nil {
+		t.Fatalf("Failed to read the embedded ECO table, %v", err)
+	}
+	for _, test := range tests {
+		opening, ok := classifier.Classify(gameFromSAN(t, test.moves))
+		if !ok || opening.String() != test.expected {
+			t.Errorf("Expected %q to be %q, got %q (%v)", test.moves, test.expected, opening, ok)
+		}
+	}
+	if opening, ok := classifier.Classify(gameFromSAN(t, "")); ok {
+		t.Errorf("Expected no opening before the first move, got %q", opening)
+	}
+}
+
+func TestECOClassifier_only_classifies_standard_chess(t *testing.T) {
+	defer quiet()()
+	game := NewVariantGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, Atomic{})
+	if err := playMoves(game, []Move{
+		{From: Square{"E", 2}, To: Square{"E", 4}},
+		{From: Square{"C", 7}, To: Square{"C", 5}},
+	}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	if opening, ok := game.Opening(); ok {
+		t.Errorf("Expected atomic games not to be classified, got %q", opening)
+	}
+}
+
+func TestReadECOClassifier(t *testing.T) {
+	table := "eco\tname\tpgn\tepd\n" +
+		"X01\tKings Pawn\t1. e4\trnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq -\n"
+	classifier, err := ReadECOClassifier(strings.NewReader(table))
+	if err != nil {
+		t.Fatalf("Failed to read the table, %v", err)
+	}
+	if opening, ok := classifier.Classify(gameFromSAN(t, "e4 e5")); !ok || opening.ECO != "X01" || opening.PGN != "1. e4" {
+		t.Errorf("Expected X01, got %+v (%v)", opening, ok)
+	}
+	if _, err := ReadECOClassifier(strings.NewReader("eco\tname\tpgn\n")); err == nil {
+		t.Errorf("Expected a table without an epd column to be rejected")
+	}
+}
+
+func TestWritePGN_writes_the_opening(t *testing.T) {
+	game := gameFromSAN(t, "e4 c5 Nf3")
+	var text strings.Builder
+	if err := WritePGN(&text, game, nil); err != nil {
+		t.Fatalf("Failed to write PGN, %v", err)
+	}
+	if !strings.Contains(text.String(), "[ECO \"B27\"]\n") || !strings.Contains(text.String(), "[Opening \"Sicilian Defense\"]\n") {
+		t.Errorf("Expected the ECO and Opening tags, got\n%v", text.String())
+	}
+	text.Reset()
+	if err := WritePGN(&text, game, map[string]string{"Opening": "Own name"}); err != nil {
+		t.Fatalf("Failed to write PGN, %v", err)
+	}
+	if strings.Contains(text.String(), "[ECO") || !strings.Contains(text.String(), "[Opening \"Own name\"]\n") {
+		t.Errorf("Expected the given Opening tag to be kept, got\n%v", text.String())
+	}
+}
+
+type openingVisualizer struct {
+	noopVisualizer
+	openings []string
+}
+
+func (v *openingVisualizer) VisualizeGame(g *Game) {
+	opening, _ := g.Opening()
+	v.openings = append(v.openings, opening.ECO)
+}
+
+func TestGame_shows_the_opening_to_game_visualizers(t *testing.T) {
+	defer quiet()()
+	visualizer := &openingVisualizer{}
+	white := &scriptedPlayer{colour: White, moves: []Move{{From: Square{"E", 2}, To: Square{"E", 4}}, {From: Square{"G", 1}, To: Square{"F", 3}}}}
+	black := &scriptedPlayer{colour: Black, moves: []Move{{From: Square{"C", 7}, To: Square{"C", 5}}}}
+	NewGame(white, black, visualizer).Start()
+	// the start, e4, c5 and Nf3
+	expected := []string{"", "B00", "B20", "B27"}
+	if strings.Join(visualizer.openings, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected the openings %v, got %v", expected, visualizer.openings)
+	}
+}
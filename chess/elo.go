@@ -0,0 +1,122 @@
+package chess
+
+import (
+	"fmt"
+	"math"
+)
+
+// returns the Elo difference that makes the expected score (0 to 1) of the stronger player, e.g. 190.8 for 0.75.
+// A score of 0 or 1 gives minus or plus infinity
+func EloDifference(score float64) float64 {
+	return -400 * math.Log10(1/score-1)
+}
+
+// returns the expected score (0 to 1) of a player rated elo points above its opponent
+func ExpectedScore(elo float64) float64 {
+	return 1 / (1 + math.Pow(10, -elo/400))
+}
+
+// returns the score (0 to 1) and its variance per game of a player with the wins, draws and losses
+func scoreAndVariance(wins int, draws int, losses int) (float64, float64) {
+	return weightedScoreAndVariance(float64(wins), float64(draws), float64(losses))
+}
+
+// as scoreAndVariance, the results may be fractions of games
+func weightedScoreAndVariance(wins float64, draws float64, losses float64) (float64, float64) {
+	games := wins + draws + losses
+	if games == 0 {
+		return 0.5, 0
+	}
+	score := (wins + draws/2) / games
+	variance := (wins*math.Pow(1-score, 2) + draws*math.Pow(0.5-score, 2) + losses*math.Pow(score, 2)) / games
+	return score, variance
+}
+
+// returns the Elo difference of a player with the wins, draws and losses and the margin of its 95% confidence
+// interval. The margin is infinite when there are too few games to tell
+func EloEstimate(wins int, draws int, losses int) (elo float64, margin float64) {
+	score, variance := scoreAndVariance(wins, draws, losses)
+	games := float64(wins + draws + losses)
+	if games == 0 {
+		return 0, math.Inf(1)
+	}
+	deviation := math.Sqrt(variance / games)
+	low, high := score-1.959964*deviation, score+1.959964*deviation
+	if low <= 0 || high >= 1 {
+		return EloDifference(score), math.Inf(1)
+	}
+	return EloDifference(score), (EloDifference(high) - EloDifference(low)) / 2
+}
+
+// returns the likelihood of superiority, the probability (0 to 1) that a player with the wins and losses is the
+// stronger one. Draws do not tell which player is stronger
+func LOS(wins int, losses int) float64 {
+	if wins+losses == 0 {
+		return 0.5
+	}
+	return 0.5 * (1 + math.Erf(float64(wins-losses)/math.Sqrt(2*float64(wins+losses))))
+}
+
+// a sequential probability ratio test of H0: the Elo difference is Elo0 against H1: it is Elo1. Alpha is the
+// chance of accepting H1 when H0 is true and Beta the chance of accepting H0 when H1 is true, e.g. Elo0 0, Elo1 10,
+// Alpha 0.05 and Beta 0.05
+type SPRT struct {
+	Elo0  float64
+	Elo1  float64
+	Alpha float64
+	Beta  float64
+}
+
+type SPRTDecision int
+
+const (
+	SPRTContinue SPRTDecision = iota // no decision yet, more games are needed
+	SPRTAcceptH0                     // the difference is Elo0 (or less)
+	SPRTAcceptH1                     // the difference is Elo1 (or more)
+)
+
+func (d SPRTDecision) String() string {
+	switch d {
+	case SPRTAcceptH0:
+		return "H0 accepted"
+	case SPRTAcceptH1:
+		return "H1 accepted"
+	default:
+		return "no decision"
+	}
+}
+
+// returns the log likelihood ratios at which H0 (lower) and H1 (upper) are accepted
+func (s SPRT) Bounds() (lower float64, upper float64) {
+	return math.Log(s.Beta / (1 - s.Alpha)), math.Log((1 - s.Beta) / s.Alpha)
+}
+
+// returns the log likelihood ratio of H1 against H0 for the wins, draws and losses. It uses the normal
+// approximation of the generalized SPRT and treats the games as independent. The score is estimated with half a
+// virtual win and half a virtual loss added, so that only wins, only losses or only draws still have a variance and
+// can decide
+func (s SPRT) LLR(wins int, draws int, losses int) float64 {
+	if wins+draws+losses == 0 {
+		return 0
+	}
+	score, variance := weightedScoreAndVariance(float64(wins)+0.5, float64(draws), float64(losses)+0.5)
+	score0, score1 := ExpectedScore(s.Elo0), ExpectedScore(s.Elo1)
+	return float64(wins+draws+losses) * (score1 - score0) * (2*score - score0 - score1) / (2 * variance)
+}
+
+// returns whether the wins, draws and losses are enough to accept one of the hypotheses
+func (s SPRT) Decide(wins int, draws int, losses int) SPRTDecision {
+	lower, upper := s.Bounds()
+	switch llr := s.LLR(wins, draws, losses); {
+	case llr >= upper:
+		return SPRTAcceptH1
+	case llr <= lower:
+		return SPRTAcceptH0
+	default:
+		return SPRTContinue
+	}
+}
+
+func (s SPRT) String() string {
+	return fmt.Sprintf("SPRT elo0=%v elo1=%v alpha=%v beta=%v", s.Elo0, s.Elo1, s.Alpha, s.Beta)
+}
@@ -0,0 +1,75 @@
+package chess
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a float64, b float64, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestEloDifference(t *testing.T) {
+	for score, expected := range map[float64]float64{0.5: 0, 0.75: 190.85, 0.25: -190.85, 0.9: 381.7} {
+		if elo := EloDifference(score); !near(elo, expected, 0.01) {
+			t.Errorf("Expected a score of %v to be %v Elo, got %v", score, expected, elo)
+		}
+		if back := ExpectedScore(expected); !near(back, score, 0.0001) {
+			t.Errorf("Expected %v Elo to give a score of %v, got %v", expected, score, back)
+		}
+	}
+}
+
+func TestEloEstimate(t *testing.T) {
+	elo, margin := EloEstimate(60, 20, 20)
+	if !near(elo, 147.19, 0.01) || !near(margin, 66.01, 0.01) {
+		t.Errorf("Expected about 147.2 +/- 66 Elo, got %v +/- %v", elo, margin)
+	}
+	if _, margin := EloEstimate(3, 0, 0); !math.IsInf(margin, 1) {
+		t.Errorf("Expected no error bars with only wins, got %v", margin)
+	}
+}
+
+func TestLOS(t *testing.T) {
+	if los := LOS(10, 10); los != 0.5 {
+		t.Errorf("Expected 50%% with as many wins as losses, got %v", los)
+	}
+	if los := LOS(60, 40); !near(los, 0.9772, 0.0001) {
+		t.Errorf("Expected about 97.7%%, got %v", los)
+	}
+}
+
+func TestSPRT(t *testing.T) {
+	sprt := SPRT{Elo0: 0, Elo1: 10, Alpha: 0.05, Beta: 0.05}
+	if lower, upper := sprt.Bounds(); !near(lower, -2.944, 0.001) || !near(upper, 2.944, 0.001) {
+		t.Errorf("Expected bounds of -2.944 and 2.944, got %v and %v", lower, upper)
+	}
+	if decision := sprt.Decide(400, 200, 300); decision != SPRTAcceptH1 {
+		t.Errorf("Expected a clearly stronger player to accept H1, got %v (LLR %v)", decision, sprt.LLR(400, 200, 300))
+	}
+	if decision := sprt.Decide(300, 200, 400); decision != SPRTAcceptH0 {
+		t.Errorf("Expected a clearly weaker player to accept H0, got %v (LLR %v)", decision, sprt.LLR(300, 200, 400))
+	}
+	if decision := sprt.Decide(11, 10, 10); decision != SPRTContinue {
+		t.Errorf("Expected no decision after a few games, got %v", decision)
+	}
+	if llr := sprt.LLR(0, 0, 0); llr != 0 {
+		t.Errorf("Expected no information without games, got %v", llr)
+	}
+}
+
+func TestSPRT_decides_on_a_clean_sweep(t *testing.T) {
+	sprt := SPRT{Elo0: 0, Elo1: 10, Alpha: 0.05, Beta: 0.05}
+	if decision := sprt.Decide(5, 0, 0); decision != SPRTContinue {
+		t.Errorf("Expected no decision after 5 wins, got %v (LLR %v)", decision, sprt.LLR(5, 0, 0))
+	}
+	if decision := sprt.Decide(20, 0, 0); decision != SPRTAcceptH1 {
+		t.Errorf("Expected 20 wins to accept H1, got %v (LLR %v)", decision, sprt.LLR(20, 0, 0))
+	}
+	if decision := sprt.Decide(0, 0, 20); decision != SPRTAcceptH0 {
+		t.Errorf("Expected 20 losses to accept H0, got %v (LLR %v)", decision, sprt.LLR(0, 0, 20))
+	}
+	if decision := sprt.Decide(0, 200, 0); decision != SPRTAcceptH0 {
+		t.Errorf("Expected 200 draws to accept H0, got %v (LLR %v)", decision, sprt.LLR(0, 200, 0))
+	}
+}
@@ -0,0 +1,472 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+)
+
+// the endings the solver builds tables for, with the pieces of the strong side besides its king
+var solverEndings = map[string][]PieceType{"KQvK": {Queen}, "KRvK": {Rook}, "KPvK": {Pawn}, "KBNvK": {Bishop, Knight}}
+
+// endings that are drawn in every position, reached when the lone king takes a piece
+var solverDrawnEndings = map[string]bool{"KvK": true, "KBvK": true, "KNvK": true}
+
+var errSolverNoDTZ = errors.New("the solver knows the distance to mate, not to zeroing, of endings with pawns")
+
+// solves small endings (KQvK, KRvK, KPvK and KBNvK) by retrograde analysis and answers probes from the in-memory
+// distance to mate tables. A table is built the first time a position of its ending is probed, which takes a few
+// seconds for KBNvK. Pawns only promote to queens, as in the rest of this package
+type EndgameSolver struct {
+	mu     sync.Mutex
+	tables map[string]*endgameTable
+}
+
+func NewEndgameSolver() *EndgameSolver {
+	return &EndgameSolver{tables: map[string]*endgameTable{}}
+}
+
+func (s *EndgameSolver) MaxPieces() int {
+	return 4
+}
+
+func (s *EndgameSolver) ProbeWDL(b *Board, colour Colour) (WDL, error) {
+	wdl, _, err := s.probe(b, colour)
+	return wdl, err
+}
+
+// returns the number of plies to mate, positive when colour to move wins and negative when it loses. It is 0 for
+// draws and when colour is mated
+func (s *EndgameSolver) ProbeDTM(b *Board, colour Colour) (int, error) {
+	_, dtm, err := s.probe(b, colour)
+	return dtm, err
+}
+
+// returns the distance to mate for endings without pawns, where the winning side can not capture or move a pawn
+// before it mates, so the distance to zeroing is the distance to mate
+func (s *EndgameSolver) ProbeDTZ(b *Board, colour Colour) (int, error) {
+	if white, black := tablebaseMaterial(b); strings.Contains(white+black, "P") {
+		return 0, errSolverNoDTZ
+	}
+	return s.ProbeDTM(b, colour)
+}
+
+func (s *EndgameSolver) probe(b *Board, colour Colour) (WDL, int, error) {
+	if !inTablebase(s, b) {
+		return WDLDraw, 0, ErrNotInTablebase
+	}
+	white, black := tablebaseMaterial(b)
+	if solverDrawnEndings[white+"v"+black] || solverDrawnEndings[black+"v"+white] {
+		return WDLDraw, 0, nil
+	}
+	strong, name := White, white+"v"+black
+	if _, ok := solverEndings[name]; !ok {
+		strong, name = Black, black+"v"+white
+	}
+	if _, ok := solverEndings[name]; !ok {
+		return WDLDraw, 0, ErrNotInTablebase
+	}
+	table, err := s.table(name)
+	if err != nil {
+		return WDLDraw, 0, err
+	}
+	squares, err := table.squaresOf(b, strong)
+	if err != nil {
+		return WDLDraw, 0, err
+	}
+	value := table.dtm[table.index(squares, colour == strong)]
+	switch {
+	case value == 0:
+		return WDLDraw, 0, nil
+	case colour == strong:
+		return WDLWin, int(value) - 1, nil
+	default:
+		return WDLLoss, 1 - int(value), nil
+	}
+}
+
+// returns the table of the ending, building it (and the tables it leads to) first if needed
+func (s *EndgameSolver) table(name string) (*endgameTable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.build(name)
+}
+
+func (s *EndgameSolver) build(name string) (*endgameTable, error) {
+	if table, ok := s.tables[name]; ok {
+		return table, nil
+	}
+	pieces, ok := solverEndings[name]
+	if !ok {
+		return nil, fmt.Errorf("the solver has no table for %v", name)
+	}
+	var promoted *endgameTable
+	if pieces[0] == Pawn {
+		var err error
+		if promoted, err = s.build("KQvK"); err != nil {
+			return nil, err
+		}
+	}
+	table, err := solveEndgame(name, pieces, promoted)
+	if err != nil {
+		return nil, err
+	}
+	s.tables[name] = table
+	return table, nil
+}
+
+// the distance to mate of every position of an ending where a lone king defends. A position is indexed by the
+// squares (0 is a1, 63 is h8) of the strong king, the weak king and the other pieces of the strong side, and by the
+// side to move. The board is mirrored when black is the strong side, so pawns always move up
+type endgameTable struct {
+	name   string
+	pieces []PieceType // of the strong side besides its king
+	dtm    []int8      // plies to mate plus one by index, 0 for draws and impossible positions
+}
+
+// returns the index of the position, squares holds the strong king, the weak king and the pieces
+func (t *endgameTable) index(squares []int, strongToMove bool) int {
+	index := 0
+	for _, square := range squares {
+		index = index*64 + square
+	}
+	index *= 2
+	if !strongToMove {
+		index++
+	}
+	return index
+}
+
+// fills squares with the position of the index and returns whether the strong side is to move
+func (t *endgameTable) position(index int, squares []int) bool {
+	strongToMove := index%2 == 0
+	index /= 2
+	for i := len(squares) - 1; i >= 0; i-- {
+		squares[i] = index % 64
+		index /= 64
+	}
+	return strongToMove
+}
+
+// returns the squares of the strong king, the weak king and the pieces of the strong side in the order of the table
+func (t *endgameTable) squaresOf(b *Board, strong Colour) ([]int, error) {
+	squares := make([]int, 2+len(t.pieces))
+	square := func(piece Piece) int {
+		row := piece.CurrentSquare.Row
+		if strong == Black {
+			row = 9 - row
+		}
+		return b.getColumnIndex(piece.CurrentSquare.Column) + 8*(row-1)
+	}
+	strongPieces, weakPieces := b.WhitePieces, b.BlackPieces
+	if strong == Black {
+		strongPieces, weakPieces = weakPieces, strongPieces
+	}
+	for _, piece := range weakPieces {
+		if piece.InPlay && piece.Type == King {
+			squares[1] = square(piece)
+		}
+	}
+	placed := make([]bool, len(t.pieces))
+	for _, piece := range strongPieces {
+		if !piece.InPlay {
+			continue
+		}
+		if piece.Type == King {
+			squares[0] = square(piece)
+			continue
+		}
+		found := false
+		for i, pieceType := range t.pieces {
+			if pieceType == piece.Type && !placed[i] {
+				squares[2+i], placed[i], found = square(piece), true, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("the position is not %v", t.name)
+		}
+	}
+	return squares, nil
+}
+
+var (
+	kingAttacks   [64]uint64
+	knightAttacks [64]uint64
+)
+
+func init() {
+	for square := 0; square < 64; square++ {
+		file, rank := square%8, square/8
+		for _, step := range [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}} {
+			if f, r := file+step[0], rank+step[1]; f >= 0 && f < 8 && r >= 0 && r < 8 {
+				kingAttacks[square] |= 1 << (f + 8*r)
+			}
+		}
+		for _, step := range [][2]int{{-2, -1}, {-2, 1}, {-1, -2}, {-1, 2}, {1, -2}, {1, 2}, {2, -1}, {2, 1}} {
+			if f, r := file+step[0], rank+step[1]; f >= 0 && f < 8 && r >= 0 && r < 8 {
+				knightAttacks[square] |= 1 << (f + 8*r)
+			}
+		}
+	}
+}
+
+// returns whether the piece of the strong side on from attacks target, with the squares in occupied blocking
+// sliders. Pawns attack upwards
+func attacks(pieceType PieceType, from int, target int, occupied uint64) bool {
+	df, dr := target%8-from%8, target/8-from/8
+	switch pieceType {
+	case King:
+		return kingAttacks[from]&(1<<target) != 0
+	case Knight:
+		return knightAttacks[from]&(1<<target) != 0
+	case Pawn:
+		return dr == 1 && (df == 1 || df == -1)
+	}
+	straight, diagonal := df == 0 || dr == 0, df == dr || df == -dr
+	if from == target || (pieceType == Rook && !straight) || (pieceType == Bishop && !diagonal) || (!straight && !diagonal) {
+		return false
+	}
+	step := sign(df) + 8*sign(dr)
+	for square := from + step; square != target; square += step {
+		if occupied&(1<<square) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// the squares of a position being solved, the strong king, the weak king and the pieces
+type solverPosition struct {
+	table   *endgameTable
+	squares []int
+}
+
+func (p solverPosition) pieceType(i int) PieceType {
+	if i < 2 {
+		return King
+	}
+	return p.table.pieces[i-2]
+}
+
+func (p solverPosition) occupied() uint64 {
+	var occupied uint64
+	for _, square := range p.squares {
+		occupied |= 1 << square
+	}
+	return occupied
+}
+
+// returns whether a piece of the strong side attacks target, leaving out the piece with the index skip (-1 for
+// none) as if it was taken
+func (p solverPosition) attacked(target int, occupied uint64, skip int) bool {
+	for i, square := range p.squares {
+		if i != 1 && i != skip && attacks(p.pieceType(i), square, target, occupied) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns whether the squares are a position that can occur: no two pieces on a square, the kings apart and no pawn
+// on the first or last rank. With the strong side to move the weak king must not be in check
+func (p solverPosition) possible(strongToMove bool) bool {
+	occupied := uint64(0)
+	for i, square := range p.squares {
+		if occupied&(1<<square) != 0 {
+			return false
+		}
+		occupied |= 1 << square
+		if p.pieceType(i) == Pawn && (square < 8 || square >= 56) {
+			return false
+		}
+	}
+	if kingAttacks[p.squares[0]]&(1<<p.squares[1]) != 0 {
+		return false
+	}
+	return !strongToMove || !p.attacked(p.squares[1], occupied, -1)
+}
+
+// returns the number of legal moves of the weak king that stay in the ending and whether it can take a piece
+// instead, which draws. Taking the last piece or a piece of KBNvK leaves too little material to mate
+func (p solverPosition) weakMoves() (int, bool) {
+	king, occupied := p.squares[1], p.occupied()
+	count := 0
+	for targets := kingAttacks[king] &^ kingAttacks[p.squares[0]]; targets != 0; targets &= targets - 1 {
+		target := bits.TrailingZeros64(targets)
+		taken := -1
+		for i := 2; i < len(p.squares); i++ {
+			if p.squares[i] == target {
+				taken = i
+			}
+		}
+		if target == p.squares[0] {
+			continue
+		}
+		if p.attacked(target, occupied&^(1<<king), taken) {
+			continue
+		}
+		if taken >= 0 {
+			return count, true
+		}
+		count++
+	}
+	return count, false
+}
+
+// appends the squares the piece with the index i could have come from in its last move, which must be empty now, to
+// origins
+func (p solverPosition) origins(i int, origins []int) []int {
+	square, occupied := p.squares[i], p.occupied()
+	switch p.pieceType(i) {
+	case King:
+		for from := kingAttacks[square] &^ occupied; from != 0; from &= from - 1 {
+			origins = append(origins, bits.TrailingZeros64(from))
+		}
+	case Knight:
+		for from := knightAttacks[square] &^ occupied; from != 0; from &= from - 1 {
+			origins = append(origins, bits.TrailingZeros64(from))
+		}
+	case Pawn:
+		if square >= 16 && occupied&(1<<(square-8)) == 0 {
+			origins = append(origins, square-8)
+			if square/8 == 3 && occupied&(1<<(square-16)) == 0 {
+				origins = append(origins, square-16)
+			}
+		}
+	default:
+		for _, direction := range [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}} {
+			diagonal := direction[0] != 0 && direction[1] != 0
+			if (p.pieceType(i) == Rook && diagonal) || (p.pieceType(i) == Bishop && !diagonal) {
+				continue
+			}
+			for f, r := square%8+direction[0], square/8+direction[1]; f >= 0 && f < 8 && r >= 0 && r < 8; f, r = f+direction[0], r+direction[1] {
+				if occupied&(1<<(f+8*r)) != 0 {
+					break
+				}
+				origins = append(origins, f+8*r)
+			}
+		}
+	}
+	return origins
+}
+
+// solves the ending by retrograde analysis. Mates are found first, then a position with the strong side to move is
+// won in n+1 plies when it has a move to a position lost in n, and a position with the weak side to move is lost in
+// n+1 plies when its last move to a won position is to one won in n. promoted is the table of KQvK for pawn endings
+func solveEndgame(name string, pieces []PieceType, promoted *endgameTable) (*endgameTable, error) {
+	table := &endgameTable{name: name, pieces: pieces}
+	size := 2
+	for i := 0; i < 2+len(pieces); i++ {
+		size *= 64
+	}
+	table.dtm = make([]int8, size)
+	const escapes = 255                // the weak side has a move that does not lose
+	remaining := make([]uint8, size/2) // the moves of the weak side that are not known to lose, by index/2
+
+	position := solverPosition{table: table, squares: make([]int, 2+len(pieces))}
+	current := []int32{}
+	seeds := map[int][]int32{} // positions won by promoting, by the ply of the mate
+	for index := 0; index < size; index++ {
+		strongToMove := table.position(index, position.squares)
+		if !position.possible(strongToMove) {
+			continue
+		}
+		if !strongToMove {
+			count, escape := position.weakMoves()
+			switch {
+			case escape:
+				remaining[index/2] = escapes
+			case count > 0:
+				remaining[index/2] = uint8(count)
+			case position.attacked(position.squares[1], position.occupied(), -1):
+				table.dtm[index] = 1 // mated
+				current = append(current, int32(index))
+			default:
+				remaining[index/2] = escapes // stalemate
+			}
+			continue
+		}
+		if promoted == nil || pieces[0] != Pawn || position.squares[2]/8 != 6 {
+			continue
+		}
+		to := position.squares[2] + 8
+		if to == position.squares[0] || to == position.squares[1] {
+			continue
+		}
+		if value := promoted.dtm[promoted.index([]int{position.squares[0], position.squares[1], to}, false)]; value != 0 {
+			seeds[int(value)] = append(seeds[int(value)], int32(index))
+		}
+	}
+
+	predecessor := solverPosition{table: table, squares: make([]int, len(position.squares))}
+	origins := make([]int, 0, 32)
+	for ply := 0; len(current) > 0 || len(seeds) > 0; ply++ {
+		if ply+2 > 127 {
+			return nil, fmt.Errorf("%v has mates too long for the table", name)
+		}
+		for _, index := range seeds[ply] {
+			if table.dtm[index] == 0 {
+				table.dtm[index] = int8(ply + 1)
+				current = append(current, index)
+			}
+		}
+		delete(seeds, ply)
+		next := []int32{}
+		for _, index := range current {
+			strongToMove := table.position(int(index), position.squares)
+			copy(predecessor.squares, position.squares)
+			if !strongToMove {
+				// lost, so the positions the strong side moved from are won
+				for i := range position.squares {
+					if i == 1 {
+						continue
+					}
+					origins = position.origins(i, origins[:0])
+					for _, from := range origins {
+						predecessor.squares[i] = from
+						previous := table.index(predecessor.squares, true)
+						if table.dtm[previous] == 0 && predecessor.possible(true) {
+							table.dtm[previous] = int8(ply + 2)
+							next = append(next, int32(previous))
+						}
+					}
+					predecessor.squares[i] = position.squares[i]
+				}
+				continue
+			}
+			// won, so the positions the weak king moved from lose once all their moves lead to won positions
+			origins = position.origins(1, origins[:0])
+			for _, from := range origins {
+				if kingAttacks[position.squares[0]]&(1<<from) != 0 {
+					continue
+				}
+				predecessor.squares[1] = from
+				previous := table.index(predecessor.squares, false)
+				if table.dtm[previous] != 0 || remaining[previous/2] == escapes || remaining[previous/2] == 0 {
+					continue
+				}
+				remaining[previous/2]--
+				if remaining[previous/2] == 0 {
+					table.dtm[previous] = int8(ply + 2)
+					next = append(next, int32(previous))
+				}
+			}
+		}
+		current = next
+	}
+	return table, nil
+}
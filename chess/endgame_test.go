@@ -0,0 +1,173 @@
+package chess
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// returns the FEN of a position of the table with white as the strong side
+func solverFEN(table *endgameTable, squares []int, strongToMove bool) string {
+	board := [64]string{}
+	board[squares[0]], board[squares[1]] = "K", "k"
+	for i, pieceType := range table.pieces {
+		board[squares[2+i]] = tablebasePieceLetters[pieceType]
+	}
+	var fen strings.Builder
+	for rank := 7; rank >= 0; rank-- {
+		empty := 0
+		for file := 0; file < 8; file++ {
+			if board[file+8*rank] == "" {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				fen.WriteByte(byte('0' + empty))
+				empty = 0
+			}
+			fen.WriteString(board[file+8*rank])
+		}
+		if empty > 0 {
+			fen.WriteByte(byte('0' + empty))
+		}
+		if rank > 0 {
+			fen.WriteString("/")
+		}
+	}
+	if strongToMove {
+		return fen.String() + " w - - 0 1"
+	}
+	return fen.String() + " b - - 0 1"
+}
+
+// the longest wins are well known: mate in 10 moves for KQvK, 16 for KRvK and 33 for KBNvK
+func TestEndgameSolver_longest_mates(t *testing.T) {
+	tests := map[string]int{"KQvK": 19, "KRvK": 31, "KBNvK": 65}
+	solver := NewEndgameSolver()
+	for name, expected := range tests {
+		if name == "KBNvK" && testing.Short() {
+			continue // takes several seconds
+		}
+		table, err := solver.table(name)
+		if err != nil {
+			t.Fatalf("Failed to solve %v, %v", name, err)
+		}
+		longest := 0
+		for index := 0; index < len(table.dtm); index += 2 {
+			if plies := int(table.dtm[index]) - 1; plies > longest {
+				longest = plies
+			}
+		}
+		if longest != expected {
+			t.Errorf("Expected the longest %v win to take %v plies, got %v", name, expected, longest)
+		}
+	}
+}
+
+// every position with the weak side to move where the solver finds no moves and a sample of the others are checked
+// with the move generator of the board. King and pawn can not mate before the pawn promotes
+func TestEndgameSolver_agrees_with_the_board_on_mates_and_stalemates(t *testing.T) {
+	solver := NewEndgameSolver()
+	for _, name := range []string{"KQvK", "KRvK", "KPvK"} {
+		table, err := solver.table(name)
+		if err != nil {
+			t.Fatalf("Failed to solve %v, %v", name, err)
+		}
+		position := solverPosition{table: table, squares: make([]int, 3)}
+		mates, stalemates := 0, 0
+		for index := 1; index < len(table.dtm); index += 2 {
+			table.position(index, position.squares)
+			if !position.possible(false) {
+				continue
+			}
+			count, escape := position.weakMoves()
+			if (count > 0 || escape) && index%193 != 1 {
+				continue
+			}
+			fen := solverFEN(table, position.squares, false)
+			game, err := ParseFEN(fen)
+			if err != nil {
+				t.Fatalf("Failed to parse %q, %v", fen, err)
+			}
+			noMoves := len(game.Board.LegalMovesFor(Black)) == 0
+			check := game.Board.IsCheck(Black)
+			if noMoves != (count == 0 && !escape) {
+				t.Errorf("%v: the solver counts %v moves (taking a piece: %v), the board %v", fen, count, escape, len(game.Board.LegalMovesFor(Black)))
+			}
+			if mated := table.dtm[index] == 1; mated != (noMoves && check) {
+				t.Errorf("%v: the solver says mate is %v, the board says %v", fen, mated, noMoves && check)
+			}
+			if mated := table.dtm[index] == 1; mated {
+				mates++
+			} else if noMoves {
+				stalemates++
+			}
+		}
+		if (mates == 0) != (name == "KPvK") || stalemates == 0 {
+			t.Errorf("Expected stalemates and mates without pawns in %v, got %v and %v", name, stalemates, mates)
+		}
+	}
+}
+
+func TestEndgameSolver_probe(t *testing.T) {
+	tests := []struct {
+		fen string
+		wdl WDL
+		dtm int
+	}{
+		{"7k/8/5K2/8/8/8/8/6Q1 w - - 0 1", WDLWin, 1},
+		{"6q1/8/8/8/8/5k2/8/7K b - - 0 1", WDLWin, 1}, // black is the strong side
+		{"7k/6Q1/5K2/8/8/8/8/8 b - - 0 1", WDLLoss, 0},
+		{"7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", WDLDraw, 0}, // stalemate
+		{"8/4P3/4K3/8/8/8/8/k7 w - - 0 1", WDLWin, 0},
+		{"k7/8/8/8/8/8/P7/K7 w - - 0 1", WDLDraw, 0}, // the rook pawn does not win against a king in front of it
+		{"k7/8/8/8/8/8/8/K7 w - - 0 1", WDLDraw, 0},
+		{"k7/8/8/8/8/8/8/KB6 w - - 0 1", WDLDraw, 0},
+	}
+	solver := NewEndgameSolver()
+	for _, test := range tests {
+		position, err := ParseFEN(test.fen)
+		if err != nil {
+			t.Fatalf("Failed to parse %q, %v", test.fen, err)
+		}
+		wdl, err := solver.ProbeWDL(position.Board, position.NextToMove)
+		if err != nil || wdl != test.wdl {
+			t.Errorf("Expected %q to be a %v, got %v (%v)", test.fen, test.wdl, wdl, err)
+		}
+		if test.dtm == 0 {
+			continue
+		}
+		if dtm, err := solver.ProbeDTM(position.Board, position.NextToMove); err != nil || dtm != test.dtm {
+			t.Errorf("Expected %q to be mate in %v plies, got %v (%v)", test.fen, test.dtm, dtm, err)
+		}
+	}
+
+	position, _ := ParseFEN("k7/8/8/8/8/8/8/KQr5 w - - 0 1")
+	if _, err := solver.ProbeWDL(position.Board, White); !errors.Is(err, ErrNotInTablebase) {
+		t.Errorf("Expected KQvKR not to be solved, got %v", err)
+	}
+	position, _ = ParseFEN("8/4P3/4K3/8/8/8/8/k7 w - - 0 1")
+	if _, err := solver.ProbeDTZ(position.Board, White); err == nil {
+		t.Errorf("Expected no distance to zeroing for a pawn ending")
+	}
+}
+
+// with perfect play from both sides the game takes exactly as long as the distance to mate
+func TestTablebasePlayer_mates_with_the_solver(t *testing.T) {
+	defer quiet()()
+	solver := NewEndgameSolver()
+	for _, fen := range []string{"8/8/8/4k3/8/8/8/R3K3 w - - 0 1", "3k4/8/3K4/3P4/8/8/8/8 w - - 0 1"} {
+		game, err := NewGameFromFEN(NewTablebasePlayer(solver, nil), NewTablebasePlayer(solver, nil), &noopVisualizer{}, fen)
+		if err != nil {
+			t.Fatalf("Failed to create game, %v", err)
+		}
+		dtm, err := solver.ProbeDTM(game.Board, White)
+		if err != nil || dtm <= 0 {
+			t.Fatalf("Expected %q to be won, got %v (%v)", fen, dtm, err)
+		}
+		result := game.Start()
+		if winner, ok := result.Winner(); !ok || winner != White || result.Termination != Checkmate || len(game.History) != dtm {
+			t.Errorf("Expected %q to be mate in %v plies, got %v after %v plies", fen, dtm, result, len(game.History))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+)
+
+// why a move was rejected
+type IllegalMoveReason int64
+
+const (
+	NoPiece IllegalMoveReason = iota
+	NotYourPiece
+	NotYourTurn
+	AlreadyThere
+	OffBoard
+	PieceNotInPlay
+	InvalidPieceMove // the piece can not move like that (e.g. a rook moving diagonally)
+	PathBlocked
+	SquareOccupied
+	LeavesKingInCheck
+	CastlingNotAllowed
+	KingIsInMate
+	ForbiddenByVariant // the move is legal in standard chess but not in the variant being played
+	NotInPocket        // there is no such piece to drop (crazyhouse)
+)
+
+var (
+	ErrNoPiece            = errors.New("no piece at square")
+	ErrNotYourPiece       = errors.New("not your piece")
+	ErrNotYourTurn        = errors.New("not your turn")
+	ErrAlreadyThere       = errors.New("already there")
+	ErrOffBoard           = errors.New("square is not on the board")
+	ErrPieceNotInPlay     = errors.New("piece is not in play")
+	ErrInvalidPieceMove   = errors.New("piece can not move like that")
+	ErrPathBlocked        = errors.New("cannot jump over other pieces")
+	ErrSquareOccupied     = errors.New("square is occupied by own piece")
+	ErrLeavesKingInCheck  = errors.New("move leaves own king in check")
+	ErrCastlingNotAllowed = errors.New("castling not allowed")
+	ErrKingIsInMate       = errors.New("king is in mate")
+	ErrForbiddenByVariant = errors.New("move is not allowed in this variant")
+	ErrNotInPocket        = errors.New("piece is not in your pocket")
+)
+
+var illegalMoveSentinels = map[IllegalMoveReason]error{
+	NoPiece:            ErrNoPiece,
+	NotYourPiece:       ErrNotYourPiece,
+	NotYourTurn:        ErrNotYourTurn,
+	AlreadyThere:       ErrAlreadyThere,
+	OffBoard:           ErrOffBoard,
+	PieceNotInPlay:     ErrPieceNotInPlay,
+	InvalidPieceMove:   ErrInvalidPieceMove,
+	PathBlocked:        ErrPathBlocked,
+	SquareOccupied:     ErrSquareOccupied,
+	LeavesKingInCheck:  ErrLeavesKingInCheck,
+	CastlingNotAllowed: ErrCastlingNotAllowed,
+	KingIsInMate:       ErrKingIsInMate,
+	ForbiddenByVariant: ErrForbiddenByVariant,
+	NotInPocket:        ErrNotInPocket,
+}
+
+func (r IllegalMoveReason) String() string {
+	if sentinel, ok := illegalMoveSentinels[r]; ok {
+		return sentinel.Error()
+	}
+	return "unknown reason"
+}
+
+// returned when a move is rejected, use errors.Is with the Err* sentinels or errors.As to get the Reason
+type IllegalMoveError struct {
+	Reason IllegalMoveReason
+	Detail string // the full explanation, e.g. "pawns can only move forward"
+}
+
+func illegalMove(reason IllegalMoveReason, format string, a ...any) *IllegalMoveError {
+	return &IllegalMoveError{Reason: reason, Detail: fmt.Sprintf(format, a...)}
+}
+
+func (e *IllegalMoveError) Error() string {
+	if e.Detail == "" {
+		return e.Reason.String()
+	}
+	return e.Detail
+}
+
+// makes errors.Is(err, ErrPathBlocked) and friends work
+func (e *IllegalMoveError) Unwrap() error {
+	return illegalMoveSentinels[e.Reason]
+}
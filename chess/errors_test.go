@@ -0,0 +1,107 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIllegalMoveErrors_can_be_told_apart(t *testing.T) {
+	defer quiet()()
+	board := NewBoard()
+	_, a1Rook := board.GetPieceAtSquare("A", 1)
+	_, e1King := board.GetPieceAtSquare("E", 1)
+	_, e2Pawn := board.GetPieceAtSquare("E", 2)
+
+	cases := []struct {
+		name     string
+		piece    *Piece
+		to       Square
+		sentinel error
+		reason   IllegalMoveReason
+	}{
+		{"rook moving diagonally", a1Rook, Square{"B", 2}, ErrInvalidPieceMove, InvalidPieceMove},
+		{"rook jumping over pawn", a1Rook, Square{"A", 5}, ErrPathBlocked, PathBlocked},
+		{"rook onto own pawn", a1Rook, Square{"A", 2}, ErrSquareOccupied, SquareOccupied},
+		{"rook staying put", a1Rook, Square{"A", 1}, ErrAlreadyThere, AlreadyThere},
+		{"pawn moving backwards", e2Pawn, Square{"E", 1}, ErrInvalidPieceMove, InvalidPieceMove},
+		{"king castling through pieces", e1King, Square{"G", 1}, ErrCastlingNotAllowed, CastlingNotAllowed},
+		{"pawn off the board", e2Pawn, Square{"E", 9}, ErrOffBoard, OffBoard},
+	}
+	for _, c := range cases {
+		_, err := c.piece.Move(c.to.Column, c.to.Row, board, false)
+		if !errors.Is(err, c.sentinel) {
+			t.Errorf("%v: expected error to be %v, got %v", c.name, c.sentinel, err)
+		}
+		var illegalMoveErr *IllegalMoveError
+		if !errors.As(err, &illegalMoveErr) || illegalMoveErr.Reason != c.reason {
+			t.Errorf("%v: expected IllegalMoveError with reason %v, got %v", c.name, c.reason, err)
+		}
+	}
+}
+
+func TestIllegalMoveErrors_leaves_king_in_check(t *testing.T) {
+	defer quiet()()
+	board := NewBoard()
+	// pin the white queen on E2 with a black rook on E5
+	_, e7Pawn := board.GetPieceAtSquare("E", 7)
+	e7Pawn.InPlay = false
+	_, e2Pawn := board.GetPieceAtSquare("E", 2)
+	e2Pawn.InPlay = false
+	_, queen := board.GetPieceAtSquare("D", 1)
+	queen.CurrentSquare = Square{"E", 2}
+	_, h8Rook := board.GetPieceAtSquare("H", 8)
+	h8Rook.CurrentSquare = Square{"E", 5}
+
+	_, err := queen.Move("D", 3, board, false)
+	if !errors.Is(err, ErrLeavesKingInCheck) {
+		t.Errorf("Expected moving a pinned queen to leave the king in check, got %v", err)
+	}
+}
+
+func TestIllegalMoveErrors_keep_their_reason_when_in_check(t *testing.T) {
+	defer quiet()()
+	board := NewBoard()
+	// check the white king on E1 with a black rook on E5
+	_, e2Pawn := board.GetPieceAtSquare("E", 2)
+	e2Pawn.InPlay = false
+	_, h8Rook := board.GetPieceAtSquare("H", 8)
+	h8Rook.CurrentSquare = Square{"E", 5}
+	_, h1Rook := board.GetPieceAtSquare("H", 1)
+	_, a1Rook := board.GetPieceAtSquare("A", 1)
+	_, a2Pawn := board.GetPieceAtSquare("A", 2)
+	_, b1Knight := board.GetPieceAtSquare("B", 1)
+
+	cases := []struct {
+		name   string
+		piece  *Piece
+		to     Square
+		reason IllegalMoveReason
+	}{
+		{"rook moving like a knight", h1Rook, Square{"G", 3}, InvalidPieceMove},
+		{"pawn moving three squares", a2Pawn, Square{"A", 5}, InvalidPieceMove},
+		{"rook jumping over pawn", a1Rook, Square{"A", 5}, PathBlocked},
+		{"knight onto own pawn", b1Knight, Square{"D", 2}, SquareOccupied},
+		{"legally shaped move", a2Pawn, Square{"A", 3}, LeavesKingInCheck},
+	}
+	for _, c := range cases {
+		_, err := c.piece.Move(c.to.Column, c.to.Row, board, false)
+		var illegalMoveErr *IllegalMoveError
+		if !errors.As(err, &illegalMoveErr) || illegalMoveErr.Reason != c.reason {
+			t.Errorf("%v: expected IllegalMoveError with reason %v, got %v", c.name, c.reason, err)
+		}
+	}
+}
+
+func TestGameMove_rejects_moves_out_of_turn(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if _, err := game.move(Move{From: Square{"E", 7}, To: Square{"E", 5}}, Black); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Expected ErrNotYourTurn, got %v", err)
+	}
+	if _, err := game.move(Move{From: Square{"E", 7}, To: Square{"E", 5}}, White); !errors.Is(err, ErrNotYourPiece) {
+		t.Errorf("Expected ErrNotYourPiece, got %v", err)
+	}
+	if _, err := game.move(Move{From: Square{"E", 4}, To: Square{"E", 5}}, White); !errors.Is(err, ErrNoPiece) {
+		t.Errorf("Expected ErrNoPiece, got %v", err)
+	}
+}
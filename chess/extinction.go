@@ -0,0 +1,57 @@
+package chess
+
+import "fmt"
+
+// the king is an ordinary piece (there is no check) and a player loses when all pieces of any type it had are
+// taken, e.g. both knights or all pawns. A promoted pawn is no longer a pawn, so promoting the last pawn loses too
+type Extinction struct {
+	Standard
+}
+
+func (Extinction) Name() string {
+	return "Extinction"
+}
+
+func (Extinction) KingIsRoyal() bool {
+	return false
+}
+
+func (Extinction) GameOver(g *Game) (Result, bool) {
+	// the player that just moved wins if both sides lose a piece type at once (e.g. a promotion that takes)
+	justMoved := opponentOf(g.NextToMove)
+	for _, colour := range []Colour{g.NextToMove, justMoved} {
+		if pieceType, extinct := g.Board.extinctPieceType(colour); extinct {
+			return winFor(opponentOf(colour), VariantRule, fmt.Sprintf("%v has no %vs left", colour, pieceType)), true
+		}
+	}
+	if len(g.LegalMoves()) == 0 {
+		return drawBy(Stalemate, "Stale mate"), true
+	}
+	return Result{}, false
+}
+
+// returns a piece type the colour has had on the board but lost all of, promoted pawns count as lost pawns
+func (b *Board) extinctPieceType(colour Colour) (PieceType, bool) {
+	pieces := b.WhitePieces
+	if colour == Black {
+		pieces = b.BlackPieces
+	}
+	inPlay := map[PieceType]int{}
+	for _, piece := range pieces {
+		if _, ok := inPlay[piece.Type]; !ok {
+			inPlay[piece.Type] = 0
+		}
+		if piece.InPlay {
+			inPlay[piece.Type]++
+		}
+		if _, ok := inPlay[Pawn]; !ok && piece.promoted {
+			inPlay[Pawn] = 0 // the colour had this pawn
+		}
+	}
+	for _, pieceType := range []PieceType{King, Queen, Rook, Bishop, Knight, Pawn} {
+		if count, ok := inPlay[pieceType]; ok && count == 0 {
+			return pieceType, true
+		}
+	}
+	return Pawn, false
+}
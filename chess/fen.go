@@ -0,0 +1,396 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// a position parsed from (or written to) Forsyth-Edwards Notation
+type Position struct {
+	Board          *Board
+	NextToMove     Colour
+	HalfmoveClock  int
+	FullmoveNumber int
+}
+
+var fenPieceTypes = map[rune]PieceType{'p': Pawn, 'n': Knight, 'b': Bishop, 'r': Rook, 'q': Queen, 'k': King}
+
+const StartingPositionFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// parses a FEN string. The castling field can be written as standard FEN (KQkq), X-FEN (KQkq, with a column letter
+// when the castling rook is not the outermost one) or Shredder-FEN (the columns of the castling rooks, e.g. HAha).
+// Castling rights with the king or rook on non-standard squares makes the board a chess960 board and a pocket
+// (e.g. [Qn] after the rows, promoted pawns are marked with ~) makes it a crazyhouse board.
+func ParseFEN(fen string) (*Position, error) {
+	fields := strings.Fields(fen)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("invalid FEN %q, expected at least 4 fields", fen)
+	}
+	b := newEmptyBoard()
+	// crazyhouse pockets are written in brackets after the rows (or as a 9th row)
+	placement, pocket, hasPocket := fields[0], "", false
+	if i := strings.Index(placement, "["); i >= 0 && strings.HasSuffix(placement, "]") {
+		placement, pocket, hasPocket = placement[:i], placement[i+1:len(placement)-1], true
+	}
+	ranks := strings.Split(placement, "/")
+	if len(ranks) == 9 && !hasPocket {
+		ranks, pocket, hasPocket = ranks[:8], ranks[8], true
+	}
+	if len(ranks) != 8 {
+		return nil, fmt.Errorf("invalid FEN %q, expected 8 rows", fen)
+	}
+	for i, rank := range ranks {
+		row := 8 - i
+		columnIndex := 0
+		for _, r := range rank {
+			if r >= '1' && r <= '8' {
+				columnIndex += int(r - '0')
+				continue
+			}
+			if r == '~' && columnIndex > 0 {
+				// the piece before is a promoted pawn
+				_, promotedPiece := b.GetPieceAtSquare(b.columns[columnIndex-1], row)
+				promotedPiece.promoted = true
+				continue
+			}
+			pieceType, ok := fenPieceTypes[toLowerRune(r)]
+			if !ok || columnIndex > 7 {
+				return nil, fmt.Errorf("invalid FEN %q, unexpected %q on row %v", fen, r, row)
+			}
+			colour := Black
+			if r >= 'A' && r <= 'Z' {
+				colour = White
+			}
+			square := Square{Column: b.columns[columnIndex], Row: row}
+			piece := Piece{Type: pieceType, CurrentSquare: square, Colour: colour, InPlay: true, hasMoved: true}
+			if pieceType == Pawn {
+				piece.hasMoved = !onStartingSquare(Pawn, colour, square)
+			}
+			if colour == White {
+				b.WhitePieces = append(b.WhitePieces, piece)
+			} else {
+				b.BlackPieces = append(b.BlackPieces, piece)
+			}
+			columnIndex++
+		}
+		if columnIndex != 8 {
+			return nil, fmt.Errorf("invalid FEN %q, row %v does not have 8 columns", fen, row)
+		}
+	}
+
+	if hasPocket {
+		for _, r := range pocket {
+			pieceType, ok := fenPieceTypes[toLowerRune(r)]
+			if !ok || pieceType == King {
+				return nil, fmt.Errorf("invalid FEN %q, unexpected %q in pocket", fen, r)
+			}
+			colour := Black
+			if r >= 'A' && r <= 'Z' {
+				colour = White
+			}
+			b.pockets[colour][pieceType]++
+		}
+		b.variant = Crazyhouse{}
+	}
+
+	position := &Position{Board: b, HalfmoveClock: 0, FullmoveNumber: 1}
+	switch fields[1] {
+	case "w":
+		position.NextToMove = White
+	case "b":
+		position.NextToMove = Black
+	default:
+		return nil, fmt.Errorf("invalid FEN %q, side to move must be w or b", fen)
+	}
+	if err := b.setCastlingRights(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid FEN %q, %v", fen, err)
+	}
+	if err := b.setEnPassantSquare(fields[3], position.NextToMove); err != nil {
+		return nil, fmt.Errorf("invalid FEN %q, %v", fen, err)
+	}
+	if len(fields) > 4 {
+		halfmoveClock, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid FEN %q, halfmove clock must be a number", fen)
+		}
+		position.HalfmoveClock = halfmoveClock
+	}
+	if len(fields) > 5 {
+		fullmoveNumber, err := strconv.Atoi(fields[5])
+		if err != nil {
+			return nil, fmt.Errorf("invalid FEN %q, fullmove number must be a number", fen)
+		}
+		position.FullmoveNumber = fullmoveNumber
+	}
+	return position, nil
+}
+
+func toLowerRune(r rune) rune {
+	if r >= 'A' && r <= 'Z' {
+		return r + ('a' - 'A')
+	}
+	return r
+}
+
+// marks the kings and castling rooks as not moved according to the castling field of a FEN string
+func (b *Board) setCastlingRights(castling string) error {
+	if castling == "-" {
+		return nil
+	}
+	for _, r := range castling {
+		colour, homeRow, pieces := Black, 8, b.BlackPieces
+		if r >= 'A' && r <= 'Z' {
+			colour, homeRow, pieces = White, 1, b.WhitePieces
+		}
+		king := b.getKing(colour)
+		if king.Type != King || king.CurrentSquare.Row != homeRow {
+			return fmt.Errorf("castling right %q without a king on row %v", r, homeRow)
+		}
+		kingColumnIndex := b.getColumnIndex(king.CurrentSquare.Column)
+
+		var rook *Piece
+		side := kingside
+		switch lower := toLowerRune(r); {
+		case lower == 'k' || lower == 'q':
+			// the outermost rook on that side of the king
+			if lower == 'q' {
+				side = queenside
+			}
+			for i := range pieces {
+				piece := &pieces[i]
+				if piece.Type != Rook || !piece.InPlay || piece.CurrentSquare.Row != homeRow {
+					continue
+				}
+				columnIndex := b.getColumnIndex(piece.CurrentSquare.Column)
+				if side == kingside && columnIndex > kingColumnIndex && (rook == nil || columnIndex > b.getColumnIndex(rook.CurrentSquare.Column)) {
+					rook = piece
+				}
+				if side == queenside && columnIndex < kingColumnIndex && (rook == nil || columnIndex < b.getColumnIndex(rook.CurrentSquare.Column)) {
+					rook = piece
+				}
+			}
+		case lower >= 'a' && lower <= 'h':
+			// the column of the castling rook (X-FEN and Shredder-FEN)
+			column := strings.ToUpper(string(lower))
+			if b.getColumnIndex(column) < kingColumnIndex {
+				side = queenside
+			}
+			if found, piece := b.GetPieceAtSquare(column, homeRow); found && piece.Type == Rook && piece.Colour == colour {
+				rook = piece
+			}
+		default:
+			return fmt.Errorf("unknown castling right %q", r)
+		}
+		if rook == nil {
+			return fmt.Errorf("castling right %q without a rook", r)
+		}
+		rook.hasMoved = false
+		king.hasMoved = false
+		b.castlingRookColumns[colour][side] = rook.CurrentSquare.Column
+		if king.CurrentSquare.Column != "E" || (side == kingside && rook.CurrentSquare.Column != "H") || (side == queenside && rook.CurrentSquare.Column != "A") {
+			b.chess960 = true
+		}
+	}
+	return nil
+}
+
+// sets the opponents last move to the pawn move that allows an en passant capture on the given square
+func (b *Board) setEnPassantSquare(enPassant string, nextToMove Colour) error {
+	if enPassant == "-" {
+		return nil
+	}
+	if len(enPassant) != 2 {
+		return fmt.Errorf("invalid en passant square %q", enPassant)
+	}
+	column := strings.ToUpper(enPassant[:1])
+	row, err := strconv.Atoi(enPassant[1:])
+	if err != nil {
+		return fmt.Errorf("invalid en passant square %q", enPassant)
+	}
+	// the pawn that moved two squares is in front of the en passant square (as seen from the pawn)
+	if nextToMove == White && row == 6 {
+		found, pawn := b.GetPieceAtSquare(column, 5)
+		if !found || pawn.Type != Pawn || pawn.Colour != Black {
+			return fmt.Errorf("no black pawn in front of en passant square %q", enPassant)
+		}
+		b.blacksLastMove = LastMove{Piece: pawn, Move: &Move{From: Square{column, 7}, To: Square{column, 5}}}
+		b.lastMoveColour = Black
+		return nil
+	}
+	if nextToMove == Black && row == 3 {
+		found, pawn := b.GetPieceAtSquare(column, 4)
+		if !found || pawn.Type != Pawn || pawn.Colour != White {
+			return fmt.Errorf("no white pawn in front of en passant square %q", enPassant)
+		}
+		b.whitesLastMove = LastMove{Piece: pawn, Move: &Move{From: Square{column, 2}, To: Square{column, 4}}}
+		b.lastMoveColour = White
+		return nil
+	}
+	return fmt.Errorf("invalid en passant square %q for %v to move", enPassant, nextToMove)
+}
+
+// returns the position as a FEN string, castling rights are written as X-FEN
+func (p *Position) FEN() string {
+	return p.Board.fen(p.NextToMove, p.HalfmoveClock, p.FullmoveNumber, false)
+}
+
+// returns the position as a FEN string with castling rights written as Shredder-FEN
+func (p *Position) ShredderFEN() string {
+	return p.Board.fen(p.NextToMove, p.HalfmoveClock, p.FullmoveNumber, true)
+}
+
+func (b *Board) fen(nextToMove Colour, halfmoveClock int, fullmoveNumber int, shredder bool) string {
+	_, crazyhouse := b.variant.(Crazyhouse)
+	var fen strings.Builder
+	for row := 8; row >= 1; row-- {
+		empty := 0
+		for _, column := range b.columns {
+			occupied, piece := b.GetPieceAtSquare(column, row)
+			if !occupied {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				fen.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			fen.WriteString(piece.fenLetter())
+			if crazyhouse && piece.promoted {
+				fen.WriteString("~")
+			}
+		}
+		if empty > 0 {
+			fen.WriteString(strconv.Itoa(empty))
+		}
+		if row > 1 {
+			fen.WriteString("/")
+		}
+	}
+	if crazyhouse {
+		fen.WriteString(b.pocketsFEN())
+	}
+	if nextToMove == White {
+		fen.WriteString(" w ")
+	} else {
+		fen.WriteString(" b ")
+	}
+	fen.WriteString(b.castlingRightsFEN(shredder))
+	fen.WriteString(" ")
+	fen.WriteString(b.enPassantSquareFEN(nextToMove))
+	fen.WriteString(fmt.Sprintf(" %v %v", halfmoveClock, fullmoveNumber))
+	return fen.String()
+}
+
+// returns the piece as a FEN letter, upper case for white and lower case for black
+func (p *Piece) fenLetter() string {
+	letter := "?"
+	for r, pieceType := range fenPieceTypes {
+		if pieceType == p.Type {
+			letter = string(r)
+		}
+	}
+	if p.Colour == White {
+		return strings.ToUpper(letter)
+	}
+	return letter
+}
+
+func (b *Board) castlingRightsFEN(shredder bool) string {
+	var rights strings.Builder
+	for _, colour := range []Colour{White, Black} {
+		king := b.getKing(colour)
+		if king.Type != King || king.hasMoved {
+			continue
+		}
+		for _, side := range []castleSide{kingside, queenside} {
+			rook, err := selectRookForCastling(king, side, b)
+			if err != nil || rook.hasMoved {
+				continue
+			}
+			right := strings.ToLower(rook.CurrentSquare.Column)
+			if !shredder && b.isOutermostRook(rook, side) {
+				right = "k"
+				if side == queenside {
+					right = "q"
+				}
+			}
+			if colour == White {
+				right = strings.ToUpper(right)
+			}
+			rights.WriteString(right)
+		}
+	}
+	if rights.Len() == 0 {
+		return "-"
+	}
+	return rights.String()
+}
+
+// returns true if there is no other rook of the same colour further out on the castling side
+func (b *Board) isOutermostRook(rook *Piece, side castleSide) bool {
+	pieces := b.WhitePieces
+	if rook.Colour == Black {
+		pieces = b.BlackPieces
+	}
+	rookColumnIndex := b.getColumnIndex(rook.CurrentSquare.Column)
+	for _, piece := range pieces {
+		if piece.Type != Rook || !piece.InPlay || piece.CurrentSquare.Row != rook.CurrentSquare.Row {
+			continue
+		}
+		columnIndex := b.getColumnIndex(piece.CurrentSquare.Column)
+		if (side == kingside && columnIndex > rookColumnIndex) || (side == queenside && columnIndex < rookColumnIndex) {
+			return false
+		}
+	}
+	return true
+}
+
+// returns the en passant square when nextToMove can actually capture en passant, "-" otherwise. Only the pawns
+// next to the pawn that moved two squares can capture, and only when it does not leave their king in check
+func (b *Board) enPassantCaptureSquare(nextToMove Colour) string {
+	square := b.enPassantSquareFEN(nextToMove)
+	if square == "-" {
+		return square
+	}
+	lastMove := b.blacksLastMove
+	if nextToMove == Black {
+		lastMove = b.whitesLastMove
+	}
+	target := lastMove.Move.To
+	_, captured := b.GetPieceAtSquare(target.Column, target.Row)
+	column, row := strings.ToUpper(square[:1]), int(square[1]-'0')
+	for _, neighbour := range []int{b.getColumnIndex(target.Column) - 1, b.getColumnIndex(target.Column) + 1} {
+		if neighbour < 0 || neighbour > 7 {
+			continue
+		}
+		occupied, pawn := b.GetPieceAtSquare(b.columns[neighbour], target.Row)
+		if !occupied || pawn.Type != Pawn || pawn.Colour != nextToMove {
+			continue
+		}
+		// the captured pawn leaves the board too, which matters when both pawns shield the king
+		captured.InPlay = false
+		legal := pawn.MoveIsLegal(column, row, b)
+		captured.InPlay = true
+		if legal {
+			return square
+		}
+	}
+	return "-"
+}
+
+func (b *Board) enPassantSquareFEN(nextToMove Colour) string {
+	lastMove := b.blacksLastMove
+	fromRow, toRow, behindRow := 7, 5, 6
+	if nextToMove == Black {
+		lastMove = b.whitesLastMove
+		fromRow, toRow, behindRow = 2, 4, 3
+	}
+	if lastMove.Piece == nil || lastMove.Move == nil || lastMove.Piece.Type != Pawn {
+		return "-"
+	}
+	if lastMove.Move.From.Row != fromRow || lastMove.Move.To.Row != toRow || lastMove.Piece.CurrentSquare != lastMove.Move.To {
+		return "-"
+	}
+	return fmt.Sprintf("%v%v", strings.ToLower(lastMove.Move.To.Column), behindRow)
+}
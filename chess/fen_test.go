@@ -0,0 +1,108 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestParseFEN_starting_position_round_trip(t *testing.T) {
+	defer quiet()()
+	position, err := ParseFEN(StartingPositionFEN)
+	if err != nil {
+		t.Errorf("Failed to parse FEN, %v", err)
+		return
+	}
+	expectedStateOfBoard := `
+	♜  ♞  ♝  ♛  ♚  ♝  ♞  ♜
+	♟  ♟  ♟  ♟  ♟  ♟  ♟  ♟
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	♙  ♙  ♙  ♙  ♙  ♙  ♙  ♙
+	♖  ♘  ♗  ♕  ♔  ♗  ♘  ♖
+	`
+	if err := assertExpectedBoardState(expectedStateOfBoard, position.Board); err != nil {
+		t.Errorf("Failed to assert expected board state, %v (Visible whitespace is ignored, something else differs!", err.Error())
+	}
+	if position.Board.IsChess960() {
+		t.Errorf("Expected the standard starting position not to be a chess960 board")
+	}
+	if fen := position.FEN(); fen != StartingPositionFEN {
+		t.Errorf("Expected %v, got %v", StartingPositionFEN, fen)
+	}
+	if fen := position.ShredderFEN(); fen != "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w HAha - 0 1" {
+		t.Errorf("Expected Shredder-FEN castling rights HAha, got %v", fen)
+	}
+}
+
+func TestGameFEN_tracks_en_passant_and_move_counters(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if err := playMoves(game, []Move{{From: Square{"E", 2}, To: Square{"E", 4}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	expected := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
+	if fen := game.FEN(); fen != expected {
+		t.Errorf("Expected %v, got %v", expected, fen)
+	}
+	if err := playMoves(game, []Move{{From: Square{"G", 8}, To: Square{"F", 6}}, {From: Square{"E", 1}, To: Square{"E", 2}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	expected = "rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPPKPPP/RNBQ1BNR b kq - 2 2"
+	if fen := game.FEN(); fen != expected {
+		t.Errorf("Expected %v, got %v", expected, fen)
+	}
+}
+
+func TestNewGameFromFEN_allows_en_passant_capture(t *testing.T) {
+	defer quiet()()
+	fen := "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 30"
+	game, err := NewGameFromFEN(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, fen)
+	if err != nil {
+		t.Errorf("Failed to create game from FEN, %v", err)
+		return
+	}
+	if game.FEN() != fen {
+		t.Errorf("Expected %v, got %v", fen, game.FEN())
+	}
+	if err := playMoves(game, []Move{{From: Square{"E", 5}, To: Square{"D", 6}}}); err != nil {
+		t.Errorf("Expected en passant capture to be legal, got %v", err)
+	}
+	if found, _ := game.Board.GetPieceAtSquare("D", 5); found {
+		t.Errorf("Expected the black pawn on D5 to be captured en passant")
+	}
+}
+
+func TestParseFEN_X_FEN_inner_rook(t *testing.T) {
+	defer quiet()()
+	// the rook on B1 is not the outermost rook on the queenside so X-FEN names its column
+	position, err := ParseFEN("4k3/8/8/8/8/8/8/RR2K3 w B - 0 1")
+	if err != nil {
+		t.Errorf("Failed to parse FEN, %v", err)
+		return
+	}
+	if fen := position.FEN(); fen != "4k3/8/8/8/8/8/8/RR2K3 w B - 0 1" {
+		t.Errorf("Expected castling rights to be written as B, got %v", fen)
+	}
+	if _, rook := position.Board.GetPieceAtSquare("A", 1); !rook.hasMoved {
+		t.Errorf("Expected the rook on A1 to have no castling rights")
+	}
+}
+
+func TestParseFEN_invalid(t *testing.T) {
+	defer quiet()()
+	invalid := []string{
+		"",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNX w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBN1 w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e3 0 1",
+	}
+	for _, fen := range invalid {
+		if _, err := ParseFEN(fen); err == nil {
+			t.Errorf("Expected an error parsing %q", fen)
+		}
+	}
+}
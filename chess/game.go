@@ -3,19 +3,33 @@ package chess
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 type Player interface {
 	PickMove(g *Game) (*Move, error)
 }
 
+// optional interface a Player can implement to respond to draw offers from the opponent
+type DrawOfferResponder interface {
+	RespondToDrawOffer(g *Game, offeredBy Colour) bool
+}
+
+// optional interface a Player can implement to be told why its move was rejected
+type IllegalMoveHandler interface {
+	HandleIllegalMove(g *Game, move Move, err error)
+}
+
+var ErrGameFinished = errors.New("game is finished")
+
 type BoardVisualizer interface {
 	VisualizeState(b *Board)
 }
-type Result struct {
-	Draw   bool
-	Winner Colour
-	Reason string
+
+// optional interface a BoardVisualizer can implement to be shown the game instead of only the board, e.g. to show
+// the opening
+type GameVisualizer interface {
+	VisualizeGame(g *Game)
 }
 
 type Game struct {
@@ -27,10 +41,19 @@ type Game struct {
 	NextToMove         Colour
 	result             Result
 	boardVisualizer    BoardVisualizer
-	fiftyRuleCounter   int
-	positions          map[string]int
+	halfmoveClock      int            // plies since the last capture or pawn move
+	positions          map[string]int // number of times each position (incl. side to move) has occurred
 	numberOfWhiteMoves int
 	numberOfBlackMoves int
+	drawOffered        bool
+	drawOfferedBy      Colour
+	fullmoveNumber     int // starts at 1 and is incremented after each black move
+	variant            Variant
+	start              *Board // the position before the first move, so the history can be replayed
+	startNextToMove    Colour
+	startFEN           string
+	clocks             [2]clock         // indexed by colour, see SetTimeControl
+	clock              func() time.Time // time.Now if nil, replaced in tests
 }
 
 // creates and returns a new game
@@ -41,18 +64,125 @@ func NewGame(white Player, black Player, stateVisualizer BoardVisualizer) *Game
 	game.boardVisualizer = stateVisualizer
 	game.finished = false
 	game.History = make([]Move, 0)
-	game.Board = newBoard()
+	game.Board = NewBoard()
 	game.NextToMove = White
-	game.fiftyRuleCounter = 0
-	game.positions = make(map[string]int)
+	game.halfmoveClock = 0
 	game.numberOfWhiteMoves = 0
 	game.numberOfBlackMoves = 0
+	game.fullmoveNumber = 1
+	game.variant = Standard{}
+	game.setStart()
+	return game
+}
+
+// creates and returns a new game of the given variant
+func NewVariantGame(white Player, black Player, stateVisualizer BoardVisualizer, variant Variant) *Game {
+	game := NewGame(white, black, stateVisualizer)
+	game.variant = variant
+	game.Board = variant.NewBoard()
+	game.Board.variant = variant
+	game.setStart()
 	return game
 }
 
+// returns the variant being played
+func (g *Game) Variant() Variant {
+	return g.variant
+}
+
+// records the current position as the start of the game, which Replay, StartFEN and WritePGN play the history
+// from. The starting position counts towards repetitions
+func (g *Game) setStart() {
+	g.start, g.startNextToMove, g.startFEN = g.Board.Clone(), g.NextToMove, g.FEN()
+	g.positions = map[string]int{g.positionKey(): 1}
+}
+
+// returns the board after each move of the history, starting with the position before the first move
+func (g *Game) Replay() ([]*Board, error) {
+	board, colour := g.start.Clone(), g.startNextToMove
+	boards := []*Board{board.Clone()}
+	for i, move := range g.History {
+		if _, err := board.makeMove(move, colour); err != nil {
+			return nil, fmt.Errorf("can not replay move %v: %w", i+1, err)
+		}
+		boards = append(boards, board.Clone())
+		colour = opponentOf(colour)
+	}
+	return boards, nil
+}
+
+// returns the FEN of the position before the first move
+func (g *Game) StartFEN() string {
+	return g.startFEN
+}
+
+// returns all legal moves for the side to move, taking the rules of the variant into account
+func (g *Game) LegalMoves() map[Move]*MoveResult {
+	return g.Board.LegalMovesFor(g.NextToMove)
+}
+
+// creates and returns a new game starting from a custom position, the board is validated first
+func NewGameFromPosition(white Player, black Player, stateVisualizer BoardVisualizer, board *Board, nextToMove Colour) (*Game, error) {
+	if err := board.Validate(nextToMove); err != nil {
+		return nil, err
+	}
+	game := NewGame(white, black, stateVisualizer)
+	game.Board = board
+	game.variant = board.getVariant() // e.g. crazyhouse when the position has pockets
+	game.NextToMove = nextToMove
+	game.setStart()
+	return game, nil
+}
+
+// creates and returns a new game starting from the position in the given FEN string
+func NewGameFromFEN(white Player, black Player, stateVisualizer BoardVisualizer, fen string) (*Game, error) {
+	position, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	game, err := NewGameFromPosition(white, black, stateVisualizer, position.Board, position.NextToMove)
+	if err != nil {
+		return nil, err
+	}
+	game.halfmoveClock = position.HalfmoveClock
+	game.fullmoveNumber = position.FullmoveNumber
+	game.setStart()
+	return game, nil
+}
+
+// creates and returns a new game of the given variant starting from the position in the given FEN string. Only
+// standard positions are validated, variants allow positions standard chess does not (e.g. no white king in horde)
+func NewVariantGameFromFEN(white Player, black Player, stateVisualizer BoardVisualizer, variant Variant, fen string) (*Game, error) {
+	if _, isStandard := variant.(Standard); isStandard {
+		return NewGameFromFEN(white, black, stateVisualizer, fen)
+	}
+	position, err := ParseFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	game := NewVariantGame(white, black, stateVisualizer, variant)
+	game.Board = position.Board
+	game.Board.variant = variant
+	game.NextToMove = position.NextToMove
+	game.halfmoveClock = position.HalfmoveClock
+	game.fullmoveNumber = position.FullmoveNumber
+	game.setStart()
+	return game, nil
+}
+
+// returns the current position of the game
+func (g *Game) Position() *Position {
+	return &Position{Board: g.Board, NextToMove: g.NextToMove, HalfmoveClock: g.halfmoveClock, FullmoveNumber: g.fullmoveNumber}
+}
+
+// returns the current position of the game as a FEN string
+func (g *Game) FEN() string {
+	return g.Position().FEN()
+}
+
 // starts the game
 func (g *Game) Start() Result {
-	g.boardVisualizer.VisualizeState(g.Board)
+	g.visualize()
 	g.nextMove()
 	diff := g.numberOfBlackMoves - g.numberOfWhiteMoves
 	if diff < 0 {
@@ -64,78 +194,215 @@ func (g *Game) Start() Result {
 	return g.result
 }
 
+func (g *Game) visualize() {
+	if visualizer, ok := g.boardVisualizer.(GameVisualizer); ok {
+		visualizer.VisualizeGame(g)
+		return
+	}
+	g.boardVisualizer.VisualizeState(g.Board)
+}
+
 func isGameOver(g *Game) bool {
-	if g.Board.kingIsInMate(Black) {
-		fmt.Println("mate!")
-		g.boardVisualizer.VisualizeState(g.Board)
-		g.result = Result{Draw: false, Winner: White, Reason: "Black is in mate"}
+	if g.finished {
+		g.visualize()
 		return true
 	}
-
-	if g.Board.kingIsInMate(White) {
-		fmt.Println("mate!")
-		g.boardVisualizer.VisualizeState(g.Board)
-		g.result = Result{Draw: false, Winner: Black, Reason: "White is in mate"}
+	if result, over := g.variant.GameOver(g); over {
+		fmt.Printf("%v!\n", result.Reason)
+		g.visualize()
+		g.finish(result)
 		return true
 	}
 
-	if g.Board.isStaleMate(White) || g.Board.isStaleMate(Black) {
-		fmt.Println("Stale mate!")
-		g.boardVisualizer.VisualizeState(g.Board)
-		g.result = Result{Draw: true, Winner: White, Reason: "Stale mate"}
+	// 75 move rule and fivefold repetition are mandatory draws, no claim needed
+	if g.halfmoveClock >= 150 {
+		fmt.Println("75 move rule!")
+		g.visualize()
+		g.finish(drawBy(FiftyMoveRule, "75 move rule"))
 		return true
 	}
 
-	if g.fiftyRuleCounter >= 50 {
-		fmt.Println("50 move rule!")
-		g.boardVisualizer.VisualizeState(g.Board)
-		g.result = Result{Draw: true, Winner: White, Reason: "50 move rule"}
+	if g.positions[g.positionKey()] >= 5 {
+		fmt.Println("5-fold repetition!")
+		g.visualize()
+		g.finish(drawBy(Repetition, "5-fold repetition"))
 		return true
 	}
 
-	// else if three fold repetition check
-	return threefoldRepetitionCheck(g)
+	return false
 }
+
+// returns a key identifying the current position. As in the FIDE rules the same piece placement is a different
+// position with a different side to move, different castling rights or when an en passant capture is possible
+func (g *Game) positionKey() string {
+	return g.Board.getPosition() + g.NextToMove.String() + " " + g.Board.castlingRightsFEN(false) + " " + g.Board.enPassantCaptureSquare(g.NextToMove)
+}
+
+// returns true if the current position has occurred at least three times
 func threefoldRepetitionCheck(g *Game) bool {
-	for k := range g.positions {
-		if g.positions[k] >= 3 {
-			fmt.Println("3-fold repetition!")
-			g.boardVisualizer.VisualizeState(g.Board)
-			g.result = Result{Draw: true, Winner: White, Reason: "3-fold repetition"}
-			return true
+	return g.positions[g.positionKey()] >= 3
+}
+
+// returns true if no capture has been made and no pawn has been moved in the last fifty moves by each player
+func fiftyMoveRuleCheck(g *Game) bool {
+	return g.halfmoveClock >= 100
+}
+
+// returns the number of plies since the last capture or pawn move
+func (g *Game) HalfmoveClock() int {
+	return g.halfmoveClock
+}
+
+// returns true if the game is finished (mate, stale mate, resignation, timeout or draw)
+func (g *Game) IsFinished() bool {
+	return g.finished
+}
+
+// returns the result of the game, the Outcome is NoOutcome while the game is in progress
+func (g *Game) Result() Result {
+	return g.result
+}
+
+// resigns the game on behalf of colour, the opponent wins
+func (g *Game) Resign(colour Colour) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	winner := White
+	if colour == White {
+		winner = Black
+	}
+	g.finish(winFor(winner, Resignation, fmt.Sprintf("%v resigned", colour)))
+	return nil
+}
+
+// offers a draw on behalf of colour. If the opponent implements DrawOfferResponder it is asked right away,
+// otherwise the offer stays open until the opponent accepts, declines or makes a move
+func (g *Game) OfferDraw(colour Colour) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	if g.drawOffered {
+		return fmt.Errorf("%v has already offered a draw", g.drawOfferedBy)
+	}
+	g.drawOffered = true
+	g.drawOfferedBy = colour
+
+	opponentColour, opponent := Black, g.black
+	if colour == Black {
+		opponentColour, opponent = White, g.white
+	}
+	if responder, ok := opponent.(DrawOfferResponder); ok {
+		if responder.RespondToDrawOffer(g, colour) {
+			return g.AcceptDraw(opponentColour)
 		}
+		return g.DeclineDraw(opponentColour)
 	}
-	return false
+	return nil
+}
+
+// returns true and the offering colour if there is an open draw offer
+func (g *Game) DrawOffered() (bool, Colour) {
+	return g.drawOffered, g.drawOfferedBy
+}
+
+// accepts the opponents open draw offer on behalf of colour
+func (g *Game) AcceptDraw(colour Colour) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	if !g.drawOffered || g.drawOfferedBy == colour {
+		return fmt.Errorf("there is no draw offer for %v to accept", colour)
+	}
+	g.drawOffered = false
+	g.finish(drawBy(Agreement, "Draw agreed"))
+	return nil
+}
+
+// declines the opponents open draw offer on behalf of colour
+func (g *Game) DeclineDraw(colour Colour) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	if !g.drawOffered || g.drawOfferedBy == colour {
+		return fmt.Errorf("there is no draw offer for %v to decline", colour)
+	}
+	g.drawOffered = false
+	return nil
+}
+
+// returns true if the side to move can claim a draw in the current position (threefold repetition or fifty move rule)
+func (g *Game) CanClaimDraw() bool {
+	return fiftyMoveRuleCheck(g) || threefoldRepetitionCheck(g)
+}
+
+// claims a draw on behalf of colour, fails if neither threefold repetition nor the fifty move rule applies. As in
+// the FIDE rules only the side to move can claim, e.g. from PickMove instead of moving
+func (g *Game) ClaimDraw(colour Colour) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	if colour != g.NextToMove {
+		return fmt.Errorf("%v can not claim a draw, it is %vs move: %w", colour, g.NextToMove, ErrNotYourTurn)
+	}
+	if fiftyMoveRuleCheck(g) {
+		g.finish(drawBy(FiftyMoveRule, "50 move rule"))
+		return nil
+	}
+	if threefoldRepetitionCheck(g) {
+		g.finish(drawBy(Repetition, "3-fold repetition"))
+		return nil
+	}
+	return fmt.Errorf("%v can not claim a draw, neither threefold repetition nor fifty move rule applies", colour)
+}
+
+func (g *Game) finish(result Result) {
+	g.result = result
+	g.finished = true
 }
 func (g *Game) nextMove() {
 
 	for {
 
 		if g.NextToMove == White { // white to move
+			started := g.now()
 			move, pickErr := g.white.PickMove(g)
+			if g.finished { // resigned, agreed to or claimed a draw instead of moving
+				break
+			}
+			if g.flagFell(White, started) { // the move came too late
+				break
+			}
 			if pickErr != nil {
 				fmt.Printf("Error picking move: %v", pickErr)
 			} else {
 				_, err := g.move(*move, White)
 				if err != nil {
-					fmt.Printf("Error making move: %v\n", err)
+					reportIllegalMove(g, g.white, *move, err)
 				} else {
-					g.boardVisualizer.VisualizeState(g.Board)
-					fmt.Printf("White moved from %v%v to %v%v", move.From.Column, move.From.Row, move.To.Column, move.To.Row)
+					g.visualize()
+					printMove(White, *move)
 					g.NextToMove = Black
 				}
 			}
 		} else { // black to move
+			started := g.now()
 			move, pickErr := g.black.PickMove(g)
+			if g.finished { // resigned, agreed to or claimed a draw instead of moving
+				break
+			}
+			if g.flagFell(Black, started) { // the move came too late
+				break
+			}
 			if pickErr != nil {
 				fmt.Printf("Error picking move: %v", pickErr)
 			} else {
 				_, err := g.move(*move, Black)
 				if err != nil {
-					fmt.Printf("Error making move: %v\n", err)
+					reportIllegalMove(g, g.black, *move, err)
 				} else {
-					g.boardVisualizer.VisualizeState(g.Board)
-					fmt.Printf("Black moved from %v%v to %v%v", move.From.Column, move.From.Row, move.To.Column, move.To.Row)
+					g.visualize()
+					printMove(Black, *move)
 					g.NextToMove = White
 				}
 			}
@@ -147,46 +414,81 @@ func (g *Game) nextMove() {
 		// else time for the next move (next iteration in game loop)
 	}
 }
+func printMove(colour Colour, move Move) {
+	if move.Kind == Drop {
+		fmt.Printf("%v dropped %v on %v%v", colour, move.Piece, move.To.Column, move.To.Row)
+		return
+	}
+	fmt.Printf("%v moved from %v%v to %v%v", colour, move.From.Column, move.From.Row, move.To.Column, move.To.Row)
+}
+func reportIllegalMove(g *Game, player Player, move Move, err error) {
+	if handler, ok := player.(IllegalMoveHandler); ok {
+		handler.HandleIllegalMove(g, move, err)
+		return
+	}
+	fmt.Printf("Error making move: %v\n", err)
+}
 func (g *Game) move(move Move, as Colour) (string, error) {
-	_, p := g.Board.GetPieceAtSquare(move.From.Column, move.From.Row)
-	if p.Colour != as {
-		return "", errors.New("hey! not your piece")
+	if as != g.NextToMove {
+		return "", illegalMove(NotYourTurn, "it is %vs turn", g.NextToMove)
 	}
-	result, moveErr := p.Move(move.To.Column, move.To.Row, g.Board, false)
-	if moveErr != nil {
-		return "", moveErr
+	var p *Piece
+	var movedType PieceType
+	var result *MoveResult
+	if move.Kind == Drop {
+		dropResult, dropErr := g.Board.Drop(as, move.Piece, move.To.Column, move.To.Row, false)
+		if dropErr != nil {
+			return "", dropErr
+		}
+		_, p = g.Board.GetPieceAtSquare(move.To.Column, move.To.Row)
+		movedType, result = move.Piece, dropResult
+	} else {
+		found, piece := g.Board.GetPieceAtSquare(move.From.Column, move.From.Row)
+		if !found {
+			return "", illegalMove(NoPiece, "no piece at %v%v", move.From.Column, move.From.Row)
+		}
+		if piece.Colour != as {
+			return "", illegalMove(NotYourPiece, "hey! not your piece")
+		}
+		if err := g.variant.AllowMove(g.Board, piece, move); err != nil {
+			return "", err
+		}
+		p, movedType = piece, piece.Type // a pawn might be promoted by the move
+		moveResult, moveErr := p.Move(move.To.Column, move.To.Row, g.Board, false)
+		if moveErr != nil {
+			return "", moveErr
+		}
+		result = moveResult
 	}
+	g.variant.AfterMove(g.Board, p, move, result)
 	if as == White {
 		g.numberOfWhiteMoves++
 	} else {
 		g.numberOfBlackMoves++
 	}
-	// if no capture has been made and no pawn has been moved in the last fifty moves
-	if result.Action == GoTo && p.Type != pawn {
-		g.fiftyRuleCounter = g.fiftyRuleCounter + 1
-	} else {
-		g.fiftyRuleCounter = 0
-	}
-	if g.fiftyRuleCounter >= 50 {
-		return "", fmt.Errorf("FiftyRuleCounter is %v", g.fiftyRuleCounter)
-	}
-	// keep track of positions, if threefold repetition, draw (does NOT need to be 3 times in a row)
-	currentPosition := g.Board.getPosition()
-	if _, ok := g.positions[currentPosition]; ok {
-		g.positions[currentPosition] = g.positions[currentPosition] + 1
+	// the halfmove clock is reset by captures and pawn moves
+	if result.Action == GoTo && movedType != Pawn {
+		g.halfmoveClock++
 	} else {
-		g.positions[currentPosition] = 1
+		g.halfmoveClock = 0
 	}
-	if threefoldRepetitionCheck(g) {
-		return "", fmt.Errorf("ThreefoldRepetitionCheck")
+	// making a move declines an open draw offer from the opponent
+	if g.drawOffered && g.drawOfferedBy != as {
+		g.drawOffered = false
 	}
 	successMsg := fmt.Sprintf("%v %v moved from %v %v to %v %v", p.Colour, p.Type, move.From.Column,
 		move.From.Row, move.To.Column, move.To.Row)
-	g.History = append(g.History, Move{From: move.From, To: move.To})
+	if move.Kind == Drop {
+		successMsg = fmt.Sprintf("%v %v dropped on %v %v", as, move.Piece, move.To.Column, move.To.Row)
+	}
+	g.History = append(g.History, move)
 	if g.NextToMove == White {
 		g.NextToMove = Black
 	} else {
 		g.NextToMove = White
+		g.fullmoveNumber++
 	}
+	// keep track of positions for repetitions (does NOT need to be in a row)
+	g.positions[g.positionKey()]++
 	return fmt.Sprintf("%s it is now %vs turn", successMsg, g.NextToMove), nil
 }
@@ -0,0 +1,310 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+type noopVisualizer struct{}
+
+func (v *noopVisualizer) VisualizeState(b *Board) {}
+
+// plays the given moves in order, then resigns
+type scriptedPlayer struct {
+	colour       Colour
+	moves        []Move
+	acceptsDraws bool
+}
+
+func (p *scriptedPlayer) PickMove(g *Game) (*Move, error) {
+	if len(p.moves) == 0 {
+		return nil, g.Resign(p.colour)
+	}
+	move := p.moves[0]
+	p.moves = p.moves[1:]
+	return &move, nil
+}
+
+type drawRespondingPlayer struct {
+	scriptedPlayer
+}
+
+func (p *drawRespondingPlayer) RespondToDrawOffer(g *Game, offeredBy Colour) bool {
+	return p.acceptsDraws
+}
+
+func TestResign_opponent_wins(t *testing.T) {
+	defer quiet()()
+	white := &scriptedPlayer{colour: White, moves: []Move{{From: Square{"E", 2}, To: Square{"E", 4}}}}
+	black := &scriptedPlayer{colour: Black}
+	game := NewGame(white, black, &noopVisualizer{})
+	result := game.Start()
+	if winner, ok := result.Winner(); !ok || winner != White || result.Termination != Resignation {
+		t.Errorf("Expected White to win when Black resigns, got %+v", result)
+	}
+	if !game.IsFinished() {
+		t.Errorf("Expected game to be finished after resignation")
+	}
+	if err := game.Resign(White); err != ErrGameFinished {
+		t.Errorf("Expected ErrGameFinished when resigning a finished game, got %v", err)
+	}
+}
+
+func TestOfferDraw_accepted_by_responder(t *testing.T) {
+	defer quiet()()
+	white := &scriptedPlayer{colour: White}
+	black := &drawRespondingPlayer{scriptedPlayer{colour: Black, acceptsDraws: true}}
+	game := NewGame(white, black, &noopVisualizer{})
+	if err := game.OfferDraw(White); err != nil {
+		t.Errorf("Expected draw offer to succeed, got %v", err)
+	}
+	if !game.IsFinished() || game.result.Termination != Agreement {
+		t.Errorf("Expected game to be drawn by agreement, got %+v", game.result)
+	}
+}
+
+func TestOfferDraw_declined_by_responder(t *testing.T) {
+	defer quiet()()
+	white := &scriptedPlayer{colour: White}
+	black := &drawRespondingPlayer{scriptedPlayer{colour: Black, acceptsDraws: false}}
+	game := NewGame(white, black, &noopVisualizer{})
+	if err := game.OfferDraw(White); err != nil {
+		t.Errorf("Expected draw offer to succeed, got %v", err)
+	}
+	if game.IsFinished() {
+		t.Errorf("Expected game to continue after declined draw offer")
+	}
+	if offered, _ := game.DrawOffered(); offered {
+		t.Errorf("Expected no open draw offer after it was declined")
+	}
+}
+
+func TestOfferDraw_stays_open_until_opponent_moves(t *testing.T) {
+	defer quiet()()
+	white := &scriptedPlayer{colour: White}
+	black := &scriptedPlayer{colour: Black}
+	game := NewGame(white, black, &noopVisualizer{})
+	if err := game.OfferDraw(White); err != nil {
+		t.Errorf("Expected draw offer to succeed, got %v", err)
+	}
+	if err := game.AcceptDraw(White); err == nil {
+		t.Errorf("Expected White not to be able to accept its own draw offer")
+	}
+	if _, err := game.move(Move{From: Square{"E", 2}, To: Square{"E", 4}}, White); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if offered, by := game.DrawOffered(); !offered || by != White {
+		t.Errorf("Expected White's draw offer to still be open")
+	}
+	if _, err := game.move(Move{From: Square{"E", 7}, To: Square{"E", 5}}, Black); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if offered, _ := game.DrawOffered(); offered {
+		t.Errorf("Expected Black's move to decline the draw offer")
+	}
+	if err := game.AcceptDraw(Black); err == nil {
+		t.Errorf("Expected accepting a declined draw offer to fail")
+	}
+}
+
+func TestClaimDraw_on_threefold_repetition(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if err := game.ClaimDraw(White); err == nil {
+		t.Errorf("Expected claiming a draw in the starting position to fail")
+	}
+	shuffle := []Move{
+		{From: Square{"G", 1}, To: Square{"F", 3}},
+		{From: Square{"G", 8}, To: Square{"F", 6}},
+		{From: Square{"F", 3}, To: Square{"G", 1}},
+		{From: Square{"F", 6}, To: Square{"G", 8}},
+	}
+	for i := 0; i < 3; i++ {
+		for j, m := range shuffle {
+			colour := White
+			if j%2 == 1 {
+				colour = Black
+			}
+			if _, err := game.move(m, colour); err != nil {
+				t.Errorf("Failed to move, %v", err)
+				return
+			}
+		}
+	}
+	if game.IsFinished() {
+		t.Errorf("Expected threefold repetition to be claimable, not automatic")
+	}
+	if !game.CanClaimDraw() {
+		t.Errorf("Expected a draw to be claimable after threefold repetition")
+	}
+	if err := game.ClaimDraw(White); err != nil {
+		t.Errorf("Expected draw claim to succeed, got %v", err)
+	}
+	if !game.IsFinished() || !game.result.Draw() || game.result.Termination != Repetition {
+		t.Errorf("Expected game to be drawn, got %+v", game.result)
+	}
+}
+
+func playMoves(game *Game, moves []Move) error {
+	for _, m := range moves {
+		if _, err := game.move(m, game.NextToMove); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRepetition_fivefold_is_an_automatic_draw(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	shuffle := []Move{
+		{From: Square{"G", 1}, To: Square{"F", 3}},
+		{From: Square{"G", 8}, To: Square{"F", 6}},
+		{From: Square{"F", 3}, To: Square{"G", 1}},
+		{From: Square{"F", 6}, To: Square{"G", 8}},
+	}
+	// the starting position counts as the first occurrence
+	for i := 0; i < 3; i++ {
+		if err := playMoves(game, shuffle); err != nil {
+			t.Errorf("Failed to move, %v", err)
+			return
+		}
+	}
+	if isGameOver(game) {
+		t.Errorf("Expected fourfold repetition not to end the game, got %+v", game.result)
+	}
+	if err := playMoves(game, shuffle); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	if !isGameOver(game) || game.result.Reason != "5-fold repetition" {
+		t.Errorf("Expected fivefold repetition to end the game, got %+v", game.result)
+	}
+}
+
+func TestFiftyMoveRule_is_counted_in_plies_and_claimable(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	game.halfmoveClock = 99
+	if game.CanClaimDraw() {
+		t.Errorf("Expected no draw claim after 99 plies")
+	}
+	game.halfmoveClock = 100
+	if !game.CanClaimDraw() {
+		t.Errorf("Expected draw claim after 100 plies")
+	}
+	if isGameOver(game) {
+		t.Errorf("Expected fifty move rule not to end the game automatically")
+	}
+	if err := game.ClaimDraw(White); err != nil || game.result.Reason != "50 move rule" {
+		t.Errorf("Expected fifty move rule claim to succeed, got %v (%+v)", err, game.result)
+	}
+}
+
+func TestClaimDraw_only_by_the_side_to_move(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	game.halfmoveClock = 100
+	if err := game.ClaimDraw(Black); !errors.Is(err, ErrNotYourTurn) || game.IsFinished() {
+		t.Errorf("Expected Black not to be able to claim a draw on Whites move, got %v", err)
+	}
+	if err := playMoves(game, []Move{{From: Square{"G", 1}, To: Square{"F", 3}}}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	game.halfmoveClock = 100
+	if err := game.ClaimDraw(Black); err != nil || !game.IsFinished() {
+		t.Errorf("Expected Black to claim the draw on its own move, got %v", err)
+	}
+}
+
+func TestSeventyFiveMoveRule_is_an_automatic_draw(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	game.halfmoveClock = 149
+	if isGameOver(game) {
+		t.Errorf("Expected game to continue after 149 plies")
+	}
+	if _, err := game.move(Move{From: Square{"G", 1}, To: Square{"F", 3}}, White); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if !isGameOver(game) || game.result.Reason != "75 move rule" {
+		t.Errorf("Expected 75 move rule to end the game, got %+v", game.result)
+	}
+}
+
+func TestHalfmoveClock_is_reset_by_pawn_moves(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if err := playMoves(game, []Move{{From: Square{"G", 1}, To: Square{"F", 3}}, {From: Square{"G", 8}, To: Square{"F", 6}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if game.HalfmoveClock() != 2 {
+		t.Errorf("Expected halfmove clock to be 2, got %v", game.HalfmoveClock())
+	}
+	if err := playMoves(game, []Move{{From: Square{"E", 2}, To: Square{"E", 4}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if game.HalfmoveClock() != 0 {
+		t.Errorf("Expected halfmove clock to be reset by a pawn move, got %v", game.HalfmoveClock())
+	}
+}
+
+func TestRepetition_needs_the_same_castling_rights(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	opening := []Move{
+		{From: Square{"E", 2}, To: Square{"E", 4}},
+		{From: Square{"E", 7}, To: Square{"E", 5}},
+	}
+	kingWalk := []Move{
+		{From: Square{"E", 1}, To: Square{"E", 2}},
+		{From: Square{"E", 8}, To: Square{"E", 7}},
+		{From: Square{"E", 2}, To: Square{"E", 1}},
+		{From: Square{"E", 7}, To: Square{"E", 8}},
+	}
+	if err := playMoves(game, opening); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	// the placement after 1. e4 e5 comes back twice, but without the castling rights
+	for i := 0; i < 2; i++ {
+		if err := playMoves(game, kingWalk); err != nil {
+			t.Errorf("Failed to move, %v", err)
+			return
+		}
+	}
+	if game.CanClaimDraw() {
+		t.Errorf("Expected no threefold repetition when the castling rights differ")
+	}
+	if err := playMoves(game, kingWalk); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	if !game.CanClaimDraw() {
+		t.Errorf("Expected threefold repetition once the position without castling rights occurred three times")
+	}
+}
+
+func TestEnPassantCaptureSquare_only_when_the_capture_is_legal(t *testing.T) {
+	tests := []struct {
+		fen      string
+		expected string
+	}{
+		{"4k3/8/8/8/3Pp3/8/8/4K3 b - d3 0 1", "d3"},
+		{"4k3/8/8/8/3P4/8/8/4K3 b - d3 0 1", "-"},    // no pawn to capture with
+		{"8/8/8/8/k2Pp2Q/8/8/3K4 b - d3 0 1", "-"},   // both pawns leave the fourth row and the queen checks
+		{"8/8/8/1b6/3Pp3/8/8/k5K1 b - d3 0 1", "d3"}, // the capture blocks nothing
+		{"8/8/8/8/3Pp3/8/8/k3K2B b - d3 0 1", "d3"},  // the bishop does not pin the pawn
+		{"4k3/8/8/8/3Pp3/8/8/4R1K1 b - d3 0 1", "-"}, // the pawn is pinned on the file
+		{"4k3/8/8/8/2pP4/8/8/4K3 b - d3 0 1", "d3"},  // from the other side
+	}
+	for _, test := range tests {
+		position, err := ParseFEN(test.fen)
+		if err != nil {
+			t.Fatalf("Failed to parse %v, %v", test.fen, err)
+		}
+		if square := position.Board.enPassantCaptureSquare(position.NextToMove); square != test.expected {
+			t.Errorf("Expected %v in %v, got %v", test.expected, test.fen, square)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package chess
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"strings"
+)
+
+// indexes in gifPalette
+const (
+	gifLightSquare uint8 = iota
+	gifDarkSquare
+	gifLightLastMove
+	gifDarkLastMove
+	gifCheck
+	gifWhite
+	gifBlack
+	gifGrey
+)
+
+var gifPalette = color.Palette{
+	color.RGBA{0xf0, 0xd9, 0xb5, 0xff},
+	color.RGBA{0xb5, 0x88, 0x63, 0xff},
+	color.RGBA{0xce, 0xd2, 0x6b, 0xff},
+	color.RGBA{0xaa, 0xa2, 0x3a, 0xff},
+	color.RGBA{0xe0, 0x40, 0x40, 0xff},
+	color.RGBA{0xff, 0xff, 0xff, 0xff},
+	color.RGBA{0x00, 0x00, 0x00, 0xff},
+	color.RGBA{0x80, 0x80, 0x80, 0xff},
+}
+
+// the built-in piece sprites, x is the body of the piece. The outline is added around the body when drawing
+var gifSprites = map[PieceType][]string{
+	Pawn: {
+		"............",
+		"............",
+		".....xx.....",
+		"....xxxx....",
+		"....xxxx....",
+		".....xx.....",
+		"....xxxx....",
+		"...xxxxxx...",
+		"...xxxxxx...",
+		"..xxxxxxxx..",
+		"..xxxxxxxx..",
+		"............",
+	},
+	Knight: {
+		"............",
+		".....xx.....",
+		"....xxxxx...",
+		"...xxxxxxx..",
+		"..xxx.xxxx..",
+		"..xx.xxxxx..",
+		".....xxxxx..",
+		"....xxxxx...",
+		"...xxxxxx...",
+		"..xxxxxxxx..",
+		"..xxxxxxxx..",
+		"............",
+	},
+	Bishop: {
+		"............",
+		".....xx.....",
+		"....xxxx....",
+		"...xxx.xx...",
+		"...xx.xxx...",
+		"...xxxxxx...",
+		"....xxxx....",
+		".....xx.....",
+		"...xxxxxx...",
+		"..xxxxxxxx..",
+		"..xxxxxxxx..",
+		"............",
+	},
+	Rook: {
+		"............",
+		"..x..xx..x..",
+		"..xxxxxxxx..",
+		"...xxxxxx...",
+		"...xxxxxx...",
+		"...xxxxxx...",
+		"...xxxxxx...",
+		"...xxxxxx...",
+		"..xxxxxxxx..",
+		"..xxxxxxxx..",
+		".xxxxxxxxxx.",
+		"............",
+	},
+	Queen: {
+		"............",
+		".x...xx...x.",
+		".x..xxxx..x.",
+		".xx.xxxx.xx.",
+		"..xxxxxxxx..",
+		"..xxxxxxxx..",
+		"...xxxxxx...",
+		"...xxxxxx...",
+		"..xxxxxxxx..",
+		"..xxxxxxxx..",
+		".xxxxxxxxxx.",
+		"............",
+	},
+	King: {
+		".....xx.....",
+		"....xxxx....",
+		".....xx.....",
+		"..xx.xx.xx..",
+		".xxxxxxxxxx.",
+		".xxxxxxxxxx.",
+		"..xxxxxxxx..",
+		"...xxxxxx...",
+		"...xxxxxx...",
+		"..xxxxxxxx..",
+		"..xxxxxxxx..",
+		"............",
+	},
+}
+
+const gifSpriteSize = 12
+
+// a 3x5 font with the characters used in results
+var gifFont = map[rune][]string{
+	'0': {"xxx", "x.x", "x.x", "x.x", "xxx"},
+	'1': {".x.", "xx.", ".x.", ".x.", "xxx"},
+	'2': {"xxx", "..x", "xxx", "x..", "xxx"},
+	'-': {"...", "...", "xxx", "...", "..."},
+	'/': {"..x", "..x", ".x.", "x..", "x.."},
+	'*': {"...", "x.x", ".x.", "x.x", "..."},
+}
+
+type GIFOptions struct {
+	SquareSize int  // in pixels, 36 if zero (the sprites are scaled by whole numbers)
+	Delay      int  // between frames in 100ths of a second, 100 if zero
+	Flipped    bool // black at the bottom
+}
+
+// writes the game as an animated GIF with one frame per ply, starting with the position before the first move and
+// ending with a frame showing the result
+func WriteGIF(w io.Writer, g *Game, options GIFOptions) error {
+	if options.SquareSize <= 0 {
+		options.SquareSize = 3 * gifSpriteSize
+	}
+	if options.Delay <= 0 {
+		options.Delay = 100
+	}
+	boards, err := g.Replay()
+	if err != nil {
+		return err
+	}
+	animation := &gif.GIF{}
+	for _, board := range boards {
+		animation.Image = append(animation.Image, drawGIFFrame(board, options))
+		animation.Delay = append(animation.Delay, options.Delay)
+	}
+	final := drawGIFFrame(boards[len(boards)-1], options)
+	drawGIFResult(final, g.Result().PGN(), options.SquareSize)
+	animation.Image = append(animation.Image, final)
+	animation.Delay = append(animation.Delay, 3*options.Delay)
+	return gif.EncodeAll(w, animation)
+}
+
+func drawGIFFrame(b *Board, options GIFOptions) *image.Paletted {
+	size := options.SquareSize
+	frame := image.NewPaletted(image.Rect(0, 0, 8*size, 8*size), gifPalette)
+	previousMove, hasPreviousMove := b.PreviousMove()
+	for _, square := range b.Squares {
+		index := gifLightSquare
+		if (b.getColumnIndex(square.Column)+square.Row)%2 == 1 {
+			index = gifDarkSquare
+		}
+		if hasPreviousMove && (square == previousMove.To || (previousMove.Kind == PieceMove && square == previousMove.From)) {
+			index += gifLightLastMove
+		}
+		draw.Draw(frame, gifSquareRect(b, square, options), &image.Uniform{gifPalette[index]}, image.Point{}, draw.Src)
+	}
+	for _, colour := range []Colour{White, Black} {
+		king := b.getKing(colour)
+		if king.Type != King || !king.InPlay {
+			continue
+		}
+		if isCheck, _ := b.kingIsInCheck(colour); isCheck {
+			draw.Draw(frame, gifSquareRect(b, king.CurrentSquare, options), &image.Uniform{gifPalette[gifCheck]}, image.Point{}, draw.Src)
+		}
+	}
+	for _, pieces := range [][]Piece{b.WhitePieces, b.BlackPieces} {
+		for _, piece := range pieces {
+			if piece.InPlay {
+				drawGIFSprite(frame, gifSquareRect(b, piece.CurrentSquare, options), piece)
+			}
+		}
+	}
+	return frame
+}
+
+// returns the pixels of the square in the frame
+func gifSquareRect(b *Board, square Square, options GIFOptions) image.Rectangle {
+	size := options.SquareSize
+	column, row := b.getColumnIndex(strings.ToUpper(square.Column)), 8-square.Row
+	if options.Flipped {
+		column, row = 7-column, square.Row-1
+	}
+	return image.Rect(column*size, row*size, (column+1)*size, (row+1)*size)
+}
+
+// draws the sprite of the piece centred in the square, with an outline so white pieces show on light squares
+func drawGIFSprite(frame *image.Paletted, square image.Rectangle, piece Piece) {
+	sprite := gifSprites[piece.Type]
+	scale := square.Dx() / gifSpriteSize
+	if scale < 1 {
+		scale = 1
+	}
+	offset := square.Min.Add(image.Pt((square.Dx()-scale*gifSpriteSize)/2, (square.Dy()-scale*gifSpriteSize)/2))
+	body, outline := gifWhite, gifBlack
+	if piece.Colour == Black {
+		body, outline = gifBlack, gifGrey
+	}
+	isBody := func(x, y int) bool {
+		return x >= 0 && y >= 0 && x < gifSpriteSize && y < gifSpriteSize && sprite[y][x] == 'x'
+	}
+	for y := 0; y < gifSpriteSize; y++ {
+		for x := 0; x < gifSpriteSize; x++ {
+			index, paint := body, isBody(x, y)
+			if !paint && (isBody(x-1, y) || isBody(x+1, y) || isBody(x, y-1) || isBody(x, y+1)) {
+				index, paint = outline, true
+			}
+			if !paint {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					frame.SetColorIndex(offset.X+x*scale+dx, offset.Y+y*scale+dy, index)
+				}
+			}
+		}
+	}
+}
+
+// draws the result (e.g. 1-0) on a banner across the middle of the board
+func drawGIFResult(frame *image.Paletted, result string, squareSize int) {
+	scale := squareSize / 6
+	if scale < 1 {
+		scale = 1
+	}
+	width := (4*len(result) - 1) * scale
+	bounds := frame.Bounds()
+	banner := image.Rect(0, bounds.Dy()/2-squareSize/2, bounds.Dx(), bounds.Dy()/2+squareSize/2)
+	draw.Draw(frame, banner, &image.Uniform{gifPalette[gifBlack]}, image.Point{}, draw.Src)
+	origin := image.Pt((bounds.Dx()-width)/2, bounds.Dy()/2-5*scale/2)
+	for i, r := range result {
+		glyph, ok := gifFont[r]
+		if !ok {
+			continue
+		}
+		for y, line := range glyph {
+			for x, pixel := range line {
+				if pixel != 'x' {
+					continue
+				}
+				dot := image.Rect(0, 0, scale, scale).Add(origin.Add(image.Pt((4*i+x)*scale, y*scale)))
+				draw.Draw(frame, dot, &image.Uniform{gifPalette[gifWhite]}, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
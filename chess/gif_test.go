@@ -0,0 +1,77 @@
+package chess
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+)
+
+func TestGIFSprites_are_square(t *testing.T) {
+	for pieceType, sprite := range gifSprites {
+		if len(sprite) != gifSpriteSize {
+			t.Errorf("Expected the %v sprite to have %v rows, got %v", pieceType, gifSpriteSize, len(sprite))
+		}
+		for i, row := range sprite {
+			if len(row) != gifSpriteSize {
+				t.Errorf("Expected row %v of the %v sprite to have %v columns, got %v", i, pieceType, gifSpriteSize, len(row))
+			}
+		}
+	}
+}
+
+func TestWriteGIF_one_frame_per_ply_and_a_result_frame(t *testing.T) {
+	defer quiet()()
+	white := &scriptedPlayer{colour: White, moves: []Move{
+		{From: Square{"F", 2}, To: Square{"F", 3}},
+		{From: Square{"G", 2}, To: Square{"G", 4}},
+	}}
+	black := &scriptedPlayer{colour: Black, moves: []Move{
+		{From: Square{"E", 7}, To: Square{"E", 5}},
+		{From: Square{"D", 8}, To: Square{"H", 4}},
+	}}
+	game := NewGame(white, black, &noopVisualizer{})
+	game.Start()
+	var out bytes.Buffer
+	if err := WriteGIF(&out, game, GIFOptions{SquareSize: 24, Delay: 50, Flipped: true}); err != nil {
+		t.Fatalf("Failed to write GIF, %v", err)
+	}
+	animation, err := gif.DecodeAll(&out)
+	if err != nil {
+		t.Fatalf("Failed to decode GIF, %v", err)
+	}
+	// the starting position, 4 plies and the result
+	if len(animation.Image) != 6 {
+		t.Errorf("Expected 6 frames, got %v", len(animation.Image))
+	}
+	if animation.Delay[0] != 50 {
+		t.Errorf("Expected a delay of 50, got %v", animation.Delay[0])
+	}
+	if bounds := animation.Image[0].Bounds(); bounds.Dx() != 8*24 || bounds.Dy() != 8*24 {
+		t.Errorf("Expected 192x192 frames, got %v", bounds)
+	}
+	// the white king on E1 is in check after the last move, with black at the bottom E1 is at the top
+	if index := animation.Image[4].ColorIndexAt(3*24+1, 1); index != gifCheck {
+		t.Errorf("Expected the king in check to be highlighted, got colour %v", index)
+	}
+}
+
+func TestReplay_starts_from_the_position_before_the_first_move(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Crazyhouse{}, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[n] b KQkq - 0 1")
+	if err := playMoves(game, []Move{NewDrop(Knight, Square{"E", 4})}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	boards, err := game.Replay()
+	if err != nil {
+		t.Fatalf("Failed to replay, %v", err)
+	}
+	if len(boards) != 2 {
+		t.Fatalf("Expected 2 boards, got %v", len(boards))
+	}
+	if found, _ := boards[0].GetPieceAtSquare("E", 4); found {
+		t.Errorf("Expected E4 to be empty before the drop")
+	}
+	if found, piece := boards[1].GetPieceAtSquare("E", 4); !found || piece.Type != Knight || piece.Colour != Black {
+		t.Errorf("Expected a black knight on E4 after the drop")
+	}
+}
@@ -0,0 +1,79 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// odds given by the stronger player, the pieces on the given squares are removed from the side giving the odds.
+// Squares are given from whites point of view and mirrored when black gives the odds
+type Handicap struct {
+	Name    string
+	Squares []Square
+	// the player receiving the odds makes the first move, even when playing black (e.g. pawn and move)
+	ReceiverMovesFirst bool
+	// time odds, the time each side has for the whole game. The game is played without a clock if both are 0 and a
+	// side with 0 has unlimited time
+	GiverTime    time.Duration
+	ReceiverTime time.Duration
+}
+
+// returns the common handicaps, a castling right is lost together with its rook
+func Handicaps() []Handicap {
+	return []Handicap{
+		{Name: "Pawn and move", Squares: []Square{{"F", 2}}, ReceiverMovesFirst: true},
+		{Name: "Pawn", Squares: []Square{{"F", 2}}},
+		{Name: "Knight", Squares: []Square{{"B", 1}}},
+		{Name: "Rook", Squares: []Square{{"A", 1}}},
+		{Name: "Queen", Squares: []Square{{"D", 1}}},
+		{Name: "Two knights", Squares: []Square{{"B", 1}, {"G", 1}}},
+		{Name: "Queen and rook", Squares: []Square{{"D", 1}, {"A", 1}}},
+		{Name: "Time (3 against 10 minutes)", GiverTime: 3 * time.Minute, ReceiverTime: 10 * time.Minute},
+		{Name: "Time (1 minute against unlimited)", GiverTime: time.Minute},
+	}
+}
+
+// returns the standard starting position with the handicap pieces of giver removed and the colour to move first
+func NewHandicapBoard(handicap Handicap, giver Colour) (*Board, Colour, error) {
+	board := NewBoard()
+	for _, square := range handicap.Squares {
+		row := square.Row
+		if giver == Black {
+			row = 9 - row
+		}
+		found, piece := board.GetPieceAtSquare(square.Column, row)
+		if !found || piece.Type == King {
+			return nil, White, fmt.Errorf("invalid handicap %q, there is no piece to remove on %v%v", handicap.Name, strings.ToUpper(square.Column), row)
+		}
+		if err := board.RemovePiece(square.Column, row); err != nil {
+			return nil, White, err
+		}
+	}
+	nextToMove := White
+	if handicap.ReceiverMovesFirst {
+		nextToMove = opponentOf(giver)
+	}
+	return board, nextToMove, nil
+}
+
+// creates and returns a new game where giver plays without the pieces and with the time of the handicap
+func NewHandicapGame(white Player, black Player, stateVisualizer BoardVisualizer, handicap Handicap, giver Colour) (*Game, error) {
+	if handicap.GiverTime < 0 || handicap.ReceiverTime < 0 {
+		return nil, fmt.Errorf("invalid handicap %q, the time can not be negative", handicap.Name)
+	}
+	board, nextToMove, err := NewHandicapBoard(handicap, giver)
+	if err != nil {
+		return nil, err
+	}
+	game, err := NewGameFromPosition(white, black, stateVisualizer, board, nextToMove)
+	if err != nil {
+		return nil, err
+	}
+	if giver == White {
+		game.SetTimeControl(handicap.GiverTime, handicap.ReceiverTime)
+	} else {
+		game.SetTimeControl(handicap.ReceiverTime, handicap.GiverTime)
+	}
+	return game, nil
+}
@@ -0,0 +1,59 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestNewHandicapBoard_removes_pieces_and_castling_rights(t *testing.T) {
+	tests := []struct {
+		handicap string
+		giver    Colour
+		fen      string
+	}{
+		{"Knight", White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/R1BQKBNR w KQkq - 0 1"},
+		{"Rook", White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/1NBQKBNR w Kkq - 0 1"},
+		{"Rook", Black, "1nbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQk - 0 1"},
+		{"Queen", Black, "rnb1kbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"},
+		{"Pawn and move", Black, "rnbqkbnr/ppppp1pp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"},
+		{"Pawn and move", White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPP1PP/RNBQKBNR b KQkq - 0 1"},
+		{"Queen and rook", White, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/1NB1KBNR w Kkq - 0 1"},
+	}
+	handicaps := map[string]Handicap{}
+	for _, handicap := range Handicaps() {
+		handicaps[handicap.Name] = handicap
+	}
+	for _, test := range tests {
+		board, nextToMove, err := NewHandicapBoard(handicaps[test.handicap], test.giver)
+		if err != nil {
+			t.Errorf("Failed to create %v odds board, %v", test.handicap, err)
+			continue
+		}
+		position := Position{Board: board, NextToMove: nextToMove, FullmoveNumber: 1}
+		if fen := position.FEN(); fen != test.fen {
+			t.Errorf("Expected %v odds given by %v to be %q, got %q", test.handicap, test.giver, test.fen, fen)
+		}
+	}
+}
+
+func TestNewHandicapBoard_rejects_missing_pieces(t *testing.T) {
+	if _, _, err := NewHandicapBoard(Handicap{Name: "Nothing", Squares: []Square{{"E", 4}}}, White); err == nil {
+		t.Errorf("Expected an error when there is no piece to remove")
+	}
+	if _, _, err := NewHandicapBoard(Handicap{Name: "King", Squares: []Square{{"E", 1}}}, White); err == nil {
+		t.Errorf("Expected an error when removing the king")
+	}
+}
+
+func TestNewHandicapGame_receiver_moves_first(t *testing.T) {
+	defer quiet()()
+	game, err := NewHandicapGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, Handicaps()[0], White)
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	if game.NextToMove != Black {
+		t.Errorf("Expected Black to receive the move")
+	}
+	if _, err := game.move(Move{From: Square{"E", 7}, To: Square{"E", 5}}, Black); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+}
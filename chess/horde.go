@@ -0,0 +1,36 @@
+package chess
+
+import "fmt"
+
+const hordeFEN = "rnbqkbnr/pppppppp/8/1PP2PP1/PPPPPPPP/PPPPPPPP/PPPPPPPP/PPPPPPPP w kq - 0 1"
+
+// white has 36 pawns and no king against the normal black army. White wins by checkmating black and black wins
+// by taking all of whites pieces. White pawns on the first row may move two squares, they can not be taken en passant
+type Horde struct {
+	Standard
+}
+
+func (Horde) Name() string {
+	return "Horde"
+}
+
+func (Horde) NewBoard() *Board {
+	position, err := ParseFEN(hordeFEN)
+	if err != nil {
+		panic(err)
+	}
+	return position.Board
+}
+
+func (Horde) GameOver(g *Game) (Result, bool) {
+	if g.Board.piecesInPlay(White) == 0 {
+		return winFor(Black, VariantRule, "White has lost all pieces"), true
+	}
+	if len(g.LegalMoves()) > 0 {
+		return Result{}, false
+	}
+	if isCheck, _ := g.Board.kingIsInCheck(g.NextToMove); isCheck {
+		return winFor(opponentOf(g.NextToMove), Checkmate, fmt.Sprintf("%v is in mate", g.NextToMove)), true
+	}
+	return drawBy(Stalemate, "Stale mate"), true
+}
@@ -0,0 +1,70 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestHorde_starting_position(t *testing.T) {
+	defer quiet()()
+	game := NewVariantGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, Horde{})
+	if n := game.Board.piecesInPlay(White); n != 36 {
+		t.Errorf("Expected White to have 36 pawns, got %v", n)
+	}
+	if king := game.Board.getKing(White); king.Type == King {
+		t.Errorf("Expected White to have no king")
+	}
+	if isCheck, _ := game.Board.kingIsInCheck(White); isCheck {
+		t.Errorf("Expected White without a king never to be in check")
+	}
+	if game.FEN() != hordeFEN {
+		t.Errorf("Expected %q, got %q", hordeFEN, game.FEN())
+	}
+	if result, over := (Horde{}).GameOver(game); over {
+		t.Errorf("Expected the game to go on, got %v", result)
+	}
+}
+
+func TestHorde_first_row_pawns_may_move_two_squares(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Horde{}, "4k3/8/8/8/8/8/8/P7 w - - 0 1")
+	if _, err := game.move(Move{From: Square{"A", 1}, To: Square{"A", 3}}, White); err != nil {
+		t.Errorf("Expected a pawn on the first row to move two squares, got %v", err)
+	}
+	if _, err := game.move(Move{From: Square{"E", 8}, To: Square{"E", 7}}, Black); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if _, err := game.move(Move{From: Square{"A", 3}, To: Square{"A", 5}}, White); err == nil {
+		t.Errorf("Expected the pawn not to move two squares again")
+	}
+}
+
+func TestHorde_black_wins_by_taking_all_white_pieces(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Horde{}, "4k3/8/8/8/8/8/8/r6P b - - 0 1")
+	if _, err := game.move(Move{From: Square{"A", 1}, To: Square{"H", 1}}, Black); err != nil {
+		t.Errorf("Failed to move, %v", err)
+		return
+	}
+	if result, over := (Horde{}).GameOver(game); !over || result.Outcome != BlackWon || result.Termination != VariantRule {
+		t.Errorf("Expected Black to win, got %+v", result)
+	}
+}
+
+func TestHorde_white_wins_by_checkmate(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Horde{}, "7k/5PP1/6PP/8/8/8/8/8 b - - 0 1")
+	if result, over := (Horde{}).GameOver(game); !over || result.Outcome != WhiteWon || result.Termination != Checkmate {
+		t.Errorf("Expected White to win by checkmate, got %+v", result)
+	}
+}
+
+func TestPerft_horde(t *testing.T) {
+	defer quiet()()
+	board := (Horde{}).NewBoard()
+	board.variant = Horde{}
+	for i, expected := range []int{8, 128, 1274} {
+		if nodes := Perft(board, White, i+1); nodes != expected {
+			t.Errorf("Expected horde perft(%v) to be %v, got %v", i+1, expected, nodes)
+		}
+	}
+}
@@ -5,31 +5,27 @@ import (
 )
 
 func moveKing(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
-	if p.Type != king {
+	if p.Type != King {
 		return nil, fmt.Errorf("MoveKing called on piece of type %v", p.Type)
 	}
-	if !dryRun && !p.MoveIsLegal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("move is not legal")
-	}
-
-	currentColumnValue := b.getColumnValue(p.CurrentSquare.Column)
-	targetColumnValue := b.getColumnValue(targetColumn)
+	side, isCastling := castlingSideFor(p, targetColumn, targetRow, b)
 
 	// check if castling attempt for either black or white
-	if targetRow == p.CurrentSquare.Row && targetColumnValue == currentColumnValue+2 ||
-		targetRow == p.CurrentSquare.Row && targetColumnValue == currentColumnValue-2 {
-		if err := p.tryCastling(targetColumn, targetRow, b, dryRun); err == nil {
+	if isCastling {
+		if err := p.tryCastling(side, b, dryRun); err == nil {
 			return &MoveResult{Action: GoTo, Piece: nil}, nil
 		} else {
-			return nil, fmt.Errorf("castling not allowed, err: %v", err)
+			return nil, illegalMove(CastlingNotAllowed, "castling not allowed, err: %v", err)
 		}
 	}
 
+	currentColumnValue := b.getColumnValue(p.CurrentSquare.Column)
+	targetColumnValue := b.getColumnValue(targetColumn)
 	if targetColumnValue > currentColumnValue+1 ||
 		targetColumnValue < currentColumnValue-1 ||
 		targetRow > p.CurrentSquare.Row+1 ||
 		targetRow < p.CurrentSquare.Row-1 {
-		return nil, fmt.Errorf("king cant move to square %v%v, can only move one square in any direction, except when castling", targetColumn, targetRow)
+		return nil, illegalMove(InvalidPieceMove, "king cant move to square %v%v, can only move one square in any direction, except when castling", targetColumn, targetRow)
 	}
 
 	enemyAtTargetSquare, enemyPiece := b.targetSquareOccupiedByEnemy(targetColumn, targetRow, p)
@@ -42,7 +38,7 @@ func moveKing(targetColumn string, targetRow int, b *Board, p *Piece, dryRun boo
 	} else {
 		occupied, pieceAtTarget := b.GetPieceAtSquare(targetColumn, targetRow)
 		if occupied {
-			return nil, fmt.Errorf("%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
+			return nil, illegalMove(SquareOccupied, "%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
 		} else { // not occupied
 			if !dryRun {
 				p.goTo(targetColumn, targetRow, b)
@@ -61,8 +57,48 @@ const (
 	noCastle
 )
 
-func (king *Piece) tryCastling(targetColumn string, targetRow int, b *Board, dryRun bool) error {
-	chosenRook, castleSide, err := selectRookAndSideForCastling(king, b, targetColumn)
+// the king and rook always end up on the same squares, regardless of where they started (chess960)
+var castlingTargets = map[castleSide]struct{ kingColumn, rookColumn string }{
+	kingside:  {"G", "F"},
+	queenside: {"C", "D"},
+}
+
+// returns which side the king is trying to castle to, if the move is a castling attempt. In standard chess the king
+// moves two squares towards the rook, in chess960 the king moves onto the square of its own castling rook
+func castlingSideFor(k *Piece, targetColumn string, targetRow int, b *Board) (castleSide, bool) {
+	if targetRow != k.CurrentSquare.Row {
+		return noCastle, false
+	}
+	kingColumnIndex := b.getColumnIndex(k.CurrentSquare.Column)
+	targetColumnIndex := b.getColumnIndex(targetColumn)
+	if b.chess960 {
+		occupied, piece := b.GetPieceAtSquare(targetColumn, targetRow)
+		if !occupied || piece.Colour != k.Colour || piece.Type != Rook {
+			return noCastle, false
+		}
+		if targetColumnIndex > kingColumnIndex && targetColumn == b.castlingRookColumns[k.Colour][kingside] {
+			return kingside, true
+		}
+		if targetColumnIndex < kingColumnIndex && targetColumn == b.castlingRookColumns[k.Colour][queenside] {
+			return queenside, true
+		}
+		return noCastle, false
+	}
+	if targetColumnIndex == kingColumnIndex+2 {
+		return kingside, true
+	}
+	if targetColumnIndex == kingColumnIndex-2 {
+		return queenside, true
+	}
+	return noCastle, false
+}
+
+func (king *Piece) tryCastling(side castleSide, b *Board, dryRun bool) error {
+	// the king might be a copy (e.g. when listing moves), the checks below temporarily move the king on the board
+	if found, kingOnBoard := b.GetPieceAtSquare(king.CurrentSquare.Column, king.CurrentSquare.Row); found {
+		king = kingOnBoard
+	}
+	chosenRook, err := selectRookForCastling(king, side, b)
 	if err != nil {
 		return err
 	}
@@ -71,25 +107,38 @@ func (king *Piece) tryCastling(targetColumn string, targetRow int, b *Board, dry
 	if chosenRook.hasMoved || king.hasMoved {
 		return fmt.Errorf("king or rook has previously moved")
 	}
-	// the king can not jump over pieces nor castle through check
-	// (into check is covered by MoveIsLegal in main func MoveKing)
-	if castleSide == kingside {
-		_, err := b.checkPathForOccupiedSquaresStraigthRight(chosenRook.CurrentSquare.Column, chosenRook.CurrentSquare.Row, king)
-		if err != nil {
-			return fmt.Errorf("pieces between king and rook")
+	kingFrom := b.getColumnIndex(king.CurrentSquare.Column)
+	kingTo := b.getColumnIndex(castlingTargets[side].kingColumn)
+	rookFrom := b.getColumnIndex(chosenRook.CurrentSquare.Column)
+	rookTo := b.getColumnIndex(castlingTargets[side].rookColumn)
+
+	// all squares the king and rook pass or land on must be empty (except for the king and rook themselves)
+	first, last := kingFrom, kingFrom
+	for _, columnIndex := range []int{kingTo, rookFrom, rookTo} {
+		if columnIndex < first {
+			first = columnIndex
 		}
-		err = b.checkPathForSquaresUnderAttackStraightRight(chosenRook.CurrentSquare.Column, chosenRook.CurrentSquare.Row, king)
-		if err != nil {
-			return fmt.Errorf("king passes through a square that is attacked by an enemy piece")
+		if columnIndex > last {
+			last = columnIndex
 		}
-
-	} else { // check queenside castling
-		_, err := b.checkPathForOccupiedSquaresStraightLeft(chosenRook.CurrentSquare.Column, chosenRook.CurrentSquare.Row, king)
-		if err != nil {
+	}
+	for i := first; i <= last; i++ {
+		occupied, piece := b.GetPieceAtSquare(b.columns[i], king.CurrentSquare.Row)
+		if occupied && piece != king && piece != chosenRook {
 			return fmt.Errorf("pieces between king and rook")
 		}
-		err = b.checkPathForSquaresUnderAttackStraightLeft(chosenRook.CurrentSquare.Column, chosenRook.CurrentSquare.Row, king)
-		if err != nil {
+	}
+	// the king can not castle through check (or into check)
+	realKingSquare := king.CurrentSquare
+	step := 1
+	if kingTo < kingFrom {
+		step = -1
+	}
+	for i := kingFrom + step; i != kingTo+step && kingFrom != kingTo; i += step {
+		king.CurrentSquare = Square{Column: b.columns[i], Row: realKingSquare.Row} // temp move
+		isCheck, _ := b.kingIsInCheck(king.Colour)
+		king.CurrentSquare = realKingSquare // undo temp move
+		if isCheck {
 			return fmt.Errorf("king passes through a square that is attacked by an enemy piece")
 		}
 	}
@@ -97,77 +146,42 @@ func (king *Piece) tryCastling(targetColumn string, targetRow int, b *Board, dry
 	if isCheck, _ := b.kingIsInCheck(king.Colour); isCheck {
 		return fmt.Errorf("king is in check")
 	}
+	// the rook might have been shielding the kings target square (chess960)
+	realRookSquare := chosenRook.CurrentSquare
+	king.CurrentSquare = Square{Column: b.columns[kingTo], Row: realKingSquare.Row}       // temp move
+	chosenRook.CurrentSquare = Square{Column: b.columns[rookTo], Row: realRookSquare.Row} // temp move
+	endsInCheck, _ := b.kingIsInCheck(king.Colour)
+	king.CurrentSquare = realKingSquare       // undo temp move
+	chosenRook.CurrentSquare = realRookSquare // undo temp move
+	if endsInCheck {
+		return fmt.Errorf("king ends up in check")
+	}
 
-	// move king two squares towards rook (kingside or queenside)
+	// move king and rook to their castling squares (kingside or queenside)
 	if !dryRun {
-		king.goTo(targetColumn, targetRow, b)
-	}
-	// move rook to other side of king, directly next to it on the opposite side
-	if castleSide == kingside { // castle kingside
-		if !dryRun {
-			// move rook to the left of the king
-			chosenRook.goTo(b.getColumnStringByIndex(b.getColumnIndex(king.CurrentSquare.Column)-1), king.CurrentSquare.Row, b)
-		}
-	} else { // castle queenside
-		if !dryRun {
-			// move rook to the right of the king
-			chosenRook.goTo(b.getColumnStringByIndex(b.getColumnIndex(king.CurrentSquare.Column)+1), king.CurrentSquare.Row, b)
-		}
+		king.goTo(b.columns[kingTo], realKingSquare.Row, b)
+		chosenRook.goTo(b.columns[rookTo], realRookSquare.Row, b)
 	}
 	return nil
 }
 
-func selectRookAndSideForCastling(k *Piece, b *Board, targetColumn string) (*Piece, castleSide, error) {
-	if k.Type != king {
-		return nil, noCastle, fmt.Errorf("Piece is not a King, cant select a rook and side for castling")
-	}
-	var kingSideRook *Piece
-	var kingSideRookFound bool
-	var queenSideRook *Piece
-	var queenSideRookFound bool
-
-	if k.Colour == White {
-		kingSideRookFound, kingSideRook = b.GetPieceAtSquare("H", 1)
-		queenSideRookFound, queenSideRook = b.GetPieceAtSquare("A", 1)
-	} else {
-		kingSideRookFound, kingSideRook = b.GetPieceAtSquare("H", 8)
-		queenSideRookFound, queenSideRook = b.GetPieceAtSquare("A", 8)
-	}
-
-	targetColumnValue := b.getColumnValue(targetColumn)
-	kingSideRookColumn := 8  // regardless of color and if it exists
-	queenSideRookColumn := 1 // regardless of color and if it exists
-	distanceToKingsideRook := kingSideRookColumn - targetColumnValue
-	distanceToQueensideRook := queenSideRookColumn - targetColumnValue
-	var chosenRook *Piece
-
-	// math absolute value
-	if distanceToKingsideRook < 0 {
-		distanceToKingsideRook = distanceToKingsideRook * -1
+// returns the rook the king would castle with on the given side
+func selectRookForCastling(k *Piece, side castleSide, b *Board) (*Piece, error) {
+	if k.Type != King {
+		return nil, fmt.Errorf("Piece is not a King, cant select a rook for castling")
 	}
-	if distanceToQueensideRook < 0 {
-		distanceToQueensideRook = distanceToQueensideRook * -1
-	}
-
-	var castleSide castleSide
-	if distanceToKingsideRook < distanceToQueensideRook {
-		castleSide = kingside
-		if !kingSideRookFound {
-			return nil, noCastle, fmt.Errorf("no rook found on kingside")
-		}
-		chosenRook = kingSideRook
-	} else {
-		castleSide = queenside
-		if !queenSideRookFound {
-			return nil, noCastle, fmt.Errorf("no rook found on queenside")
+	rookFound, rook := b.GetPieceAtSquare(b.castlingRookColumns[k.Colour][side], k.CurrentSquare.Row)
+	if !rookFound || rook.Type != Rook || rook.Colour != k.Colour {
+		if side == kingside {
+			return nil, fmt.Errorf("no rook found on kingside")
 		}
-		chosenRook = queenSideRook
+		return nil, fmt.Errorf("no rook found on queenside")
 	}
-	return chosenRook, castleSide, nil
+	return rook, nil
 }
 
 func (p *Piece) kingTryRun(b *Board) error {
-	if p.Type != king {
+	if p.Type != King {
 		return fmt.Errorf("Piece is not a King")
 	}
 	if isCheck, _ := b.kingIsInCheck(p.Colour); !isCheck {
@@ -252,15 +266,24 @@ func (p *Piece) getValidKingMoves(b *Board) map[Move]*MoveResult {
 	row = p.CurrentSquare.Row
 	possibleTargetSquares = addToListIfValidSquare(b, possibleTargetSquares, row, columnIndex)
 
-	// castling queenside
-	columnIndex = b.getColumnIndex(p.CurrentSquare.Column) - 2
-	row = p.CurrentSquare.Row
-	possibleTargetSquares = addToListIfValidSquare(b, possibleTargetSquares, row, columnIndex)
+	if b.chess960 {
+		// castling (the king moves onto its own rook)
+		for _, side := range []castleSide{queenside, kingside} {
+			if rook, err := selectRookForCastling(p, side, b); err == nil {
+				possibleTargetSquares = append(possibleTargetSquares, rook.CurrentSquare)
+			}
+		}
+	} else {
+		// castling queenside
+		columnIndex = b.getColumnIndex(p.CurrentSquare.Column) - 2
+		row = p.CurrentSquare.Row
+		possibleTargetSquares = addToListIfValidSquare(b, possibleTargetSquares, row, columnIndex)
 
-	// castling kingside
-	columnIndex = b.getColumnIndex(p.CurrentSquare.Column) + 2
-	row = p.CurrentSquare.Row
-	possibleTargetSquares = addToListIfValidSquare(b, possibleTargetSquares, row, columnIndex)
+		// castling kingside
+		columnIndex = b.getColumnIndex(p.CurrentSquare.Column) + 2
+		row = p.CurrentSquare.Row
+		possibleTargetSquares = addToListIfValidSquare(b, possibleTargetSquares, row, columnIndex)
+	}
 
 	for _, s := range possibleTargetSquares {
 		result, err := moveKing(s.Column, s.Row, b, p, true)
@@ -7,7 +7,7 @@ import (
 
 func TestMoveKing_can_move_1_square_in_any_direction(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -18,9 +18,9 @@ func TestMoveKing_can_move_1_square_in_any_direction(t *testing.T) {
 	// ..  ..  ..  ..  WK  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  ..  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 1}, Square{Column: "E", Row: 2}}
-	whiteMove3 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 3}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 1}, To: Square{Column: "E", Row: 2}}
+	whiteMove3 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 3}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -113,7 +113,7 @@ func TestMoveKing_can_move_1_square_in_any_direction(t *testing.T) {
 }
 
 func TestKingTryRun_can_outrun_a_check(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// BR  BN  BB  ..  BK  BB  BN  BR
 	// bP  bP  ..  bP  bP  bP  bP  bP
 	// ..  ..  ..  ..  ..  ..  ..  ..
@@ -122,10 +122,10 @@ func TestKingTryRun_can_outrun_a_check(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  ..  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	blackMove1 := Move{Square{Column: "C", Row: 7}, Square{Column: "C", Row: 5}}
-	blackMove2 := Move{Square{Column: "D", Row: 8}, Square{Column: "A", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	blackMove1 := Move{From: Square{Column: "C", Row: 7}, To: Square{Column: "C", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "A", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2, blackMove1, blackMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -145,7 +145,7 @@ func TestKingTryRun_can_outrun_a_check(t *testing.T) {
 		t.Errorf("Expected the enemy to be at A5, but got %v%v", enemies[0].CurrentSquare.Column, enemies[0].CurrentSquare.Row)
 		return
 	}
-	if enemies[0].Type != queen {
+	if enemies[0].Type != Queen {
 		t.Errorf("Expected the enemy to be a queen, but got %v", enemies[0].Type)
 		return
 	}
@@ -157,7 +157,7 @@ func TestKingTryRun_can_outrun_a_check(t *testing.T) {
 
 }
 func TestKingTryRun_BLACK_can_outrun_a_check_when_on_edge_of_board(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// BR  BN  BB  BQ  ..  BB  BN  BR
 	// bP  bP  bP  bP  bP  ..  bP  bP
 	// ..  ..  ..  ..  ..  bP  ..  ..
@@ -167,10 +167,10 @@ func TestKingTryRun_BLACK_can_outrun_a_check_when_on_edge_of_board(t *testing.T)
 	// wP  wP  wP  wP  wP  wP  wP  ..
 	// WR  WN  WB  WQ  WK  WB  WN  WR
 
-	blackMove1 := Move{Square{Column: "F", Row: 7}, Square{Column: "F", Row: 6}}
-	blackMove2 := Move{Square{Column: "E", Row: 8}, Square{Column: "F", Row: 7}}
-	blackMove3 := Move{Square{Column: "F", Row: 7}, Square{Column: "G", Row: 6}}
-	blackMove4 := Move{Square{Column: "G", Row: 6}, Square{Column: "H", Row: 5}}
+	blackMove1 := Move{From: Square{Column: "F", Row: 7}, To: Square{Column: "F", Row: 6}}
+	blackMove2 := Move{From: Square{Column: "E", Row: 8}, To: Square{Column: "F", Row: 7}}
+	blackMove3 := Move{From: Square{Column: "F", Row: 7}, To: Square{Column: "G", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "G", Row: 6}, To: Square{Column: "H", Row: 5}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -195,7 +195,7 @@ func TestKingTryRun_BLACK_can_outrun_a_check_when_on_edge_of_board(t *testing.T)
 		t.Errorf("Expected the enemy to be at A5, but got %v%v", enemies[0].CurrentSquare.Column, enemies[0].CurrentSquare.Row)
 		return
 	}
-	if enemies[0].Type != rook {
+	if enemies[0].Type != Rook {
 		t.Errorf("Expected the enemy to be a rook, but got %v", enemies[0].Type)
 		return
 	}
@@ -208,7 +208,7 @@ func TestKingTryRun_BLACK_can_outrun_a_check_when_on_edge_of_board(t *testing.T)
 }
 
 func TestKingTryRun_returns_error_if_king_cant_run_anywhere(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// BR  BN  BB  ..  BK  BB  BN  BR
 	// bP  bP  ..  bP  bP  bP  bP  bP
 	// ..  ..  ..  ..  ..  ..  ..  ..
@@ -217,9 +217,9 @@ func TestKingTryRun_returns_error_if_king_cant_run_anywhere(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  ..  wP  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	blackMove1 := Move{Square{Column: "C", Row: 7}, Square{Column: "C", Row: 5}}
-	blackMove2 := Move{Square{Column: "D", Row: 8}, Square{Column: "A", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	blackMove1 := Move{From: Square{Column: "C", Row: 7}, To: Square{Column: "C", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "A", Row: 5}}
 	moves := []Move{whiteMove1, blackMove1, blackMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -239,7 +239,7 @@ func TestKingTryRun_returns_error_if_king_cant_run_anywhere(t *testing.T) {
 		t.Errorf("Expected the enemy to be at A5, but got %v%v", enemies[0].CurrentSquare.Column, enemies[0].CurrentSquare.Row)
 		return
 	}
-	if enemies[0].Type != queen {
+	if enemies[0].Type != Queen {
 		t.Errorf("Expected the enemy to be a queen, but got %v", enemies[0].Type)
 		return
 	}
@@ -253,7 +253,7 @@ func TestKingTryRun_returns_error_if_king_cant_run_anywhere(t *testing.T) {
 
 func TestGetValidKingMovdes(t *testing.T) {
 	//defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -264,9 +264,9 @@ func TestGetValidKingMovdes(t *testing.T) {
 	// ..  ..  ..  ..  WK  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  ..  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 1}, Square{Column: "E", Row: 2}}
-	whiteMove3 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 3}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 1}, To: Square{Column: "E", Row: 2}}
+	whiteMove3 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 3}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -320,7 +320,7 @@ func TestGetValidKingMovdes(t *testing.T) {
 }
 
 func TestMoveKing_cant_move_into_check(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// BR  BN  BB  ..  BK  BB  BN  BR
 	// bP  bP  ..  bP  bP  bP  bP  bP
 	// ..  ..  ..  ..  ..  ..  ..  ..
@@ -329,11 +329,11 @@ func TestMoveKing_cant_move_into_check(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  ..  WK  wP  wP  wP
 	// WR  WN  WB  WQ  ..  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove3 := Move{Square{Column: "E", Row: 1}, Square{Column: "E", Row: 2}}
-	blackMove1 := Move{Square{Column: "C", Row: 7}, Square{Column: "C", Row: 5}}
-	blackMove2 := Move{Square{Column: "D", Row: 8}, Square{Column: "A", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove3 := Move{From: Square{Column: "E", Row: 1}, To: Square{Column: "E", Row: 2}}
+	blackMove1 := Move{From: Square{Column: "C", Row: 7}, To: Square{Column: "C", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "A", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3, blackMove1, blackMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -351,7 +351,7 @@ func TestMoveKing_cant_move_into_check(t *testing.T) {
 }
 
 func TestMoveKingBlack_cant_move_into_check(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// BR  BN  BB  BQ  BK  BB  BN  BR
 	// bP  bP  bp  bP  ..  bP  bP  bP
 	// ..  ..  ..  ..  bP  ..  ..  ..
@@ -360,9 +360,9 @@ func TestMoveKingBlack_cant_move_into_check(t *testing.T) {
 	// ..  ..  ..  wp  ..  ..  ..  ..
 	// wP  wP  wP  ..  wP  wP  wP  wP
 	// WR  WN  ..  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 3}}
-	whiteMove2 := Move{Square{Column: "C", Row: 1}, Square{Column: "G", Row: 5}}
-	blackMove1 := Move{Square{Column: "E", Row: 7}, Square{Column: "E", Row: 6}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 3}}
+	whiteMove2 := Move{From: Square{Column: "C", Row: 1}, To: Square{Column: "G", Row: 5}}
+	blackMove1 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "E", Row: 6}}
 	moves := []Move{whiteMove1, whiteMove2, blackMove1}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -395,7 +395,7 @@ func TestMoveKingBlack_cant_move_into_check(t *testing.T) {
 }
 
 func TestMoveKingBlack_cant_move_into_check_by_pawn(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// BR  BN  BB  BQ  ..  BB  BN  BR
 	// bP  bP  bp  bP  ..  bP  bP  bP
 	// ..  ..  ..  ..  bP  BK  ..  ..
@@ -404,10 +404,10 @@ func TestMoveKingBlack_cant_move_into_check_by_pawn(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	blackMove1 := Move{Square{Column: "E", Row: 7}, Square{Column: "E", Row: 6}}
-	blackMove2 := Move{Square{Column: "E", Row: 8}, Square{Column: "E", Row: 7}}
-	blackMove3 := Move{Square{Column: "E", Row: 7}, Square{Column: "F", Row: 6}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	blackMove1 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "E", Row: 6}}
+	blackMove2 := Move{From: Square{Column: "E", Row: 8}, To: Square{Column: "E", Row: 7}}
+	blackMove3 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "F", Row: 6}}
 	moves := []Move{whiteMove1, blackMove1, blackMove2, blackMove3}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -440,7 +440,7 @@ func TestMoveKingBlack_cant_move_into_check_by_pawn(t *testing.T) {
 }
 
 func TestMoveKingBlack_cant_move_into_check_by_rook(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 	// BR  BN  BB  BQ  ..  BB  BN  BR
 	// bP  bP  bp  bP  ..  bP  bP  bP
 	// ..  ..  ..  ..  bP  BK  ..  ..
@@ -449,12 +449,12 @@ func TestMoveKingBlack_cant_move_into_check_by_rook(t *testing.T) {
 	// ..  ..  ..  ..  WR  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 1}, Square{Column: "A", Row: 3}}
-	whiteMove3 := Move{Square{Column: "A", Row: 3}, Square{Column: "E", Row: 3}}
-	blackMove1 := Move{Square{Column: "E", Row: 7}, Square{Column: "E", Row: 6}}
-	blackMove2 := Move{Square{Column: "E", Row: 8}, Square{Column: "E", Row: 7}}
-	blackMove3 := Move{Square{Column: "E", Row: 7}, Square{Column: "F", Row: 6}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 1}, To: Square{Column: "A", Row: 3}}
+	whiteMove3 := Move{From: Square{Column: "A", Row: 3}, To: Square{Column: "E", Row: 3}}
+	blackMove1 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "E", Row: 6}}
+	blackMove2 := Move{From: Square{Column: "E", Row: 8}, To: Square{Column: "E", Row: 7}}
+	blackMove3 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "F", Row: 6}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3, blackMove1, blackMove2, blackMove3}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -487,7 +487,7 @@ func TestMoveKingBlack_cant_move_into_check_by_rook(t *testing.T) {
 }
 
 func TestMoveKing_white_can_castle_kingside(t *testing.T) {
-	board := newBoard()
+	board := NewBoard()
 
 	// BR  BN  BB  BQ  BK  BB  BN  BR
 	// bP  bP  bp  bP  bp  bP  bP  bP
@@ -498,10 +498,10 @@ func TestMoveKing_white_can_castle_kingside(t *testing.T) {
 	// wP  wP  wP  ..  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  ..  ..  WR
 
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove3 := Move{Square{Column: "F", Row: 1}, Square{Column: "D", Row: 3}}
-	whiteMove4 := Move{Square{Column: "G", Row: 1}, Square{Column: "F", Row: 3}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove3 := Move{From: Square{Column: "F", Row: 1}, To: Square{Column: "D", Row: 3}}
+	whiteMove4 := Move{From: Square{Column: "G", Row: 1}, To: Square{Column: "F", Row: 3}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3, whiteMove4}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -543,13 +543,13 @@ func TestMoveKing_white_can_castle_queen_side(t *testing.T) {
 	♙  ♙  ♙  ♕  .  ♙  ♙  ♙
 	♖  .  .  .  ♔  ♗  ♘  ♖
 	`
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove3 := Move{Square{Column: "C", Row: 1}, Square{Column: "E", Row: 3}}
-	whiteMove4 := Move{Square{Column: "B", Row: 1}, Square{Column: "A", Row: 3}}
-	whiteMove5 := Move{Square{Column: "D", Row: 1}, Square{Column: "D", Row: 2}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove3 := Move{From: Square{Column: "C", Row: 1}, To: Square{Column: "E", Row: 3}}
+	whiteMove4 := Move{From: Square{Column: "B", Row: 1}, To: Square{Column: "A", Row: 3}}
+	whiteMove5 := Move{From: Square{Column: "D", Row: 1}, To: Square{Column: "D", Row: 2}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3, whiteMove4, whiteMove5}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -596,17 +596,17 @@ func TestMoveKing_white_can_castle_queen_side_even_if_kingside_rook_gone(t *test
 	♙  ♙  ♙  ♕  .  ♙  ♙  ♙
 	♖  .  .  .  ♔  ♗  ♘  .
 	`
-	board := newBoard()
+	board := NewBoard()
 
 	// remove white kingside rook
 	_, rook := board.GetPieceAtSquare("H", 1)
 	rook.InPlay = false
 
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove3 := Move{Square{Column: "C", Row: 1}, Square{Column: "E", Row: 3}}
-	whiteMove4 := Move{Square{Column: "B", Row: 1}, Square{Column: "A", Row: 3}}
-	whiteMove5 := Move{Square{Column: "D", Row: 1}, Square{Column: "D", Row: 2}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove3 := Move{From: Square{Column: "C", Row: 1}, To: Square{Column: "E", Row: 3}}
+	whiteMove4 := Move{From: Square{Column: "B", Row: 1}, To: Square{Column: "A", Row: 3}}
+	whiteMove5 := Move{From: Square{Column: "D", Row: 1}, To: Square{Column: "D", Row: 2}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3, whiteMove4, whiteMove5}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -652,11 +652,11 @@ func TestMove_black_can_castle_kingside(t *testing.T) {
 	♙  ♙  ♙  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  ♕  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "E", Row: 7}, Square{Column: "E", Row: 5}}
-	blackMove2 := Move{Square{Column: "F", Row: 8}, Square{Column: "D", Row: 6}}
-	blackMove3 := Move{Square{Column: "G", Row: 8}, Square{Column: "H", Row: 6}}
+	blackMove1 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "E", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "F", Row: 8}, To: Square{Column: "D", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "G", Row: 8}, To: Square{Column: "H", Row: 6}}
 	moves := []Move{blackMove1, blackMove2, blackMove3}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -698,12 +698,12 @@ func TestMove_black_can_castle_queen_side(t *testing.T) {
 	♙  ♙  ♙  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  ♕  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "C", Row: 8}, Square{Column: "E", Row: 6}}
-	blackMove3 := Move{Square{Column: "B", Row: 8}, Square{Column: "A", Row: 6}}
-	blackMove4 := Move{Square{Column: "D", Row: 8}, Square{Column: "D", Row: 7}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "C", Row: 8}, To: Square{Column: "E", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "B", Row: 8}, To: Square{Column: "A", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "D", Row: 7}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -745,14 +745,14 @@ func TestMove_black_cant_castle_queen_side_when_in_check(t *testing.T) {
 	♙  ♙  .  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  .  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "C", Row: 8}, Square{Column: "E", Row: 6}}
-	blackMove3 := Move{Square{Column: "B", Row: 8}, Square{Column: "A", Row: 6}}
-	blackMove4 := Move{Square{Column: "D", Row: 8}, Square{Column: "D", Row: 6}}
-	whiteMove1 := Move{Square{Column: "C", Row: 2}, Square{Column: "C", Row: 3}}
-	whiteMove2 := Move{Square{Column: "D", Row: 1}, Square{Column: "A", Row: 4}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "C", Row: 8}, To: Square{Column: "E", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "B", Row: 8}, To: Square{Column: "A", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "D", Row: 6}}
+	whiteMove1 := Move{From: Square{Column: "C", Row: 2}, To: Square{Column: "C", Row: 3}}
+	whiteMove2 := Move{From: Square{Column: "D", Row: 1}, To: Square{Column: "A", Row: 4}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4, whiteMove1, whiteMove2}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -785,17 +785,17 @@ func TestMove_black_cant_castle_queen_side_passing_through_check(t *testing.T) {
 	♙  ♙  .  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  .  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "C", Row: 8}, Square{Column: "E", Row: 6}}
-	blackMove3 := Move{Square{Column: "B", Row: 8}, Square{Column: "A", Row: 6}}
-	blackMove4 := Move{Square{Column: "D", Row: 8}, Square{Column: "D", Row: 6}}
-	whiteMove1 := Move{Square{Column: "C", Row: 2}, Square{Column: "C", Row: 3}}
-	whiteMove2 := Move{Square{Column: "D", Row: 1}, Square{Column: "A", Row: 4}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "C", Row: 8}, To: Square{Column: "E", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "B", Row: 8}, To: Square{Column: "A", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "D", Row: 6}}
+	whiteMove1 := Move{From: Square{Column: "C", Row: 2}, To: Square{Column: "C", Row: 3}}
+	whiteMove2 := Move{From: Square{Column: "D", Row: 1}, To: Square{Column: "A", Row: 4}}
 	// move white queen to D4 to attack D8 blocking the black king from castling queenside
 	// after we simulate the black pawn at D5 was taken and the black queen at D6 was taken
-	whiteMove3 := Move{Square{Column: "A", Row: 4}, Square{Column: "D", Row: 4}}
+	whiteMove3 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "D", Row: 4}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4, whiteMove1, whiteMove2, whiteMove3}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -835,12 +835,12 @@ func TestMove_black_cant_castle_queen_side_if_king_moved(t *testing.T) {
 	♙  ♙  ♙  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  ♕  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "C", Row: 8}, Square{Column: "E", Row: 6}}
-	blackMove3 := Move{Square{Column: "B", Row: 8}, Square{Column: "A", Row: 6}}
-	blackMove4 := Move{Square{Column: "D", Row: 8}, Square{Column: "D", Row: 7}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "C", Row: 8}, To: Square{Column: "E", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "B", Row: 8}, To: Square{Column: "A", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "D", Row: 7}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -879,12 +879,12 @@ func TestMove_black_cant_castle_queen_side_if_rook_moved_out_of_position(t *test
 	♙  ♙  ♙  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  ♕  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "C", Row: 8}, Square{Column: "E", Row: 6}}
-	blackMove3 := Move{Square{Column: "B", Row: 8}, Square{Column: "A", Row: 6}}
-	blackMove4 := Move{Square{Column: "D", Row: 8}, Square{Column: "D", Row: 7}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "C", Row: 8}, To: Square{Column: "E", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "B", Row: 8}, To: Square{Column: "A", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "D", Row: 7}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -924,12 +924,12 @@ func TestMove_black_cant_castle_queen_side_if_rook_moved(t *testing.T) {
 	♙  ♙  ♙  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  ♕  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "C", Row: 8}, Square{Column: "E", Row: 6}}
-	blackMove3 := Move{Square{Column: "B", Row: 8}, Square{Column: "A", Row: 6}}
-	blackMove4 := Move{Square{Column: "D", Row: 8}, Square{Column: "D", Row: 7}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "C", Row: 8}, To: Square{Column: "E", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "B", Row: 8}, To: Square{Column: "A", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "D", Row: 7}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -971,17 +971,17 @@ func TestMove_black_cant_castle_queen_side_ending_up_in_check(t *testing.T) {
 	♙  ♙  .  ♙  ♙  ♙  ♙  ♙
 	♖  ♘  ♗  .  ♔  ♗  ♘  ♖
 	`
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "C", Row: 8}, Square{Column: "E", Row: 6}}
-	blackMove3 := Move{Square{Column: "B", Row: 8}, Square{Column: "A", Row: 6}}
-	blackMove4 := Move{Square{Column: "D", Row: 8}, Square{Column: "D", Row: 6}}
-	whiteMove1 := Move{Square{Column: "C", Row: 2}, Square{Column: "C", Row: 3}}
-	whiteMove2 := Move{Square{Column: "D", Row: 1}, Square{Column: "A", Row: 4}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "C", Row: 8}, To: Square{Column: "E", Row: 6}}
+	blackMove3 := Move{From: Square{Column: "B", Row: 8}, To: Square{Column: "A", Row: 6}}
+	blackMove4 := Move{From: Square{Column: "D", Row: 8}, To: Square{Column: "D", Row: 6}}
+	whiteMove1 := Move{From: Square{Column: "C", Row: 2}, To: Square{Column: "C", Row: 3}}
+	whiteMove2 := Move{From: Square{Column: "D", Row: 1}, To: Square{Column: "A", Row: 4}}
 	// move white queen to C4 to attack C8 blocking the black king from castling queenside
 	// after we simulate the black pawn at D5 and C7 was taken and the black queen at D6 was taken
-	whiteMove3 := Move{Square{Column: "A", Row: 4}, Square{Column: "C", Row: 4}}
+	whiteMove3 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "C", Row: 4}}
 	moves := []Move{blackMove1, blackMove2, blackMove3, blackMove4, whiteMove1, whiteMove2, whiteMove3}
-	board := newBoard()
+	board := NewBoard()
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
 		t.Errorf("Failed to prep the board, %s", scenarioPrepError.Error())
@@ -1,24 +1,20 @@
 package chess
 
 import (
-	"errors"
 	"fmt"
 )
 
 func moveKnight(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
-	if p.Type != knight {
+	if p.Type != Knight {
 		return nil, fmt.Errorf("MoveKnight called on piece of type %v", p.Type)
 	}
 	if !p.InPlay {
-		return nil, errors.New("Piece is not in play")
-	}
-	if !dryRun && !p.MoveIsLegal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("move is not legal")
+		return nil, illegalMove(PieceNotInPlay, "Piece is not in play")
 	}
 	if squareIsPossibleTarget(p, targetRow, targetColumn, b) {
 		occupied, pieceAtTarget := b.GetPieceAtSquare(targetColumn, targetRow)
 		if occupied && !p.enemyTo(pieceAtTarget) {
-			return nil, fmt.Errorf("%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
+			return nil, illegalMove(SquareOccupied, "%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
 		} else if occupied && p.enemyTo(pieceAtTarget) {
 			if !dryRun {
 				p.takeAt(targetColumn, targetRow, pieceAtTarget, b)
@@ -31,7 +27,7 @@ func moveKnight(targetColumn string, targetRow int, b *Board, p *Piece, dryRun b
 			return &MoveResult{Action: GoTo, Piece: nil}, nil
 		}
 	}
-	return nil, fmt.Errorf("move is not legal")
+	return nil, illegalMove(InvalidPieceMove, "move is not legal")
 }
 
 func squareIsPossibleTarget(p *Piece, targetRow int, targetColumn string, b *Board) bool {
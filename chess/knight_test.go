@@ -6,7 +6,7 @@ import (
 
 func TestMoveKnight(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	_, WN := board.GetPieceAtSquare("G", 1)
 	_, err := WN.Move("F", 3, board, false)
@@ -30,7 +30,7 @@ func TestMoveKnight(t *testing.T) {
 }
 func TestGetValidKnightMoves(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	// From starting position
 	_, WN := board.GetPieceAtSquare("G", 1)
 	peeks := WN.getValidKnightMoves(board)
@@ -68,7 +68,7 @@ func TestGetValidKnightMoves(t *testing.T) {
 }
 func TestMoveKnight_should_be_able_to_remove_check(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	// SCENARIO WN should be table to take BR to remove check on WK
 	// BR  BN  BB  BQ  BK  BB  ..  ..
 	// bP  bP  bP  bP  bP  bP  ..  bP
@@ -89,10 +89,10 @@ func TestMoveKnight_should_be_able_to_remove_check(t *testing.T) {
 	_, wpG2 := board.GetPieceAtSquare("G", 2)
 	wpG2.InPlay = false // simulate taken
 
-	whiteMove1 := Move{Square{Column: "G", Row: 1}, Square{Column: "H", Row: 3}} // WN to H3
-	blackMove1 := Move{Square{Column: "G", Row: 8}, Square{Column: "F", Row: 6}} // BN to F6
-	blackMove2 := Move{Square{Column: "H", Row: 8}, Square{Column: "G", Row: 8}} // BR to G8
-	blackMove3 := Move{Square{Column: "G", Row: 8}, Square{Column: "G", Row: 1}} // BR to G1 check
+	whiteMove1 := Move{From: Square{Column: "G", Row: 1}, To: Square{Column: "H", Row: 3}} // WN to H3
+	blackMove1 := Move{From: Square{Column: "G", Row: 8}, To: Square{Column: "F", Row: 6}} // BN to F6
+	blackMove2 := Move{From: Square{Column: "H", Row: 8}, To: Square{Column: "G", Row: 8}} // BR to G8
+	blackMove3 := Move{From: Square{Column: "G", Row: 8}, To: Square{Column: "G", Row: 1}} // BR to G1 check
 
 	moves := []Move{whiteMove1, blackMove1, blackMove2, blackMove3}
 	scenarioPrepError := prepScenario(moves, board)
@@ -0,0 +1,164 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MatchOptions struct {
+	Pairs    int // the number of game pairs, each opening is played twice with the colours swapped
+	Parallel int // the number of pairs played at the same time, 1 if not set
+	// FEN of the positions the pairs start from, used in turn. The starting position of the variant if empty
+	Openings []string
+	Variant  Variant // Standard if nil
+	// stops the match early once one of its hypotheses is accepted, the pairs being played are still finished
+	SPRT  *SPRT
+	Names [2]string // the names of the players in the PGN, "A" and "B" if not set
+	PGN   io.Writer // every game is written to it when not nil, in the order the pairs finish
+}
+
+// the outcome of a match, wins, draws and losses are those of the first player
+type MatchResult struct {
+	Wins     int
+	Draws    int
+	Losses   int
+	Pairs    int
+	Decision SPRTDecision // SPRTContinue when there is no SPRT or it did not decide
+	SPRT     *SPRT
+	Duration time.Duration
+}
+
+// stops playInParallel from starting more pairs once the SPRT has decided
+var errMatchDecided = errors.New("match decided")
+
+// plays pairs of games between the players a and b, each opening once with a as white and once with a as black
+func PlayMatch(a PlayerFactory, b PlayerFactory, options MatchOptions) (MatchResult, error) {
+	if options.Pairs < 1 {
+		return MatchResult{}, fmt.Errorf("a match needs at least one pair of games, got %v", options.Pairs)
+	}
+	if a == nil || b == nil {
+		return MatchResult{}, fmt.Errorf("a match needs a player factory for both players")
+	}
+	variant := options.Variant
+	if variant == nil {
+		variant = Standard{}
+	}
+	names := options.Names
+	if names[0] == "" {
+		names[0] = "A"
+	}
+	if names[1] == "" {
+		names[1] = "B"
+	}
+	newGame := func(white Player, black Player, opening string) (*Game, error) {
+		if opening == "" {
+			return NewVariantGame(white, black, &NullVisualizer{}, variant), nil
+		}
+		return NewVariantGameFromFEN(white, black, &NullVisualizer{}, variant, opening)
+	}
+
+	result := MatchResult{SPRT: options.SPRT}
+	var mu sync.Mutex
+	started := time.Now()
+	err := playInParallel(options.Pairs, options.Parallel, func(pair int) error {
+		opening := ""
+		if len(options.Openings) > 0 {
+			opening = options.Openings[pair%len(options.Openings)]
+		}
+		first, err := newGame(a(White), b(Black), opening)
+		if err != nil {
+			return err
+		}
+		first.Start()
+		second, err := newGame(b(White), a(Black), opening)
+		if err != nil {
+			return err
+		}
+		second.Start()
+
+		mu.Lock()
+		defer mu.Unlock()
+		result.Pairs++
+		result.add(first.Result(), White)
+		result.add(second.Result(), Black)
+		if options.PGN != nil {
+			round := strconv.Itoa(pair + 1)
+			if err := WritePGN(options.PGN, first, map[string]string{"Event": "match", "Round": round, "White": names[0], "Black": names[1]}); err != nil {
+				return err
+			}
+			if err := WritePGN(options.PGN, second, map[string]string{"Event": "match", "Round": round, "White": names[1], "Black": names[0]}); err != nil {
+				return err
+			}
+		}
+		if options.SPRT != nil && result.Decision == SPRTContinue {
+			result.Decision = options.SPRT.Decide(result.Wins, result.Draws, result.Losses)
+			if result.Decision != SPRTContinue {
+				return errMatchDecided
+			}
+		}
+		return nil
+	})
+	result.Duration = time.Since(started)
+	if err == errMatchDecided {
+		err = nil
+	}
+	return result, err
+}
+
+// counts the result of a game the first player played with the colour
+func (r *MatchResult) add(result Result, colour Colour) {
+	winner, won := result.Winner()
+	switch {
+	case result.Draw():
+		r.Draws++
+	case won && winner == colour:
+		r.Wins++
+	case won:
+		r.Losses++
+	}
+}
+
+func (r MatchResult) Games() int {
+	return r.Wins + r.Draws + r.Losses
+}
+
+// returns the score (0 to 1) of the first player
+func (r MatchResult) Score() float64 {
+	score, _ := scoreAndVariance(r.Wins, r.Draws, r.Losses)
+	return score
+}
+
+// returns the Elo difference of the first player and the margin of its 95% confidence interval
+func (r MatchResult) Elo() (float64, float64) {
+	return EloEstimate(r.Wins, r.Draws, r.Losses)
+}
+
+// returns the likelihood of superiority of the first player
+func (r MatchResult) LOS() float64 {
+	return LOS(r.Wins, r.Losses)
+}
+
+// returns a report of the match, one statistic per line
+func (r MatchResult) String() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Games: %v (%v pairs) in %v\n", r.Games(), r.Pairs, r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(&text, "Score: %v wins, %v draws, %v losses (%.1f%%)\n", r.Wins, r.Draws, r.Losses, 100*r.Score())
+	elo, margin := r.Elo()
+	if math.IsInf(margin, 1) {
+		fmt.Fprintf(&text, "Elo: %.1f (too few games for error bars)\n", elo)
+	} else {
+		fmt.Fprintf(&text, "Elo: %.1f +/- %.1f\n", elo, margin)
+	}
+	fmt.Fprintf(&text, "LOS: %.1f%%\n", 100*r.LOS())
+	if r.SPRT != nil {
+		lower, upper := r.SPRT.Bounds()
+		fmt.Fprintf(&text, "%v: LLR %.2f (%.2f, %.2f), %v\n", r.SPRT, r.SPRT.LLR(r.Wins, r.Draws, r.Losses), lower, upper, r.Decision)
+	}
+	return text.String()
+}
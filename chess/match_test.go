@@ -0,0 +1,60 @@
+package chess
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPlayMatch_plays_each_opening_with_both_colours(t *testing.T) {
+	defer quiet()()
+	var pgn bytes.Buffer
+	result, err := PlayMatch(foolsMatePlayer, resigningPlayer, MatchOptions{
+		Pairs:    2,
+		Openings: []string{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "r1bqkbnr/pppppppp/2n5/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 1 2"},
+		Names:    [2]string{"mater", "resigner"},
+		PGN:      &pgn,
+	})
+	if err != nil {
+		t.Fatalf("Failed to play the match, %v", err)
+	}
+	// the resigner loses every game, with white before its first move and with black after whites first move
+	if result.Pairs != 2 || result.Wins != 4 || result.Games() != 4 || result.Score() != 1 {
+		t.Errorf("Expected 4 wins in 2 pairs, got %+v", result)
+	}
+	games, err := ParsePGN(&pgn)
+	if err != nil || len(games) != 4 {
+		t.Fatalf("Expected 4 games in the PGN, got %v (%v)", len(games), err)
+	}
+	colours := map[string]int{}
+	for _, game := range games {
+		colours[game.Tags["White"]+" "+game.Tags["Round"]]++
+	}
+	for _, key := range []string{"mater 1", "resigner 1", "mater 2", "resigner 2"} {
+		if colours[key] != 1 {
+			t.Errorf("Expected one game for %q, got %v", key, colours)
+		}
+	}
+	if !strings.Contains(result.String(), "too few games") {
+		t.Errorf("Expected no error bars with only wins, got\n%v", result)
+	}
+}
+
+func TestPlayMatch_stops_when_the_SPRT_decides(t *testing.T) {
+	defer quiet()()
+	sprt := &SPRT{Elo0: 0, Elo1: 200, Alpha: 0.05, Beta: 0.05}
+	// black mates in every game, so each pair is a win and a loss
+	result, err := PlayMatch(foolsMatePlayer, foolsMatePlayer, MatchOptions{Pairs: 100, Parallel: 2, SPRT: sprt})
+	if err != nil {
+		t.Fatalf("Failed to play the match, %v", err)
+	}
+	if result.Decision != SPRTAcceptH0 || result.Pairs >= 100 {
+		t.Errorf("Expected H0 to be accepted early, got %v after %v pairs", result.Decision, result.Pairs)
+	}
+	if elo, _ := result.Elo(); elo != 0 || result.LOS() != 0.5 {
+		t.Errorf("Expected even players, got %v Elo and LOS %v", elo, result.LOS())
+	}
+	if !strings.Contains(result.String(), "H0 accepted") {
+		t.Errorf("Expected the decision in the report, got\n%v", result)
+	}
+}
@@ -1,21 +1,17 @@
 package chess
 
 import (
-	"errors"
 	"fmt"
 )
 
 func movePawn(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
 
-	if p.Type != pawn {
+	if p.Type != Pawn {
 		return nil, fmt.Errorf("MovePawn called on piece of type %v", p.Type)
 	}
 
 	if !p.InPlay {
-		return nil, errors.New("Piece is not in play")
-	}
-	if !dryRun && !p.MoveIsLegal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("illegal move")
+		return nil, illegalMove(PieceNotInPlay, "Piece is not in play")
 	}
 
 	if p.moveIsStraight(targetColumn, targetRow) {
@@ -23,37 +19,37 @@ func movePawn(targetColumn string, targetRow int, b *Board, p *Piece, dryRun boo
 	} else if p.moveIsDiagonal(targetColumn, targetRow, b) {
 		return movePawnDiagonally(targetColumn, targetRow, b, p, dryRun)
 	}
-	return nil, fmt.Errorf("unknown error")
+	return nil, illegalMove(InvalidPieceMove, "pawns can only move straight or diagonally")
 }
 
 func movePawnStraight(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
-	if p.Type != pawn {
+	if p.Type != Pawn {
 		return nil, fmt.Errorf("movePawnStraight called on piece of type %v", p.Type)
 	}
 	if b.getColumnIndex(p.CurrentSquare.Column) != b.getColumnIndex(targetColumn) &&
 		p.CurrentSquare.Row == targetRow {
-		return nil, fmt.Errorf("pawns cant move sideways")
+		return nil, illegalMove(InvalidPieceMove, "pawns cant move sideways")
 	}
 	if p.Colour == White && targetRow < p.CurrentSquare.Row {
-		return nil, fmt.Errorf("pawns can only move forward")
+		return nil, illegalMove(InvalidPieceMove, "pawns can only move forward")
 	}
 	if p.Colour == Black && targetRow > p.CurrentSquare.Row {
-		return nil, fmt.Errorf("pawns can only move forward")
+		return nil, illegalMove(InvalidPieceMove, "pawns can only move forward")
 	}
 	if p.hasMoved && p.Colour == White && targetRow > (p.CurrentSquare.Row+1) {
-		err := fmt.Errorf("pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
+		err := illegalMove(InvalidPieceMove, "pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
 		return nil, err
 	}
 	if !p.hasMoved && p.Colour == White && targetRow > (p.CurrentSquare.Row+2) {
-		err := fmt.Errorf("pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
+		err := illegalMove(InvalidPieceMove, "pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
 		return nil, err
 	}
 	if p.hasMoved && p.Colour == Black && targetRow < (p.CurrentSquare.Row-1) {
-		err := fmt.Errorf("pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
+		err := illegalMove(InvalidPieceMove, "pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
 		return nil, err
 	}
 	if !p.hasMoved && p.Colour == Black && targetRow < (p.CurrentSquare.Row-2) {
-		err := fmt.Errorf("pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
+		err := illegalMove(InvalidPieceMove, "pawn cant move to square %v%v, can only move two squares forward on first move and then one square", targetColumn, targetRow)
 		return nil, err
 	}
 
@@ -63,7 +59,7 @@ func movePawnStraight(targetColumn string, targetRow int, b *Board, p *Piece, dr
 
 	occupied, byPiece := b.GetPieceAtSquare(targetColumn, targetRow)
 	if occupied {
-		err := fmt.Errorf("square %v %v is occupied by %v", targetColumn, targetRow, byPiece)
+		err := illegalMove(SquareOccupied, "square %v %v is occupied by %v", targetColumn, targetRow, byPiece)
 		return nil, err
 	} else {
 		if !dryRun {
@@ -75,18 +71,18 @@ func movePawnStraight(targetColumn string, targetRow int, b *Board, p *Piece, dr
 }
 
 func movePawnDiagonally(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
-	if p.Type != pawn {
+	if p.Type != Pawn {
 		return nil, fmt.Errorf("movePawnDiagonally called on piece of type %v", p.Type)
 	}
 	if targetRow > (p.CurrentSquare.Row+1) || targetRow < (p.CurrentSquare.Row-1) {
-		err := fmt.Errorf("%v cant move to square %v%v, can only move one square diagonally", p.Type, targetColumn, targetRow)
+		err := illegalMove(InvalidPieceMove, "%v cant move to square %v%v, can only move one square diagonally", p.Type, targetColumn, targetRow)
 		return nil, err
 	}
 	if p.Colour == White && targetRow < p.CurrentSquare.Row {
-		return nil, fmt.Errorf("pawns can only move (and take) forward")
+		return nil, illegalMove(InvalidPieceMove, "pawns can only move (and take) forward")
 	}
 	if p.Colour == Black && targetRow > p.CurrentSquare.Row {
-		return nil, fmt.Errorf("pawns can only move (and take) forward")
+		return nil, illegalMove(InvalidPieceMove, "pawns can only move (and take) forward")
 	}
 	currentColumnValue := b.getColumnValue(p.CurrentSquare.Column)
 	targetColumnValue := b.getColumnValue(targetColumn)
@@ -102,29 +98,29 @@ func movePawnDiagonally(targetColumn string, targetRow int, b *Board, p *Piece,
 
 		} else { // diagonal move, but no enemy piece at target square
 
-			if enemyTaken, err := p.tryEnPassant(b, dryRun); err == nil {
+			if enemyTaken, err := p.tryEnPassant(targetColumn, b, dryRun); err == nil {
 				return &MoveResult{Action: Take, Piece: enemyTaken}, nil
 			} else {
 
-				finalErr := fmt.Errorf("piece %v cant move to %v %v, can only move diagonally when taking (en passant not possible, reason: %v)", p, targetColumn, targetRow, err)
+				finalErr := illegalMove(InvalidPieceMove, "piece %v cant move to %v %v, can only move diagonally when taking (en passant not possible, reason: %v)", p, targetColumn, targetRow, err)
 				return nil, finalErr
 			}
 		}
 	} else {
-		err := fmt.Errorf("pawn can only move 1 step diagonally")
+		err := illegalMove(InvalidPieceMove, "pawn can only move 1 step diagonally")
 		return nil, err
 	}
 }
-func (p *Piece) tryEnPassant(b *Board, dryRun bool) (*Piece, error) {
+func (p *Piece) tryEnPassant(targetColumn string, b *Board, dryRun bool) (*Piece, error) {
 	if p.Colour == White {
-		return tryEnPassantWhite(p, b, dryRun)
+		return tryEnPassantWhite(p, targetColumn, b, dryRun)
 	} else {
-		return tryEnPassantBlack(p, b, dryRun)
+		return tryEnPassantBlack(p, targetColumn, b, dryRun)
 	}
 }
 
-func tryEnPassantWhite(p *Piece, b *Board, dryRun bool) (*Piece, error) {
-	if p.Type != pawn {
+func tryEnPassantWhite(p *Piece, targetColumn string, b *Board, dryRun bool) (*Piece, error) {
+	if p.Type != Pawn {
 		return nil, fmt.Errorf("en passant is only for pawns")
 	}
 
@@ -132,7 +128,11 @@ func tryEnPassantWhite(p *Piece, b *Board, dryRun bool) (*Piece, error) {
 		return nil, fmt.Errorf("black has not moved")
 	}
 	// if oppents last move was pawn two squares forward..
-	if b.blacksLastMove.Piece.Type == pawn && b.blacksLastMove.Move.From.Row == 7 && b.blacksLastMove.Move.To.Row == 5 {
+	if b.blacksLastMove.Piece.Type == Pawn && b.blacksLastMove.Move.From.Row == 7 && b.blacksLastMove.Move.To.Row == 5 {
+		// ...and we are moving behind it..
+		if b.blacksLastMove.Move.To.Column != targetColumn {
+			return &Piece{}, fmt.Errorf("oppents pawn is not on column %v", targetColumn)
+		}
 		// ...and we are next to the square it moved to..
 		columnIndexDiff := b.getColumnIndex(p.CurrentSquare.Column) - b.getColumnIndex(b.blacksLastMove.Move.To.Column)
 		if columnIndexDiff < 0 {
@@ -146,6 +146,7 @@ func tryEnPassantWhite(p *Piece, b *Board, dryRun bool) (*Piece, error) {
 					return nil, fmt.Errorf("error: enemy not found, expected enemy pawn at %v%v", b.whitesLastMove.Move.To.Column, b.whitesLastMove.Move.To.Row)
 				}
 				enemy.InPlay = false
+				b.afterCapture(p, enemy)
 
 			}
 			return b.blacksLastMove.Piece, nil
@@ -155,8 +156,8 @@ func tryEnPassantWhite(p *Piece, b *Board, dryRun bool) (*Piece, error) {
 		return &Piece{}, fmt.Errorf("oppents last move was not pawn two squares forward")
 	}
 }
-func tryEnPassantBlack(p *Piece, b *Board, dryRun bool) (*Piece, error) {
-	if p.Type != pawn {
+func tryEnPassantBlack(p *Piece, targetColumn string, b *Board, dryRun bool) (*Piece, error) {
+	if p.Type != Pawn {
 		return nil, fmt.Errorf("en passant is only for pawns")
 	}
 
@@ -164,7 +165,11 @@ func tryEnPassantBlack(p *Piece, b *Board, dryRun bool) (*Piece, error) {
 		return nil, fmt.Errorf("white has not moved")
 	}
 	// if oppents last move was pawn two squares forward..
-	if b.whitesLastMove.Piece.Type == pawn && b.whitesLastMove.Move.From.Row == 2 && b.whitesLastMove.Move.To.Row == 4 {
+	if b.whitesLastMove.Piece.Type == Pawn && b.whitesLastMove.Move.From.Row == 2 && b.whitesLastMove.Move.To.Row == 4 {
+		// ...and we are moving behind it..
+		if b.whitesLastMove.Move.To.Column != targetColumn {
+			return &Piece{}, fmt.Errorf("oppents pawn is not on column %v", targetColumn)
+		}
 		// ...and we are next to the square it moved to..
 		columnIndexDiff := b.getColumnIndex(p.CurrentSquare.Column) - b.getColumnIndex(b.whitesLastMove.Move.To.Column)
 		if columnIndexDiff < 0 {
@@ -178,6 +183,7 @@ func tryEnPassantBlack(p *Piece, b *Board, dryRun bool) (*Piece, error) {
 					return nil, fmt.Errorf("error: enemy not found, expected enemy pawn at %v%v", b.whitesLastMove.Move.To.Column, b.whitesLastMove.Move.To.Row)
 				}
 				enemy.InPlay = false
+				b.afterCapture(p, enemy)
 
 			}
 			return b.whitesLastMove.Piece, nil
@@ -188,15 +194,17 @@ func tryEnPassantBlack(p *Piece, b *Board, dryRun bool) (*Piece, error) {
 	}
 }
 func (p *Piece) tryPromoteToQueen() error {
-	if p.Type != pawn {
+	if p.Type != Pawn {
 		return fmt.Errorf("can only promote pawns")
 	}
 	if p.Colour == White && p.CurrentSquare.Row == 8 {
-		p.Type = queen
+		p.Type = Queen
+		p.promoted = true
 		return nil
 	}
 	if p.Colour == Black && p.CurrentSquare.Row == 1 {
-		p.Type = queen
+		p.Type = Queen
+		p.promoted = true
 		return nil
 	}
 	return fmt.Errorf("pawn cant be promoted")
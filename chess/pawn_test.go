@@ -7,7 +7,7 @@ import (
 
 func TestMovePawn_should_be_able_to_move_A2_A3(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	_, a2Pawn := board.GetPieceAtSquare("A", 2)
 	_, err := a2Pawn.Move("A", 3, board, false)
 	if err != nil {
@@ -36,7 +36,7 @@ func TestMovePawn_should_be_able_to_move_A2_A3(t *testing.T) {
 
 func TestMovePawn_cant_take_backwards(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	// CREATE START SCENARIO (white pawn cant take black pawn backwards)
 	// bR  bN  bB  bQ  bK  bB  bN  bR
 	// bp  bp  bp  bp  bp  bp  bp  bp
@@ -47,11 +47,11 @@ func TestMovePawn_cant_take_backwards(t *testing.T) {
 	// wp  wp  wp  wp  wp  wp  wp  wp
 	// wR  wN  wB  wQ  wK  wB  wN  wR
 
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "D", Row: 4}, Square{Column: "D", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "D", Row: 4}, To: Square{Column: "D", Row: 5}}
 
-	blackMove1 := Move{Square{Column: "E", Row: 7}, Square{Column: "E", Row: 5}}
-	blackMove2 := Move{Square{Column: "E", Row: 5}, Square{Column: "E", Row: 4}}
+	blackMove1 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "E", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "E", Row: 5}, To: Square{Column: "E", Row: 4}}
 	moves := []Move{whiteMove1, blackMove1, whiteMove2, blackMove2}
 	scenarioPrepError := prepScenario(moves, board)
 
@@ -82,7 +82,7 @@ func TestMovePawn_cant_take_backwards(t *testing.T) {
 }
 func TestMovePawn_should_NOT_be_able_to_move_A2_A7(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	_, a2Pawn := board.GetPieceAtSquare("A", 2)
 	_, err := a2Pawn.Move("A", 7, board, false)
 	if err == nil {
@@ -98,8 +98,8 @@ func TestMovePawn_should_NOT_be_able_to_move_A2_A7(t *testing.T) {
 }
 func TestMovePawn_should_NOT_able_to_move_diagonally_if_not_when_taking(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
-	board.blacksLastMove = LastMove{Piece: &Piece{Type: king, Colour: Black, CurrentSquare: Square{Column: "D", Row: 8}},
+	board := NewBoard()
+	board.blacksLastMove = LastMove{Piece: &Piece{Type: King, Colour: Black, CurrentSquare: Square{Column: "D", Row: 8}},
 		Move: &Move{From: Square{Column: "E", Row: 8}, To: Square{Column: "D", Row: 8}}}
 
 	_, a2Pawn := board.GetPieceAtSquare("A", 2)
@@ -109,7 +109,7 @@ func TestMovePawn_should_NOT_able_to_move_diagonally_if_not_when_taking(t *testi
 	}
 	if err != nil {
 
-		expectedErrorMessage := "piece &{Pawn {A 2} White true false} cant move to B 3, can only move diagonally when taking"
+		expectedErrorMessage := "piece &{Pawn {A 2} White true false false} cant move to B 3, can only move diagonally when taking"
 		if !strings.Contains(err.Error(), expectedErrorMessage) {
 			t.Errorf("Expected error message to contain %v, got %v", expectedErrorMessage, err.Error())
 		}
@@ -118,11 +118,11 @@ func TestMovePawn_should_NOT_able_to_move_diagonally_if_not_when_taking(t *testi
 
 func TestMovePawn_white_can_do_en_passant(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 4}, Square{Column: "E", Row: 5}}
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 4}, To: Square{Column: "E", Row: 5}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2, blackMove1}
 	scenarioPrepError := prepScenario(moves, board)
 
@@ -168,12 +168,12 @@ func TestMovePawn_white_can_do_en_passant(t *testing.T) {
 }
 func TestMovePawn_black_can_do_en_passant(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 3}}
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "D", Row: 5}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 3}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "D", Row: 5}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
 
 	moves := []Move{whiteMove1, blackMove1, blackMove2, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
@@ -220,11 +220,11 @@ func TestMovePawn_black_can_do_en_passant(t *testing.T) {
 }
 func TestMovePawn_white_can_do_en_passant_to_the_right(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "D", Row: 4}, Square{Column: "D", Row: 5}}
-	blackMove1 := Move{Square{Column: "E", Row: 7}, Square{Column: "E", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "D", Row: 4}, To: Square{Column: "D", Row: 5}}
+	blackMove1 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "E", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2, blackMove1}
 	scenarioPrepError := prepScenario(moves, board)
 
@@ -270,12 +270,12 @@ func TestMovePawn_white_can_do_en_passant_to_the_right(t *testing.T) {
 }
 func TestMovePawn_black_can_do_en_passant_to_the_left(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 3}}
-	blackMove1 := Move{Square{Column: "E", Row: 7}, Square{Column: "E", Row: 5}}
-	blackMove2 := Move{Square{Column: "E", Row: 5}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "D", Row: 2}, Square{Column: "D", Row: 4}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 3}}
+	blackMove1 := Move{From: Square{Column: "E", Row: 7}, To: Square{Column: "E", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "E", Row: 5}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "D", Row: 2}, To: Square{Column: "D", Row: 4}}
 
 	moves := []Move{whiteMove1, blackMove1, blackMove2, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
@@ -323,12 +323,12 @@ func TestMovePawn_black_can_do_en_passant_to_the_left(t *testing.T) {
 
 func TestMovePawn_white_cant_do_en_passant_if_pawn_not_in_position(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 4}, Square{Column: "E", Row: 5}}
-	whiteMove3 := Move{Square{Column: "E", Row: 5}, Square{Column: "E", Row: 6}}
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 4}, To: Square{Column: "E", Row: 5}}
+	whiteMove3 := Move{From: Square{Column: "E", Row: 5}, To: Square{Column: "E", Row: 6}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3, blackMove1}
 	scenarioPrepError := prepScenario(moves, board)
 
@@ -377,13 +377,13 @@ func TestMovePawn_white_cant_do_en_passant_if_pawn_not_in_position(t *testing.T)
 }
 func TestMovePawn_black_cant_do_en_passant_if_pawn_not_in_position(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 3}}
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "D", Row: 5}, Square{Column: "D", Row: 4}}
-	blackMove3 := Move{Square{Column: "D", Row: 4}, Square{Column: "D", Row: 3}}
-	whiteMove2 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 3}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "D", Row: 5}, To: Square{Column: "D", Row: 4}}
+	blackMove3 := Move{From: Square{Column: "D", Row: 4}, To: Square{Column: "D", Row: 3}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
 
 	moves := []Move{whiteMove1, blackMove1, blackMove2, blackMove3, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
@@ -433,12 +433,12 @@ func TestMovePawn_black_cant_do_en_passant_if_pawn_not_in_position(t *testing.T)
 }
 func TestMovePawn_white_cant_do_en_passant_if_black_last_move_not_pawn_2_squares(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 4}, Square{Column: "E", Row: 5}}
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "A", Row: 7}, Square{Column: "A", Row: 6}} // last move not pawn 2 squares
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 4}, To: Square{Column: "E", Row: 5}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "A", Row: 7}, To: Square{Column: "A", Row: 6}} // last move not pawn 2 squares
 	moves := []Move{whiteMove1, whiteMove2, blackMove1, blackMove2}
 	scenarioPrepError := prepScenario(moves, board)
 
@@ -487,13 +487,13 @@ func TestMovePawn_white_cant_do_en_passant_if_black_last_move_not_pawn_2_squares
 }
 func TestMovePawn_black_cant_do_en_passant_if_white_lastmove_not_pawn_2_squares(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 3}}
-	blackMove1 := Move{Square{Column: "D", Row: 7}, Square{Column: "D", Row: 5}}
-	blackMove2 := Move{Square{Column: "D", Row: 5}, Square{Column: "D", Row: 4}}
-	whiteMove2 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
-	whiteMove3 := Move{Square{Column: "H", Row: 2}, Square{Column: "H", Row: 3}} // last move not pawn 2 squares
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 3}}
+	blackMove1 := Move{From: Square{Column: "D", Row: 7}, To: Square{Column: "D", Row: 5}}
+	blackMove2 := Move{From: Square{Column: "D", Row: 5}, To: Square{Column: "D", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
+	whiteMove3 := Move{From: Square{Column: "H", Row: 2}, To: Square{Column: "H", Row: 3}} // last move not pawn 2 squares
 
 	moves := []Move{whiteMove1, blackMove1, blackMove2, whiteMove2, whiteMove3}
 	scenarioPrepError := prepScenario(moves, board)
@@ -543,7 +543,7 @@ func TestMovePawn_black_cant_do_en_passant_if_white_lastmove_not_pawn_2_squares(
 }
 func TestMovePawn_Black_cant_move_backwards(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (black pawn cant move backwards)
 	// bR  bN  bB  bQ  bK  bB  bN  bR
@@ -556,7 +556,7 @@ func TestMovePawn_Black_cant_move_backwards(t *testing.T) {
 	// wR  wN  wB  wQ  wK  wB  wN  wR
 
 	// black pawn from A7 to A6
-	blackMove := Move{Square{Column: "A", Row: 7}, Square{Column: "A", Row: 6}}
+	blackMove := Move{From: Square{Column: "A", Row: 7}, To: Square{Column: "A", Row: 6}}
 	moves := []Move{blackMove}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -577,7 +577,7 @@ func TestMovePawn_Black_cant_move_backwards(t *testing.T) {
 
 func TestMovePawn_White_cant_move_backwards(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (white pawn cant move backwards)
 	// bR  bN  bB  bQ  bK  bB  bN  bR
@@ -588,7 +588,7 @@ func TestMovePawn_White_cant_move_backwards(t *testing.T) {
 	// wp  ..  ..  ..  ..  ..  ..  ..
 	// ..  wp  wp  wp  wp  wp  wp  wp
 	// wR  wN  wB  wQ  wK  wB  wN  wR
-	whiteMove := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 3}}
+	whiteMove := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 3}}
 	moves := []Move{whiteMove}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -607,7 +607,7 @@ func TestMovePawn_White_cant_move_backwards(t *testing.T) {
 }
 func TestMovePawn_should_only_be_able_to_move_two_squares_first_move(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	_, a2Pawn := board.GetPieceAtSquare("A", 2)
 	_, err := a2Pawn.Move("A", 4, board, false)
 	if err != nil {
@@ -625,7 +625,7 @@ func TestMovePawn_should_only_be_able_to_move_two_squares_first_move(t *testing.
 }
 func TestMovePawn_should_be_illegal_if_king_is_in_check_and_it_doesnt_remove_check(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (Black King has to take, no other move is legal)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -636,10 +636,10 @@ func TestMovePawn_should_be_illegal_if_king_is_in_check_and_it_doesnt_remove_che
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// ..  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 1}, Square{Column: "A", Row: 3}}
-	whiteMove3 := Move{Square{Column: "A", Row: 3}, Square{Column: "E", Row: 3}}
-	whiteMove4 := Move{Square{Column: "E", Row: 3}, Square{Column: "E", Row: 7}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 1}, To: Square{Column: "A", Row: 3}}
+	whiteMove3 := Move{From: Square{Column: "A", Row: 3}, To: Square{Column: "E", Row: 3}}
+	whiteMove4 := Move{From: Square{Column: "E", Row: 3}, To: Square{Column: "E", Row: 7}}
 	moves := []Move{whiteMove1, whiteMove2, whiteMove3, whiteMove4}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -659,7 +659,7 @@ func TestMovePawn_should_be_illegal_if_king_is_in_check_and_it_doesnt_remove_che
 
 func TestGetValidPawnMoves(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (white pawn takes black pawn)
 	// bR  bN  bB  bQ  bK  bB  bN  bR
@@ -672,9 +672,9 @@ func TestGetValidPawnMoves(t *testing.T) {
 	// wR  wN  wB  wQ  wK  wB  wN  wR
 
 	// wp from A2 to A4
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
 	// then bp from B7 to B5
-	blackMove1 := Move{Square{Column: "B", Row: 7}, Square{Column: "B", Row: 5}}
+	blackMove1 := Move{From: Square{Column: "B", Row: 7}, To: Square{Column: "B", Row: 5}}
 	moves := []Move{whiteMove1, blackMove1}
 	scenarioPrepError := prepScenario(moves, board)
 
@@ -0,0 +1,116 @@
+package chess
+
+// returns a deep copy of the board, moves made on the copy do not affect the original
+func (b *Board) Clone() *Board {
+	clone := *b
+	clone.WhitePieces = append([]Piece(nil), b.WhitePieces...)
+	clone.BlackPieces = append([]Piece(nil), b.BlackPieces...)
+	clone.whitesLastMove = clone.cloneLastMove(b.whitesLastMove, b)
+	clone.blacksLastMove = clone.cloneLastMove(b.blacksLastMove, b)
+	return &clone
+}
+
+// returns the last move with the piece pointing into the clones pieces instead of the originals
+func (clone *Board) cloneLastMove(lastMove LastMove, original *Board) LastMove {
+	if lastMove.Piece == nil || lastMove.Move == nil {
+		return LastMove{}
+	}
+	move := *lastMove.Move
+	for i := range original.WhitePieces {
+		if &original.WhitePieces[i] == lastMove.Piece {
+			return LastMove{Piece: &clone.WhitePieces[i], Move: &move}
+		}
+	}
+	for i := range original.BlackPieces {
+		if &original.BlackPieces[i] == lastMove.Piece {
+			return LastMove{Piece: &clone.BlackPieces[i], Move: &move}
+		}
+	}
+	return LastMove{}
+}
+
+// returns all legal moves for a player/colour, taking the rules of the variant into account
+func (b *Board) LegalMovesFor(colour Colour) map[Move]*MoveResult {
+	moves := map[Move]*MoveResult{}
+	for move, result := range b.GetAllMovesFor(colour) {
+		if move.Kind == Drop {
+			if _, err := b.Drop(colour, move.Piece, move.To.Column, move.To.Row, true); err == nil {
+				moves[move] = result
+			}
+			continue
+		}
+		found, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row)
+		if !found || piece.Colour != colour {
+			continue
+		}
+		if !piece.MoveIsLegal(move.To.Column, move.To.Row, b) {
+			continue
+		}
+		if err := b.getVariant().AllowMove(b, piece, move); err != nil {
+			continue
+		}
+		moves[move] = result
+	}
+	return moves
+}
+
+// makes the move (or drop for colour) and applies the after move effects of the variant
+func (b *Board) makeMove(move Move, colour Colour) (*MoveResult, error) {
+	if move.Kind == Drop {
+		result, err := b.Drop(colour, move.Piece, move.To.Column, move.To.Row, false)
+		if err != nil {
+			return nil, err
+		}
+		_, p := b.GetPieceAtSquare(move.To.Column, move.To.Row)
+		b.getVariant().AfterMove(b, p, move, result)
+		return result, nil
+	}
+	found, p := b.GetPieceAtSquare(move.From.Column, move.From.Row)
+	if !found {
+		return nil, illegalMove(NoPiece, "no piece at %v%v", move.From.Column, move.From.Row)
+	}
+	result, err := p.Move(move.To.Column, move.To.Row, b, false)
+	if err != nil {
+		return nil, err
+	}
+	b.getVariant().AfterMove(b, p, move, result)
+	return result, nil
+}
+
+// counts the number of move sequences of the given length (in plies) starting with colour to move. Comparing the
+// counts with known numbers for test positions is the standard way to verify move generation. Pawns only promote
+// to queens, so positions with promotions within the depth do not match the published numbers
+func Perft(b *Board, colour Colour, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := b.LegalMovesFor(colour)
+	if depth == 1 {
+		return len(moves)
+	}
+	nodes := 0
+	for move := range moves {
+		clone := b.Clone()
+		if _, err := clone.makeMove(move, colour); err != nil {
+			continue
+		}
+		nodes += Perft(clone, opponentOf(colour), depth-1)
+	}
+	return nodes
+}
+
+// returns the perft count below each legal move, which helps to find the move where the generation goes wrong
+func PerftDivide(b *Board, colour Colour, depth int) map[Move]int {
+	counts := map[Move]int{}
+	if depth < 1 {
+		return counts
+	}
+	for move := range b.LegalMovesFor(colour) {
+		clone := b.Clone()
+		if _, err := clone.makeMove(move, colour); err != nil {
+			continue
+		}
+		counts[move] = Perft(clone, opponentOf(colour), depth-1)
+	}
+	return counts
+}
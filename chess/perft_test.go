@@ -0,0 +1,51 @@
+package chess
+
+import (
+	"testing"
+)
+
+// known perft numbers from https://www.chessprogramming.org/Perft_Results
+func TestPerft(t *testing.T) {
+	defer quiet()()
+	cases := []struct {
+		name     string
+		fen      string
+		expected []int // by depth, starting at depth 1
+	}{
+		{"starting position", StartingPositionFEN, []int{20, 400, 8902}},
+		{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []int{48, 2039}},
+		{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{14, 191, 2812}},
+		{"chess960", "bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9", []int{21, 528}},
+	}
+	for _, c := range cases {
+		position, err := ParseFEN(c.fen)
+		if err != nil {
+			t.Errorf("Failed to parse FEN, %v", err)
+			continue
+		}
+		for i, expected := range c.expected {
+			if nodes := Perft(position.Board, position.NextToMove, i+1); nodes != expected {
+				t.Errorf("Expected perft(%v) of %v to be %v, got %v", i+1, c.name, expected, nodes)
+			}
+		}
+	}
+}
+
+func TestClone_moves_do_not_affect_the_original(t *testing.T) {
+	defer quiet()()
+	board := NewBoard()
+	if err := prepScenario([]Move{{From: Square{"E", 2}, To: Square{"E", 4}}}, board); err != nil {
+		t.Errorf("Failed to prep the board, %v", err)
+		return
+	}
+	clone := board.Clone()
+	if _, err := clone.makeMove(Move{From: Square{"E", 4}, To: Square{"E", 5}}, White); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if found, _ := board.GetPieceAtSquare("E", 4); !found {
+		t.Errorf("Expected the pawn to still be on E4 on the original board")
+	}
+	if clone.whitesLastMove.Piece == board.whitesLastMove.Piece {
+		t.Errorf("Expected the last move of the clone to point to its own piece")
+	}
+}
@@ -0,0 +1,282 @@
+package chess
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	pgnTagPattern        = regexp.MustCompile(`^\[\s*(\w+)\s+"((?:[^"\\]|\\.)*)"\s*\]$`)
+	pgnMoveNumberPattern = regexp.MustCompile(`^\d+\.+`)
+)
+
+// a game read from PGN, the moves are in standard algebraic notation (SAN)
+type PGNGame struct {
+	Tags   map[string]string // e.g. White, Black, Event and FEN for games that do not start in the starting position
+	Moves  []string
+	Result string // "1-0", "0-1", "1/2-1/2" or "*"
+}
+
+// reads all games from PGN, comments, variations and annotations are skipped
+func ParsePGN(r io.Reader) ([]PGNGame, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	games := []PGNGame{}
+	game := PGNGame{Tags: map[string]string{}}
+	inMoves := false
+	finishGame := func(result string) {
+		game.Result = result
+		games = append(games, game)
+		game, inMoves = PGNGame{Tags: map[string]string{}}, false
+	}
+
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '%' && (i == 0 || text[i-1] == '\n'): // escaped line
+			i = skipPast(text, i, "\n")
+		case c == ';': // comment to the end of the line
+			i = skipPast(text, i, "\n")
+		case c == '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid PGN, comment is not closed")
+			}
+			i += end + 1
+		case c == '(':
+			end, err := skipVariation(text, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case c == '[':
+			end := strings.IndexByte(text[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid PGN, tag is not closed")
+			}
+			if inMoves {
+				finishGame("*") // a game without a result
+			}
+			match := pgnTagPattern.FindStringSubmatch(text[i : i+end+1])
+			if match == nil {
+				return nil, fmt.Errorf("invalid PGN tag %q", text[i:i+end+1])
+			}
+			game.Tags[match[1]] = strings.ReplaceAll(strings.ReplaceAll(match[2], `\"`, `"`), `\\`, `\`)
+			i += end + 1
+		default:
+			end := i
+			for end < len(text) && !strings.ContainsRune(" \t\r\n{}()[];", rune(text[end])) {
+				end++
+			}
+			token := text[i:end]
+			i = end
+			switch token {
+			case "1-0", "0-1", "1/2-1/2", "*":
+				finishGame(token)
+				continue
+			}
+			inMoves = true
+			token = pgnMoveNumberPattern.ReplaceAllString(token, "")
+			if token != "" && !strings.HasPrefix(token, "$") { // $1 and friends are annotations
+				game.Moves = append(game.Moves, token)
+			}
+		}
+	}
+	if inMoves || len(game.Tags) > 0 {
+		finishGame("*")
+	}
+	return games, nil
+}
+
+// returns the index after the next occurrence of end, or the length of the text
+func skipPast(text string, i int, end string) int {
+	if j := strings.Index(text[i:], end); j >= 0 {
+		return i + j + len(end)
+	}
+	return len(text)
+}
+
+// returns the index after the variation starting at i, variations can be nested and contain comments
+func skipVariation(text string, i int) (int, error) {
+	depth := 0
+	for ; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end == -1 {
+				return 0, fmt.Errorf("invalid PGN, comment is not closed")
+			}
+			i += end
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid PGN, variation is not closed")
+}
+
+// returns the variant named by a PGN Variant tag and whether it is Chess960, which is standard chess from a
+// shuffled starting position. An empty name is standard chess
+func pgnVariant(name string) (Variant, bool, error) {
+	switch {
+	case name == "":
+		return Standard{}, false, nil
+	case strings.EqualFold(name, "Chess960"):
+		return Standard{}, true, nil
+	}
+	for _, variant := range Variants() {
+		if strings.EqualFold(variant.Name(), name) {
+			return variant, false, nil
+		}
+	}
+	return nil, false, fmt.Errorf("unknown variant %q", name)
+}
+
+// creates a game from PGN, starting from the FEN tag if there is one, and plays its moves. A Variant tag selects
+// Chess960 or one of Variants(), other variants are rejected
+func NewGameFromPGN(white Player, black Player, stateVisualizer BoardVisualizer, pgn PGNGame) (*Game, error) {
+	variant, chess960, err := pgnVariant(pgn.Tags["Variant"])
+	if err != nil {
+		return nil, err
+	}
+	var game *Game
+	fen, hasFEN := pgn.Tags["FEN"]
+	switch {
+	case hasFEN:
+		game, err = NewVariantGameFromFEN(white, black, stateVisualizer, variant, fen)
+	case chess960:
+		game, err = NewChess960Game(white, black, stateVisualizer, 518) // the standard setup
+	default:
+		game = NewVariantGame(white, black, stateVisualizer, variant)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if chess960 && !game.Board.IsChess960() {
+		// the FEN does not tell when the king and rooks are on their standard squares
+		game.Board.chess960 = true
+		game.setStart()
+	}
+	for i, san := range pgn.Moves {
+		move, err := game.Board.ParseSAN(game.NextToMove, san)
+		if err != nil {
+			return nil, fmt.Errorf("move %v: %w", i+1, err)
+		}
+		if _, err := game.move(move, game.NextToMove); err != nil {
+			return nil, fmt.Errorf("move %v %q: %w", i+1, san, err)
+		}
+	}
+	return game, nil
+}
+
+// the tags every PGN game has, in the order they are written
+var pgnSevenTagRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+// writes the game as PGN with the moves in SAN. The seven tag roster comes first ("?" when a tag is missing),
+// followed by the other tags in alphabetical order. Result, Variant, SetUp and FEN are taken from the game, ECO and
+// Opening from its classification unless one of them is given
+func WritePGN(w io.Writer, g *Game, tags map[string]string) error {
+	all := map[string]string{}
+	for name, value := range tags {
+		all[name] = value
+	}
+	all["Result"] = g.Result().PGN()
+	startFEN := g.StartFEN()
+	switch {
+	case g.Board.IsChess960():
+		all["Variant"] = "Chess960"
+	case g.Variant().Name() != (Standard{}).Name():
+		all["Variant"] = g.Variant().Name()
+	}
+	if startFEN != NewVariantGame(nil, nil, nil, g.Variant()).FEN() {
+		all["SetUp"], all["FEN"] = "1", startFEN
+	}
+	_, hasECO := all["ECO"]
+	_, hasOpening := all["Opening"]
+	if opening, ok := g.Opening(); ok && !hasECO && !hasOpening {
+		all["ECO"], all["Opening"] = opening.ECO, opening.Name
+	}
+
+	var text strings.Builder
+	roster := map[string]bool{}
+	for _, name := range pgnSevenTagRoster {
+		roster[name] = true
+		if _, ok := all[name]; !ok {
+			all[name] = "?"
+		}
+	}
+	names := []string{}
+	for name := range all {
+		if !roster[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range append(append([]string{}, pgnSevenTagRoster...), names...) {
+		value := strings.ReplaceAll(strings.ReplaceAll(all[name], `\`, `\\`), `"`, `\"`)
+		fmt.Fprintf(&text, "[%v \"%v\"]\n", name, value)
+	}
+	text.WriteString("\n")
+
+	movetext, err := g.movetext(startFEN)
+	if err != nil {
+		return err
+	}
+	// lines are wrapped before 80 characters
+	line := ""
+	for _, token := range append(movetext, all["Result"]) {
+		if line != "" && len(line)+1+len(token) > 79 {
+			text.WriteString(line + "\n")
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += token
+	}
+	text.WriteString(line + "\n\n")
+	_, err = io.WriteString(w, text.String())
+	return err
+}
+
+// returns the moves of the game in SAN with move numbers, e.g. "1.", "e4", "e5", or "12...", "Nf6" when black moves first
+func (g *Game) movetext(startFEN string) ([]string, error) {
+	position, err := ParseFEN(startFEN)
+	if err != nil {
+		return nil, err
+	}
+	board, colour, number := g.start.Clone(), g.startNextToMove, position.FullmoveNumber
+	tokens := []string{}
+	for i, move := range g.History {
+		san, err := board.MoveToSAN(colour, move)
+		if err != nil {
+			return nil, fmt.Errorf("move %v: %w", i+1, err)
+		}
+		switch {
+		case colour == White:
+			tokens = append(tokens, fmt.Sprintf("%v.", number))
+		case i == 0:
+			tokens = append(tokens, fmt.Sprintf("%v...", number))
+		}
+		tokens = append(tokens, san)
+		if _, err := board.makeMove(move, colour); err != nil {
+			return nil, fmt.Errorf("move %v: %w", i+1, err)
+		}
+		if colour == Black {
+			number++
+		}
+		colour = opponentOf(colour)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,279 @@
+package chess
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const operaGamePGN = `[Event "Paris"]
+[Site "Paris FRA"]
+[Date "1858.??.??"]
+[White "Paul Morphy"]
+[Black "Duke Karl / Count Isouard"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 d6 3. d4 Bg4 {This is a weak move already.} 4. dxe5 Bxf3 5. Qxf3 dxe5
+6. Bc4 Nf6 7. Qb3 Qe7 8. Nc3 c6 9. Bg5 (9. Qxb7 Qb4+) b5 10. Nxb5 cxb5 11. Bxb5+ Nbd7
+12. O-O-O Rd8 13. Rxd7 Rxd7 14. Rd1 Qe6 15. Bxd7+ Nxd7 16. Qb8+ $1 Nxb8 17. Rd8# 1-0
+`
+
+func TestParsePGN(t *testing.T) {
+	games, err := ParsePGN(strings.NewReader(operaGamePGN + "\n" + `[White "A"]
+[Black "B"]
+[FEN "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1"]
+
+1.e4 ; a comment
+Kd7 *`))
+	if err != nil {
+		t.Fatalf("Failed to parse PGN, %v", err)
+	}
+	if len(games) != 2 {
+		t.Fatalf("Expected 2 games, got %v", len(games))
+	}
+	if games[0].Tags["White"] != "Paul Morphy" || games[0].Result != "1-0" || len(games[0].Moves) != 33 {
+		t.Errorf("Expected the opera game with 33 moves, got %+v", games[0])
+	}
+	if games[0].Moves[5] != "Bg4" || games[0].Moves[16] != "Bg5" || games[0].Moves[17] != "b5" {
+		t.Errorf("Expected comments and variations to be skipped, got %v", games[0].Moves)
+	}
+	if games[1].Tags["FEN"] == "" || games[1].Result != "*" || strings.Join(games[1].Moves, " ") != "e4 Kd7" {
+		t.Errorf("Expected the second game to start from a FEN, got %+v", games[1])
+	}
+}
+
+func TestNewGameFromPGN_plays_the_moves(t *testing.T) {
+	defer quiet()()
+	games, err := ParsePGN(strings.NewReader(operaGamePGN))
+	if err != nil {
+		t.Fatalf("Failed to parse PGN, %v", err)
+	}
+	game, err := NewGameFromPGN(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, games[0])
+	if err != nil {
+		t.Fatalf("Failed to play the game, %v", err)
+	}
+	if fen := game.FEN(); fen != "1n1Rkb1r/p4ppp/4q3/4p1B1/4P3/8/PPP2PPP/2K5 b k - 1 17" {
+		t.Errorf("Unexpected final position %q", fen)
+	}
+	if !game.Board.kingIsInMate(Black) {
+		t.Errorf("Expected Black to be mated")
+	}
+}
+
+func TestParseSAN(t *testing.T) {
+	defer quiet()()
+	position, err := ParseFEN("r3k2r/8/8/2Pp4/8/5N1N/8/R3K2R w KQkq d6 0 1")
+	if err != nil {
+		t.Fatalf("Failed to parse FEN, %v", err)
+	}
+	tests := []struct {
+		san  string
+		want Move
+	}{
+		{"O-O", Move{From: Square{"E", 1}, To: Square{"G", 1}}},
+		{"O-O-O+", Move{From: Square{"E", 1}, To: Square{"C", 1}}},
+		{"cxd6", Move{From: Square{"C", 5}, To: Square{"D", 6}}},
+		{"Nfg5", Move{From: Square{"F", 3}, To: Square{"G", 5}}},
+		{"Rxa8+", Move{From: Square{"A", 1}, To: Square{"A", 8}}},
+		{"Kd2", Move{From: Square{"E", 1}, To: Square{"D", 2}}},
+	}
+	for _, test := range tests {
+		if move, err := position.Board.ParseSAN(White, test.san); err != nil || move != test.want {
+			t.Errorf("Expected %q to be %v, got %v (%v)", test.san, test.want, move, err)
+		}
+	}
+	for _, san := range []string{"Ng5", "Ke3", "c6=N", "e4", "xyz"} {
+		if _, err := position.Board.ParseSAN(White, san); err == nil {
+			t.Errorf("Expected %q to be rejected", san)
+		}
+	}
+}
+
+func TestNewGameFromPGN_reports_the_illegal_move(t *testing.T) {
+	defer quiet()()
+	_, err := NewGameFromPGN(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, PGNGame{Moves: []string{"e4", "e5", "Ke3"}})
+	if err == nil || !strings.Contains(err.Error(), "move 3") {
+		t.Errorf("Expected the third move to be rejected, got %v", err)
+	}
+	if errors.Is(err, ErrNoPiece) {
+		t.Errorf("Expected a SAN error, got %v", err)
+	}
+}
+
+func TestPerftDivide_adds_up_to_perft(t *testing.T) {
+	board := NewBoard()
+	total := 0
+	for _, count := range PerftDivide(board, White, 2) {
+		total += count
+	}
+	if total != 400 {
+		t.Errorf("Expected the divided counts to add up to 400, got %v", total)
+	}
+}
+
+func TestMoveToSAN_round_trips_the_opera_game(t *testing.T) {
+	defer quiet()()
+	games, err := ParsePGN(strings.NewReader(operaGamePGN))
+	if err != nil {
+		t.Fatalf("Failed to parse PGN, %v", err)
+	}
+	board, colour := NewBoard(), White
+	for _, san := range games[0].Moves {
+		move, err := board.ParseSAN(colour, san)
+		if err != nil {
+			t.Fatalf("Failed to parse %q, %v", san, err)
+		}
+		written, err := board.MoveToSAN(colour, move)
+		if err != nil || written != san {
+			t.Errorf("Expected %q, got %q (%v)", san, written, err)
+		}
+		if _, err := board.makeMove(move, colour); err != nil {
+			t.Fatalf("Failed to make %q, %v", san, err)
+		}
+		colour = opponentOf(colour)
+	}
+}
+
+func TestMoveToSAN_disambiguation_en_passant_and_promotion(t *testing.T) {
+	position, err := ParseFEN("4k3/1P6/8/2Pp4/8/5N1N/8/R3K2R w KQ d6 0 1")
+	if err != nil {
+		t.Fatalf("Failed to parse FEN, %v", err)
+	}
+	tests := map[Move]string{
+		{From: Square{"F", 3}, To: Square{"G", 5}}: "Nfg5",
+		{From: Square{"C", 5}, To: Square{"D", 6}}: "cxd6",
+		{From: Square{"B", 7}, To: Square{"B", 8}}: "b8=Q+",
+		{From: Square{"E", 1}, To: Square{"G", 1}}: "O-O",
+		{From: Square{"A", 1}, To: Square{"A", 8}}: "Ra8+",
+		{From: Square{"H", 1}, To: Square{"F", 1}}: "Rf1",
+	}
+	for move, want := range tests {
+		if got, err := position.Board.MoveToSAN(White, move); err != nil || got != want {
+			t.Errorf("Expected %v to be %q, got %q (%v)", move, want, got, err)
+		}
+	}
+	if _, err := position.Board.MoveToSAN(White, Move{From: Square{"E", 1}, To: Square{"E", 3}}); err == nil {
+		t.Errorf("Expected an illegal move to be rejected")
+	}
+}
+
+func TestWritePGN_from_a_position_with_black_to_move(t *testing.T) {
+	defer quiet()()
+	fen := "4k3/8/8/8/8/8/4P3/4K3 b - - 0 12"
+	game, err := NewGameFromFEN(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, fen)
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	if err := playMoves(game, []Move{
+		{From: Square{"E", 8}, To: Square{"D", 7}},
+		{From: Square{"E", 2}, To: Square{"E", 4}},
+	}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	var text strings.Builder
+	if err := WritePGN(&text, game, map[string]string{"White": `A "quoted" name`, "Annotator": "test"}); err != nil {
+		t.Fatalf("Failed to write PGN, %v", err)
+	}
+	expected := `[Event "?"]
+[Site "?"]
+[Date "?"]
+[Round "?"]
+[White "A \"quoted\" name"]
+[Black "?"]
+[Result "*"]
+[Annotator "test"]
+[FEN "4k3/8/8/8/8/8/4P3/4K3 b - - 0 12"]
+[SetUp "1"]
+
+12... Kd7 13. e4 *
+
+`
+	if text.String() != expected {
+		t.Errorf("Expected\n%v\ngot\n%v", expected, text.String())
+	}
+	games, err := ParsePGN(strings.NewReader(text.String()))
+	if err != nil || len(games) != 1 || games[0].Tags["White"] != `A "quoted" name` {
+		t.Errorf("Expected the written PGN to be read back, got %+v (%v)", games, err)
+	}
+}
+
+func TestGame_start_is_recorded_when_the_game_is_created(t *testing.T) {
+	defer quiet()()
+	fen := "4k3/8/8/8/8/8/4P3/4K3 b - - 0 12"
+	game, err := NewGameFromFEN(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, fen)
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	boards, err := game.Replay()
+	if err != nil || len(boards) != 1 || boards[0].fen(Black, 0, 12, false) != fen {
+		t.Errorf("Expected the replay of a game without moves to be its starting position, got %v boards (%v)", len(boards), err)
+	}
+	if err := playMoves(game, []Move{{From: Square{"E", 8}, To: Square{"D", 7}}}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	if game.StartFEN() != fen {
+		t.Errorf("Expected the starting FEN %v, got %v", fen, game.StartFEN())
+	}
+	boards, err = game.Replay()
+	if err != nil || len(boards) != 2 || boards[0].fen(Black, 0, 12, false) != fen {
+		t.Errorf("Expected the replay to start from %v, got %v boards (%v)", fen, len(boards), err)
+	}
+}
+
+func TestNewGameFromPGN_rejects_unknown_variants(t *testing.T) {
+	defer quiet()()
+	_, err := NewGameFromPGN(nil, nil, &noopVisualizer{}, PGNGame{Tags: map[string]string{"Variant": "Suicide chess"}, Moves: []string{"e4"}})
+	if err == nil || !strings.Contains(err.Error(), "Suicide chess") {
+		t.Errorf("Expected the unknown variant to be rejected, got %v", err)
+	}
+}
+
+func TestWritePGN_round_trips_chess960_and_variant_games(t *testing.T) {
+	defer quiet()()
+	chess960, err := NewChess960Game(nil, nil, &noopVisualizer{}, 0)
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	// the standard setup has no FEN tag, only the Variant tag tells it is chess960
+	standardSetup, err := NewChess960Game(nil, nil, &noopVisualizer{}, 518)
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	atomic := NewVariantGame(nil, nil, &noopVisualizer{}, Atomic{})
+	games := []struct {
+		game    *Game
+		moves   []string
+		variant string
+	}{
+		{chess960, []string{"Nc3", "Nc6", "Nd3", "Nd6", "b3", "b6", "Qb2", "Qb7", "O-O-O"}, "Chess960"},
+		{standardSetup, []string{"Nf3", "Nf6", "g3", "g6", "Bg2", "Bg7", "O-O"}, "Chess960"},
+		{atomic, []string{"Nf3", "d5", "Ng5", "e6", "Nxf7"}, "Atomic"},
+	}
+	for _, c := range games {
+		for _, san := range c.moves {
+			move, err := c.game.Board.ParseSAN(c.game.NextToMove, san)
+			if err != nil {
+				t.Fatalf("%v: failed to parse %v, %v", c.variant, san, err)
+			}
+			if _, err := c.game.move(move, c.game.NextToMove); err != nil {
+				t.Fatalf("%v: failed to play %v, %v", c.variant, san, err)
+			}
+		}
+		var text strings.Builder
+		if err := WritePGN(&text, c.game, nil); err != nil {
+			t.Fatalf("%v: failed to write PGN, %v", c.variant, err)
+		}
+		parsed, err := ParsePGN(strings.NewReader(text.String()))
+		if err != nil || len(parsed) != 1 || parsed[0].Tags["Variant"] != c.variant {
+			t.Fatalf("%v: expected the PGN to be read back with its variant, got %+v (%v)", c.variant, parsed, err)
+		}
+		game, err := NewGameFromPGN(nil, nil, &noopVisualizer{}, parsed[0])
+		if err != nil {
+			t.Errorf("%v: failed to read the written PGN back, %v\n%v", c.variant, err, text.String())
+			continue
+		}
+		if game.FEN() != c.game.FEN() || game.Variant().Name() != c.game.Variant().Name() || game.Board.IsChess960() != c.game.Board.IsChess960() {
+			t.Errorf("%v: expected %v, got %v", c.variant, c.game.FEN(), game.FEN())
+		}
+	}
+}
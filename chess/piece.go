@@ -20,30 +20,30 @@ func (c Colour) String() string {
 	}
 }
 
-type ptype int64
+type PieceType int64
 
 const (
-	pawn ptype = iota
-	rook
-	knight
-	bishop
-	queen
-	king
+	Pawn PieceType = iota
+	Rook
+	Knight
+	Bishop
+	Queen
+	King
 )
 
-func (t ptype) String() string {
+func (t PieceType) String() string {
 	switch t {
-	case pawn:
+	case Pawn:
 		return "Pawn"
-	case rook:
+	case Rook:
 		return "Rook"
-	case knight:
+	case Knight:
 		return "Knight"
-	case bishop:
+	case Bishop:
 		return "Bishop"
-	case queen:
+	case Queen:
 		return "Queen"
-	case king:
+	case King:
 		return "King"
 	default:
 		return "Unknown"
@@ -51,47 +51,71 @@ func (t ptype) String() string {
 }
 
 type Piece struct {
-	Type          ptype
+	Type          PieceType
 	CurrentSquare Square
 	Colour        Colour
 	InPlay        bool
 	hasMoved      bool // pawn 2 squares first move, king castling if king and rook hasn't moved etc...
+	promoted      bool // a promoted pawn goes back to the pocket as a pawn when taken (crazyhouse)
+}
+
+// creates a piece in play, pawns on their starting row and kings and rooks on their starting squares count as not moved
+func NewPiece(pieceType PieceType, colour Colour, square Square) Piece {
+	return Piece{Type: pieceType, CurrentSquare: square, Colour: colour, InPlay: true, hasMoved: !onStartingSquare(pieceType, colour, square)}
+}
+
+func onStartingSquare(pieceType PieceType, colour Colour, square Square) bool {
+	homeRow, pawnRow := 1, 2
+	if colour == Black {
+		homeRow, pawnRow = 8, 7
+	}
+	switch pieceType {
+	case Pawn:
+		// pawns behind their starting row (only possible in horde) may move two squares as well
+		return square.Row == pawnRow || square.Row == homeRow
+	case King:
+		return square.Row == homeRow && square.Column == "E"
+	case Rook:
+		return square.Row == homeRow && (square.Column == "A" || square.Column == "H")
+	default:
+		return square.Row == homeRow
+	}
 }
 
 // returns the abbreviation for the piece, this can be used by (e.g) a BoardVisualizer  to visualize the board
 func (p *Piece) GetAbbreveation() string {
 	switch p.Type {
-	case pawn:
+	case Pawn:
 		if p.Colour == White {
 			return "♙"
 		} else {
 			return "♟"
 		}
-	case rook:
+	case Rook:
 		if p.Colour == White {
 			return "♖"
 		} else {
 			return "♜"
 		}
-	case knight:
+	case Knight:
 		if p.Colour == White {
 			return "♘"
 		} else {
 			return "♞"
 		}
-	case bishop:
+	case Bishop:
 		if p.Colour == White {
 			return "♗"
 		} else {
 			return "♝"
 		}
-	case queen:
+	case Queen:
 		if p.Colour == White {
 			return "♕"
 		} else {
 			return "♛"
 		}
-	case king:
+	case King:
 		if p.Colour == White {
 			return "♔"
 		} else {
@@ -139,7 +163,6 @@ func (p *Piece) moveIsNone(targetColumn string, targetRow int) bool {
 	}
 }
 func (p *Piece) takeAt(targetColumn string, targetRow int, enemy *Piece, b *Board) {
-	fmt.Printf("%v %v is taking %v %v", p.Colour, p.Type, enemy.Colour, enemy.Type)
 	square, err := b.getSquare(targetColumn, targetRow)
 	if err != nil {
 		fmt.Printf("error: %v", err)
@@ -148,6 +171,7 @@ func (p *Piece) takeAt(targetColumn string, targetRow int, enemy *Piece, b *Boar
 	p.CurrentSquare = square
 	p.hasMoved = true // if first move is a take.. (else it is set in GoTo function)
 	enemy.InPlay = false
+	b.afterCapture(p, enemy)
 }
 func (p *Piece) couldMoveTo(targetColumn string, targetRow int, b *Board) bool {
 	dryRun := true
@@ -158,7 +182,6 @@ func (p *Piece) couldMoveTo(targetColumn string, targetRow int, b *Board) bool {
 	}
 }
 func (p *Piece) goTo(targetColumn string, targetRow int, b *Board) {
-	fmt.Printf("piece %v is moving to %v%v\n", p, targetColumn, targetRow)
 	square, err := b.getSquare(targetColumn, targetRow)
 	if err != nil {
 		fmt.Printf("error: %v", err)
@@ -170,41 +193,20 @@ func (p *Piece) goTo(targetColumn string, targetRow int, b *Board) {
 
 // Checks if the target square is valid and that the move doesnt put the own king in check
 func (p *Piece) MoveIsLegal(targetColumn string, targetRow int, b *Board) bool {
-	_, err := b.getSquare(targetColumn, targetRow)
+	s, err := b.getSquare(targetColumn, targetRow)
 	if err != nil { // check if target square is on board
 		fmt.Printf("error: %v", err)
 		return false
 	}
 
-	// check if king in check on pending move
-	currentSquare := p.CurrentSquare
-	s, _ := b.getSquare(targetColumn, targetRow)
-
-	// if enemy on target square simulate take in temp move
-	targetSquareOccupied, pieceAtTargetSquare := b.GetPieceAtSquare(s.Column, s.Row)
-	if targetSquareOccupied {
-		if p.enemyTo(pieceAtTargetSquare) {
-			pieceAtTargetSquare.InPlay = false // temp take
-		}
+	if rules, ok := b.variant.(CheckRules); ok {
+		return rules.MoveIsLegal(b, p, s)
 	}
-	p.CurrentSquare = s // temp move
-
-	if isCheck, _ := b.kingIsInCheck(p.Colour); isCheck {
-
-		if targetSquareOccupied {
-			pieceAtTargetSquare.InPlay = true // reset temp take
-		}
-		p.CurrentSquare = currentSquare // reset temp move
-		return false
-	}
-
-	if targetSquareOccupied {
-		pieceAtTargetSquare.InPlay = true // reset temp take
-	}
-	p.CurrentSquare = currentSquare // reset temp move
-
-	return true
-
+	// check if king in check on pending move
+	return !b.withTempMove(p, s, func() bool {
+		isCheck, _ := b.kingIsInCheck(p.Colour)
+		return isCheck
+	})
 }
 
 func (p *Piece) enemyTo(piece *Piece) bool {
@@ -214,17 +216,17 @@ func (p *Piece) enemyTo(piece *Piece) bool {
 // returns the value of a piece
 func (p *Piece) GetValue() int {
 	switch p.Type {
-	case pawn:
+	case Pawn:
 		return 1
-	case rook:
+	case Rook:
 		return 5
-	case knight:
+	case Knight:
 		return 3
-	case bishop:
+	case Bishop:
 		return 3
-	case queen:
+	case Queen:
 		return 9
-	case king:
+	case King:
 		return 100
 	default:
 		return 0
@@ -282,37 +284,77 @@ func (p *Piece) Move(targetColumn string, targetRow int, b *Board, dryRun bool)
 	previousSquare := p.CurrentSquare // save previous square (for last move)
 
 	targetColumn = strings.ToUpper(targetColumn)
+	if _, err := b.getSquare(targetColumn, targetRow); err != nil {
+		return nil, illegalMove(OffBoard, "square %v%v is not on the board", targetColumn, targetRow)
+	}
 	if p.moveIsNone(targetColumn, targetRow) {
-		return nil, fmt.Errorf("already there")
+		return nil, illegalMove(AlreadyThere, "already there")
 	}
 	if !dryRun && b.kingIsInMate(p.Colour) {
-		return nil, fmt.Errorf("%v king is in mate", p.Colour)
+		return nil, illegalMove(KingIsInMate, "%v king is in mate", p.Colour)
+	}
+	if !dryRun {
+		if err := p.checkMoveIsLegal(targetColumn, targetRow, b); err != nil {
+			return nil, err
+		}
+	}
+	moveResult, err := p.moveByType(targetColumn, targetRow, b, dryRun)
+	// if move was successful and not a dry run, set last move
+	if err == nil && !dryRun {
+		if p.Colour == White {
+			b.whitesLastMove = LastMove{p, &Move{From: previousSquare, To: p.CurrentSquare}}
+		} else {
+			b.blacksLastMove = LastMove{p, &Move{From: previousSquare, To: p.CurrentSquare}}
+		}
+		b.lastMoveColour = p.Colour
+	}
+	return moveResult, err
+}
+
+// returns why the piece can not make the move. A move the piece can not make at all (wrong shape, blocked path or
+// occupied square) is reported as such before whether it leaves the king in check. Castling checks the squares the
+// king passes itself, and in chess960 the king castles onto its own rook, so tryCastling checks where it ends up
+func (p *Piece) checkMoveIsLegal(targetColumn string, targetRow int, b *Board) error {
+	castling := false
+	if p.Type == King {
+		_, castling = castlingSideFor(p, targetColumn, targetRow, b)
+	}
+	if !castling {
+		if _, err := p.moveByType(targetColumn, targetRow, b, true); err != nil {
+			return err
+		}
+	}
+	if castling && b.chess960 {
+		return nil
 	}
+	if !p.MoveIsLegal(targetColumn, targetRow, b) {
+		if p.Type == Pawn || p.Type == Queen {
+			return illegalMove(LeavesKingInCheck, "illegal move")
+		}
+		return illegalMove(LeavesKingInCheck, "move is not legal")
+	}
+	return nil
+}
+
+// moves the piece by the rules of its type, a dry run only checks the move
+func (p *Piece) moveByType(targetColumn string, targetRow int, b *Board, dryRun bool) (*MoveResult, error) {
 	var moveResult *MoveResult
 	var err error
 	switch p.Type {
-	case pawn:
+	case Pawn:
 		moveResult, err = movePawn(targetColumn, targetRow, b, p, dryRun)
-	case rook:
+	case Rook:
 		moveResult, err = moveRook(targetColumn, targetRow, b, p, dryRun)
-	case knight:
+	case Knight:
 		moveResult, err = moveKnight(targetColumn, targetRow, b, p, dryRun)
-	case bishop:
+	case Bishop:
 		moveResult, err = moveBishop(targetColumn, targetRow, b, p, dryRun)
-	case queen:
+	case Queen:
 		moveResult, err = moveQueen(targetColumn, targetRow, b, p, dryRun)
-	case king:
+	case King:
 		moveResult, err = moveKing(targetColumn, targetRow, b, p, dryRun)
 	default:
 		moveResult, err = nil, fmt.Errorf("unknown piece type: %v", p.Type)
 	}
-	// if move was successful and not a dry run, set last move
-	if err == nil && !dryRun {
-		if p.Colour == White {
-			b.whitesLastMove = LastMove{p, &Move{From: previousSquare, To: p.CurrentSquare}}
-		} else {
-			b.blacksLastMove = LastMove{p, &Move{From: previousSquare, To: p.CurrentSquare}}
-		}
-	}
 	return moveResult, err
 }
@@ -0,0 +1,268 @@
+package chess
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// an entry of a Polyglot opening book (.bin), 16 bytes in big endian order. Move holds the to square in bits 0-5,
+// the from square in bits 6-11 (file in the low 3 bits, row in the high 3) and the promotion piece in bits 12-14.
+// Castling is written as the king moving onto its own rook
+type PolyglotEntry struct {
+	Key    uint64
+	Move   uint16
+	Weight uint16
+	Learn  uint32
+}
+
+// the polyglot numbering of piece kinds, black pawn, white pawn, black knight and so on
+var polyglotPieceTypes = []PieceType{Pawn, Knight, Bishop, Rook, Queen, King}
+
+// promotion pieces in the order of the move encoding, starting at 1
+var polyglotPromotions = []PieceType{Knight, Bishop, Rook, Queen}
+
+// returns the Polyglot hash of the position with colour to move. En passant only counts when a pawn of colour
+// stands next to the pawn that just moved two squares, as in Polyglot
+func (b *Board) PolyglotKey(colour Colour) uint64 {
+	var key uint64
+	for _, pieces := range [][]Piece{b.WhitePieces, b.BlackPieces} {
+		for _, piece := range pieces {
+			if !piece.InPlay {
+				continue
+			}
+			kind := 0
+			for i, pieceType := range polyglotPieceTypes {
+				if pieceType == piece.Type {
+					kind = 2 * i
+				}
+			}
+			if piece.Colour == White {
+				kind++
+			}
+			key ^= polyglotRandom[64*kind+8*(piece.CurrentSquare.Row-1)+b.getColumnIndex(piece.CurrentSquare.Column)]
+		}
+	}
+	rights := b.castlingRightsFEN(false)
+	for i, right := range []string{"K", "Q", "k", "q"} {
+		if strings.Contains(rights, right) {
+			key ^= polyglotRandom[768+i]
+		}
+	}
+	if enPassant := b.enPassantSquareFEN(colour); enPassant != "-" {
+		column := strings.ToUpper(enPassant[:1])
+		pawnRow := 5
+		if colour == Black {
+			pawnRow = 4
+		}
+		for _, neighbour := range []int{b.getColumnIndex(column) - 1, b.getColumnIndex(column) + 1} {
+			if neighbour < 0 || neighbour > 7 {
+				continue
+			}
+			occupied, piece := b.GetPieceAtSquare(b.columns[neighbour], pawnRow)
+			if occupied && piece.Type == Pawn && piece.Colour == colour {
+				key ^= polyglotRandom[772+b.getColumnIndex(column)]
+				break
+			}
+		}
+	}
+	if colour == White {
+		key ^= polyglotRandom[780]
+	}
+	return key
+}
+
+// returns the move in the Polyglot encoding, castling is encoded as the king moving onto its rook
+func (b *Board) PolyglotMove(move Move) (uint16, error) {
+	if move.Kind == Drop {
+		return 0, fmt.Errorf("drops can not be written in a Polyglot book")
+	}
+	found, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row)
+	if !found {
+		return 0, illegalMove(NoPiece, "no piece at %v%v", move.From.Column, move.From.Row)
+	}
+	to := move.To
+	if piece.Type == King && !b.chess960 {
+		if side, ok := castlingSideFor(piece, move.To.Column, move.To.Row, b); ok {
+			to = Square{b.castlingRookColumns[piece.Colour][side], move.To.Row}
+		}
+	}
+	encoded := uint16(b.getColumnIndex(to.Column)) | uint16(to.Row-1)<<3 |
+		uint16(b.getColumnIndex(move.From.Column))<<6 | uint16(move.From.Row-1)<<9
+	if piece.Type == Pawn && (to.Row == 1 || to.Row == 8) {
+		encoded |= 4 << 12 // pawns always promote to a queen
+	}
+	return encoded, nil
+}
+
+// returns the move for the Polyglot encoding, false if it is not a legal move for colour (e.g. an underpromotion,
+// which this package does not support)
+func (b *Board) moveFromPolyglot(colour Colour, encoded uint16) (Move, bool) {
+	square := func(bits uint16) Square {
+		return Square{b.columns[bits&7], int(bits>>3&7) + 1}
+	}
+	move := Move{From: square(encoded >> 6), To: square(encoded)}
+	promotion := int(encoded >> 12 & 7)
+	if promotion > 0 && polyglotPromotions[promotion-1] != Queen {
+		return Move{}, false
+	}
+	found, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row)
+	if !found || piece.Colour != colour {
+		return Move{}, false
+	}
+	if rookFound, rook := b.GetPieceAtSquare(move.To.Column, move.To.Row); piece.Type == King && rookFound && rook.Type == Rook && rook.Colour == colour {
+		castling, err := b.CastlingMove(colour, b.getColumnIndex(move.To.Column) > b.getColumnIndex(move.From.Column))
+		if err != nil {
+			return Move{}, false
+		}
+		move = castling
+	}
+	if _, legal := b.LegalMovesFor(colour)[move]; !legal {
+		return Move{}, false
+	}
+	return move, true
+}
+
+// an opening book in the Polyglot format
+type PolyglotBook struct {
+	entries []PolyglotEntry // sorted by key
+}
+
+// reads a Polyglot book (.bin), the entries need not be sorted
+func ReadPolyglotBook(r io.Reader) (*PolyglotBook, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%16 != 0 {
+		return nil, fmt.Errorf("invalid Polyglot book, %v bytes is not a whole number of 16 byte entries", len(data))
+	}
+	book := &PolyglotBook{entries: make([]PolyglotEntry, 0, len(data)/16)}
+	for i := 0; i < len(data); i += 16 {
+		book.entries = append(book.entries, PolyglotEntry{
+			Key:    binary.BigEndian.Uint64(data[i:]),
+			Move:   binary.BigEndian.Uint16(data[i+8:]),
+			Weight: binary.BigEndian.Uint16(data[i+10:]),
+			Learn:  binary.BigEndian.Uint32(data[i+12:]),
+		})
+	}
+	sort.SliceStable(book.entries, func(i, j int) bool { return book.entries[i].Key < book.entries[j].Key })
+	return book, nil
+}
+
+// writes the entries as a Polyglot book, sorted by key as Polyglot requires
+func WritePolyglotBook(w io.Writer, entries []PolyglotEntry) error {
+	sorted := append([]PolyglotEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	data := make([]byte, 16*len(sorted))
+	for i, entry := range sorted {
+		binary.BigEndian.PutUint64(data[16*i:], entry.Key)
+		binary.BigEndian.PutUint16(data[16*i+8:], entry.Move)
+		binary.BigEndian.PutUint16(data[16*i+10:], entry.Weight)
+		binary.BigEndian.PutUint32(data[16*i+12:], entry.Learn)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// returns the entries of the book with the key
+func (book *PolyglotBook) Entries(key uint64) []PolyglotEntry {
+	first := sort.Search(len(book.entries), func(i int) bool { return book.entries[i].Key >= key })
+	last := first
+	for last < len(book.entries) && book.entries[last].Key == key {
+		last++
+	}
+	return book.entries[first:last]
+}
+
+// a legal move found in a book
+type BookMove struct {
+	Move   Move
+	Weight int
+}
+
+// returns the legal moves the book has for colour in the position, the entries with illegal or unsupported moves
+// and those with no weight are left out
+func (book *PolyglotBook) Moves(b *Board, colour Colour) []BookMove {
+	moves := []BookMove{}
+	for _, entry := range book.Entries(b.PolyglotKey(colour)) {
+		if entry.Weight == 0 {
+			continue
+		}
+		if move, ok := b.moveFromPolyglot(colour, entry.Move); ok {
+			moves = append(moves, BookMove{Move: move, Weight: int(entry.Weight)})
+		}
+	}
+	return moves
+}
+
+// returns the number of entries in the book
+func (book *PolyglotBook) Len() int {
+	return len(book.entries)
+}
+
+// a Player that plays moves from an opening book while the game is in the book and lets the inner player move
+// after that. Book moves are picked at random in proportion to their weight
+type BookPlayer struct {
+	Book  *PolyglotBook
+	Inner Player
+	Rand  *rand.Rand // the global source of math/rand if nil
+	out   bool       // the game has left the book, so there is no need to look any more
+}
+
+func NewBookPlayer(book *PolyglotBook, inner Player) *BookPlayer {
+	return &BookPlayer{Book: book, Inner: inner}
+}
+
+func (p *BookPlayer) PickMove(g *Game) (*Move, error) {
+	if !p.out {
+		if moves := p.Book.Moves(g.Board, g.NextToMove); len(moves) > 0 {
+			move := p.pick(moves)
+			return &move, nil
+		}
+		p.out = true
+	}
+	if p.Inner == nil {
+		return nil, errors.New("the game is out of book and there is no player to take over")
+	}
+	return p.Inner.PickMove(g)
+}
+
+// returns a move at random in proportion to the weights
+func (p *BookPlayer) pick(moves []BookMove) Move {
+	total := 0
+	for _, move := range moves {
+		total += move.Weight
+	}
+	var n int
+	if p.Rand != nil {
+		n = p.Rand.Intn(total)
+	} else {
+		n = rand.Intn(total)
+	}
+	for _, move := range moves {
+		if n < move.Weight {
+			return move.Move
+		}
+		n -= move.Weight
+	}
+	return moves[len(moves)-1].Move
+}
+
+// lets the inner player answer, draws are declined if it can not
+func (p *BookPlayer) RespondToDrawOffer(g *Game, offeredBy Colour) bool {
+	if responder, ok := p.Inner.(DrawOfferResponder); ok {
+		return responder.RespondToDrawOffer(g, offeredBy)
+	}
+	return false
+}
+
+func (p *BookPlayer) HandleIllegalMove(g *Game, move Move, err error) {
+	if handler, ok := p.Inner.(IllegalMoveHandler); ok {
+		handler.HandleIllegalMove(g, move, err)
+	}
+}
@@ -0,0 +1,151 @@
+package chess
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+// the test positions of the Polyglot book format specification
+func TestPolyglotKey(t *testing.T) {
+	tests := []struct {
+		fen string
+		key uint64
+	}{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 0x463b96181691fc9c},
+		{"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", 0x823c9b50fd114196},
+		{"rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 2", 0x0756b94461c50fb0},
+		{"rnbqkbnr/ppp1pppp/8/3pP3/8/8/PPPP1PPP/RNBQKBNR b KQkq - 0 2", 0x662fafb965db29d4},
+		{"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", 0x22a48b5a8e47ff78},
+		{"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPPKPPP/RNBQ1BNR b kq - 0 3", 0x652a607ca3f242c1},
+		{"rnbq1bnr/ppp1pkpp/8/3pPp2/8/8/PPPPKPPP/RNBQ1BNR w - - 0 4", 0x00fdd303c946bdd9},
+		{"rnbqkbnr/p1pppppp/8/8/PpP4P/8/1P1PPPP1/RNBQKBNR b KQkq c3 0 3", 0x3c8123ea7b067637},
+		{"rnbqkbnr/p1pppppp/8/8/P6P/R1p5/1P1PPPP1/1NBQKBNR b Kkq - 0 4", 0x5c3f9b829b279560},
+	}
+	for _, test := range tests {
+		position, err := ParseFEN(test.fen)
+		if err != nil {
+			t.Fatalf("Failed to parse %q, %v", test.fen, err)
+		}
+		if key := position.Board.PolyglotKey(position.NextToMove); key != test.key {
+			t.Errorf("Expected the key of %q to be %016x, got %016x", test.fen, test.key, key)
+		}
+	}
+}
+
+func TestPolyglotKey_after_moves(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if err := playMoves(game, []Move{
+		{From: Square{"E", 2}, To: Square{"E", 4}},
+		{From: Square{"D", 7}, To: Square{"D", 5}},
+		{From: Square{"E", 4}, To: Square{"E", 5}},
+		{From: Square{"F", 7}, To: Square{"F", 5}},
+	}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	if key := game.Board.PolyglotKey(game.NextToMove); key != 0x22a48b5a8e47ff78 {
+		t.Errorf("Expected the en passant file to count, got %016x", key)
+	}
+}
+
+// the positions and moves of testdata/small.bin, the book is written again when UPDATE_TESTDATA is set
+func smallBookEntries(t *testing.T) []PolyglotEntry {
+	defer quiet()()
+	entries := []PolyglotEntry{}
+	add := func(game *Game, move Move, weight uint16) {
+		encoded, err := game.Board.PolyglotMove(move)
+		if err != nil {
+			t.Fatalf("Failed to encode %v, %v", move, err)
+		}
+		entries = append(entries, PolyglotEntry{Key: game.Board.PolyglotKey(game.NextToMove), Move: encoded, Weight: weight})
+	}
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	add(game, Move{From: Square{"E", 2}, To: Square{"E", 4}}, 3)
+	add(game, Move{From: Square{"D", 2}, To: Square{"D", 4}}, 1)
+	add(game, Move{From: Square{"B", 1}, To: Square{"A", 3}}, 0) // never played
+	line := []Move{
+		{From: Square{"E", 2}, To: Square{"E", 4}},
+		{From: Square{"E", 7}, To: Square{"E", 5}},
+		{From: Square{"G", 1}, To: Square{"F", 3}},
+		{From: Square{"B", 8}, To: Square{"C", 6}},
+		{From: Square{"F", 1}, To: Square{"C", 4}},
+		{From: Square{"F", 8}, To: Square{"C", 5}},
+	}
+	for i, move := range line {
+		if i > 0 { // 1. e4 is already in the book
+			add(game, move, 1)
+		}
+		if err := playMoves(game, []Move{move}); err != nil {
+			t.Fatalf("Failed to move, %v", err)
+		}
+	}
+	add(game, Move{From: Square{"E", 1}, To: Square{"G", 1}}, 1) // O-O, written as e1h1
+	return entries
+}
+
+func TestReadPolyglotBook(t *testing.T) {
+	var expected bytes.Buffer
+	if err := WritePolyglotBook(&expected, smallBookEntries(t)); err != nil {
+		t.Fatalf("Failed to write the book, %v", err)
+	}
+	if os.Getenv("UPDATE_TESTDATA") != "" {
+		if err := os.WriteFile("testdata/small.bin", expected.Bytes(), 0644); err != nil {
+			t.Fatalf("Failed to update testdata, %v", err)
+		}
+	}
+	data, err := os.ReadFile("testdata/small.bin")
+	if err != nil {
+		t.Fatalf("Failed to read testdata, %v", err)
+	}
+	if !bytes.Equal(data, expected.Bytes()) {
+		t.Errorf("Expected testdata/small.bin to hold the entries of smallBookEntries")
+	}
+	book, err := ReadPolyglotBook(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to read the book, %v", err)
+	}
+	if book.Len() != 9 {
+		t.Errorf("Expected 9 entries, got %v", book.Len())
+	}
+	moves := book.Moves(NewBoard(), White)
+	if len(moves) != 2 || moves[0].Weight+moves[1].Weight != 4 {
+		t.Errorf("Expected e4 and d4 with a total weight of 4, got %+v", moves)
+	}
+	if _, err := ReadPolyglotBook(bytes.NewReader(data[:20])); err == nil {
+		t.Errorf("Expected a truncated book to be rejected")
+	}
+}
+
+func TestBookPlayer_plays_the_book_then_the_inner_player(t *testing.T) {
+	defer quiet()()
+	data, err := os.ReadFile("testdata/small.bin")
+	if err != nil {
+		t.Fatalf("Failed to read testdata, %v", err)
+	}
+	book, err := ReadPolyglotBook(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to read the book, %v", err)
+	}
+	// the inner players resign, so the game ends as soon as one side is out of book
+	white := &BookPlayer{Book: book, Inner: &scriptedPlayer{colour: White}, Rand: rand.New(rand.NewSource(1))}
+	black := &BookPlayer{Book: book, Inner: &scriptedPlayer{colour: Black}, Rand: rand.New(rand.NewSource(1))}
+	for i := 0; i < 20; i++ {
+		white.out, black.out = false, false
+		game := NewGame(white, black, &noopVisualizer{})
+		game.Start()
+		switch len(game.History) {
+		case 1: // 1. d4, black is out of book
+			if game.History[0] != (Move{From: Square{"D", 2}, To: Square{"D", 4}}) {
+				t.Errorf("Expected 1. d4, got %v", game.History)
+			}
+		case 7: // the whole line including castling
+			if game.History[6] != (Move{From: Square{"E", 1}, To: Square{"G", 1}}) {
+				t.Errorf("Expected O-O as the last book move, got %v", game.History[6])
+			}
+		default:
+			t.Errorf("Expected the game to follow the book, got %v", game.History)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidPosition = errors.New("invalid position")
+
+// removes all pieces (including captured ones) from the board
+func (b *Board) Clear() {
+	b.WhitePieces = []Piece{}
+	b.BlackPieces = []Piece{}
+	b.whitesLastMove = LastMove{}
+	b.blacksLastMove = LastMove{}
+	b.pockets = [2][6]int{}
+}
+
+// places a new piece on the given square, replacing any piece already there
+func (b *Board) SetPiece(pieceType PieceType, colour Colour, column string, row int) error {
+	square, err := b.getSquare(strings.ToUpper(column), row)
+	if err != nil {
+		return fmt.Errorf("can not set %v %v on %v%v: %w", colour, pieceType, column, row, ErrOffBoard)
+	}
+	if occupied, _ := b.GetPieceAtSquare(square.Column, square.Row); occupied {
+		if err := b.RemovePiece(square.Column, square.Row); err != nil {
+			return err
+		}
+	}
+	if colour == White {
+		b.WhitePieces = append(b.WhitePieces, NewPiece(pieceType, colour, square))
+	} else {
+		b.BlackPieces = append(b.BlackPieces, NewPiece(pieceType, colour, square))
+	}
+	return nil
+}
+
+// removes the piece on the given square from the board (it does not count as captured)
+func (b *Board) RemovePiece(column string, row int) error {
+	column = strings.ToUpper(column)
+	for i, piece := range b.WhitePieces {
+		if piece.InPlay && piece.CurrentSquare.Column == column && piece.CurrentSquare.Row == row {
+			b.WhitePieces = append(b.WhitePieces[:i], b.WhitePieces[i+1:]...)
+			return nil
+		}
+	}
+	for i, piece := range b.BlackPieces {
+		if piece.InPlay && piece.CurrentSquare.Column == column && piece.CurrentSquare.Row == row {
+			b.BlackPieces = append(b.BlackPieces[:i], b.BlackPieces[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("can not remove piece from %v%v: %w", column, row, ErrNoPiece)
+}
+
+// checks that the position is playable with sideToMove to move: one king per side,
+// no pawns on the first or eighth row and the side not to move is not in check
+func (b *Board) Validate(sideToMove Colour) error {
+	for _, colour := range []Colour{White, Black} {
+		pieces := b.WhitePieces
+		if colour == Black {
+			pieces = b.BlackPieces
+		}
+		kings := 0
+		for _, piece := range pieces {
+			if !piece.InPlay {
+				continue
+			}
+			if piece.Type == King {
+				kings++
+			}
+			if piece.Type == Pawn && (piece.CurrentSquare.Row == 1 || piece.CurrentSquare.Row == 8) {
+				return fmt.Errorf("%w: %v pawn on %v%v", ErrInvalidPosition, colour, piece.CurrentSquare.Column, piece.CurrentSquare.Row)
+			}
+		}
+		if kings != 1 {
+			return fmt.Errorf("%w: %v has %v kings, expected 1", ErrInvalidPosition, colour, kings)
+		}
+	}
+	notToMove := White
+	if sideToMove == White {
+		notToMove = Black
+	}
+	if isCheck, _ := b.kingIsInCheck(notToMove); isCheck {
+		return fmt.Errorf("%w: %v is in check but it is %vs turn", ErrInvalidPosition, notToMove, sideToMove)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetPiece_builds_a_custom_position(t *testing.T) {
+	defer quiet()()
+	board := NewBoard()
+	board.Clear()
+	pieces := []struct {
+		pieceType PieceType
+		colour    Colour
+		column    string
+		row       int
+	}{
+		{King, White, "E", 1},
+		{Rook, White, "H", 1},
+		{Pawn, White, "A", 2},
+		{King, Black, "e", 8},
+		{Knight, Black, "B", 8},
+	}
+	for _, p := range pieces {
+		if err := board.SetPiece(p.pieceType, p.colour, p.column, p.row); err != nil {
+			t.Errorf("Failed to set piece, %v", err)
+		}
+	}
+	expectedStateOfBoard := `
+	.  ♞  .  .  ♚  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	♙  .  .  .  .  .  .  .
+	.  .  .  .  ♔  .  .  ♖
+	`
+	if err := assertExpectedBoardState(expectedStateOfBoard, board); err != nil {
+		t.Errorf("Failed to assert expected board state, %v (Visible whitespace is ignored, something else differs!", err.Error())
+	}
+	if err := board.Validate(White); err != nil {
+		t.Errorf("Expected position to be valid, got %v", err)
+	}
+	// king and rook on their starting squares may castle, the pawn on its starting row may move two squares
+	_, whiteKing := board.GetPieceAtSquare("E", 1)
+	if !whiteKing.couldMoveTo("G", 1, board) {
+		t.Errorf("Expected white to be able to castle kingside")
+	}
+	_, whitePawn := board.GetPieceAtSquare("A", 2)
+	if !whitePawn.couldMoveTo("A", 4, board) {
+		t.Errorf("Expected pawn on its starting row to be able to move two squares")
+	}
+
+	// replacing and removing pieces
+	if err := board.SetPiece(Queen, White, "B", 8); err != nil {
+		t.Errorf("Failed to replace piece, %v", err)
+	}
+	if _, piece := board.GetPieceAtSquare("B", 8); piece.Type != Queen || piece.Colour != White {
+		t.Errorf("Expected white queen on B8, got %v %v", piece.Colour, piece.Type)
+	}
+	if len(board.BlackPieces) != 1 {
+		t.Errorf("Expected the replaced black knight to be removed, black has %v pieces", len(board.BlackPieces))
+	}
+	if err := board.RemovePiece("B", 8); err != nil {
+		t.Errorf("Failed to remove piece, %v", err)
+	}
+	if err := board.RemovePiece("B", 8); !errors.Is(err, ErrNoPiece) {
+		t.Errorf("Expected ErrNoPiece when removing from an empty square, got %v", err)
+	}
+	if err := board.SetPiece(Queen, White, "I", 8); !errors.Is(err, ErrOffBoard) {
+		t.Errorf("Expected ErrOffBoard when setting a piece outside the board, got %v", err)
+	}
+}
+
+func TestValidate_rejects_invalid_positions(t *testing.T) {
+	defer quiet()()
+	build := func(extra func(b *Board)) *Board {
+		board := NewBoard()
+		board.Clear()
+		board.SetPiece(King, White, "E", 1)
+		board.SetPiece(King, Black, "E", 8)
+		extra(board)
+		return board
+	}
+	cases := map[string]*Board{
+		"two white kings":           build(func(b *Board) { b.SetPiece(King, White, "A", 1) }),
+		"no black king":             build(func(b *Board) { b.RemovePiece("E", 8) }),
+		"pawn on the eighth row":    build(func(b *Board) { b.SetPiece(Pawn, White, "A", 8) }),
+		"pawn on the first row":     build(func(b *Board) { b.SetPiece(Pawn, Black, "A", 1) }),
+		"side not to move in check": build(func(b *Board) { b.SetPiece(Rook, White, "E", 4) }),
+	}
+	for name, board := range cases {
+		if err := board.Validate(White); !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("%v: expected ErrInvalidPosition, got %v", name, err)
+		}
+	}
+	// with black to move, black being in check is fine
+	board := build(func(b *Board) { b.SetPiece(Rook, White, "E", 4) })
+	if err := board.Validate(Black); err != nil {
+		t.Errorf("Expected black in check with black to move to be valid, got %v", err)
+	}
+}
+
+func TestNewGameFromPosition(t *testing.T) {
+	defer quiet()()
+	board := NewBoard()
+	board.Clear()
+	board.SetPiece(King, White, "E", 1)
+	board.SetPiece(King, Black, "E", 8)
+	board.SetPiece(Rook, White, "E", 4)
+	if _, err := NewGameFromPosition(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, board, White); err == nil {
+		t.Errorf("Expected game from invalid position to fail")
+	}
+	game, err := NewGameFromPosition(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, board, Black)
+	if err != nil {
+		t.Errorf("Expected game from valid position, got %v", err)
+		return
+	}
+	if _, err := game.move(Move{From: Square{"E", 8}, To: Square{"D", 7}}, Black); err != nil {
+		t.Errorf("Expected black to be able to move out of check, got %v", err)
+	}
+}
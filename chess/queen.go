@@ -1,29 +1,25 @@
 package chess
 
 import (
-	"errors"
 	"fmt"
 )
 
 func moveQueen(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
-	if p.Type != queen {
+	if p.Type != Queen {
 		return nil, fmt.Errorf("MoveQueen called on piece of type %v", p.Type)
 	}
 	if !p.InPlay {
-		return nil, errors.New("Piece is not in play")
-	}
-	if !dryRun && !p.MoveIsLegal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("illegal move")
+		return nil, illegalMove(PieceNotInPlay, "Piece is not in play")
 	}
 	if !p.moveIsStraight(targetColumn, targetRow) && !p.moveIsDiagonal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("queens can only move straight or diagonally") // not like a knight ...
+		return nil, illegalMove(InvalidPieceMove, "queens can only move straight or diagonally") // not like a knight ...
 	}
 	if _, err := p.moveJumpsOverPieces(targetColumn, targetRow, b); err != nil {
 		return nil, err
 	}
 	occupied, pieceAtTarget := b.GetPieceAtSquare(targetColumn, targetRow)
 	if occupied && !p.enemyTo(pieceAtTarget) {
-		return nil, fmt.Errorf("%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
+		return nil, illegalMove(SquareOccupied, "%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
 	} else if occupied && p.enemyTo(pieceAtTarget) {
 		if !dryRun {
 			p.takeAt(targetColumn, targetRow, pieceAtTarget, b)
@@ -6,7 +6,7 @@ import (
 
 func TestMoveQueen(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the queen)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -17,7 +17,7 @@ func TestMoveQueen(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
 	moves := []Move{whiteMove1}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -68,7 +68,7 @@ func TestMoveQueen(t *testing.T) {
 }
 func TestGetValidQueenMoves(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the queen)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -79,7 +79,7 @@ func TestGetValidQueenMoves(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// wP  wP  wP  wP  ..  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "E", Row: 2}, Square{Column: "E", Row: 4}}
+	whiteMove1 := Move{From: Square{Column: "E", Row: 2}, To: Square{Column: "E", Row: 4}}
 	moves := []Move{whiteMove1}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
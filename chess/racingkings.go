@@ -0,0 +1,66 @@
+package chess
+
+const racingKingsFEN = "8/8/8/8/8/8/krbnNBRK/qrbnNBRQ w - - 0 1"
+
+// both kings race to the 8th row, giving check is not allowed. If White gets there first Black has one move to
+// reach the 8th row as well, which makes the game a draw
+type RacingKings struct {
+	Standard
+}
+
+func (RacingKings) Name() string {
+	return "Racing Kings"
+}
+
+func (RacingKings) NewBoard() *Board {
+	position, err := ParseFEN(racingKingsFEN)
+	if err != nil {
+		panic(err)
+	}
+	return position.Board
+}
+
+// moves that give check are not allowed
+func (RacingKings) AllowMove(b *Board, p *Piece, move Move) error {
+	if !p.couldMoveTo(move.To.Column, move.To.Row, b) {
+		return nil // not a move the piece can make, the move itself reports why
+	}
+	target, _ := b.getSquare(move.To.Column, move.To.Row)
+	opponent := opponentOf(p.Colour)
+	givesCheck := b.withTempMove(p, target, func() bool {
+		isCheck, _ := b.kingIsInCheck(opponent)
+		return isCheck
+	})
+	if givesCheck {
+		return illegalMove(ForbiddenByVariant, "giving check is not allowed in racing kings")
+	}
+	return nil
+}
+
+func (RacingKings) GameOver(g *Game) (Result, bool) {
+	whiteHasArrived := g.Board.getKing(White).CurrentSquare.Row == 8
+	blackHasArrived := g.Board.getKing(Black).CurrentSquare.Row == 8
+	switch {
+	case whiteHasArrived && blackHasArrived:
+		return drawBy(VariantRule, "both kings reached the 8th row"), true
+	case blackHasArrived:
+		return winFor(Black, VariantRule, "Black king reached the 8th row"), true
+	case whiteHasArrived && (g.NextToMove == White || !g.canMoveKingToRow(8)):
+		return winFor(White, VariantRule, "White king reached the 8th row"), true
+	}
+	if len(g.LegalMoves()) == 0 {
+		return drawBy(Stalemate, "Stale mate"), true
+	}
+	return Result{}, false
+}
+
+// returns true if the side to move has a legal king move to the given row
+func (g *Game) canMoveKingToRow(row int) bool {
+	king := g.Board.getKing(g.NextToMove)
+	for move := range g.LegalMoves() {
+		if move.From == king.CurrentSquare && move.To.Row == row {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package chess
+
+import "fmt"
+
+// how a game ended
+type Termination int64
+
+const (
+	Unterminated Termination = iota // game still in progress
+	Checkmate
+	Stalemate
+	FiftyMoveRule // claimed after 50 moves or automatic after 75 moves
+	Repetition    // claimed after threefold or automatic after fivefold repetition
+	InsufficientMaterial
+	Resignation
+	Timeout
+	Agreement
+	Abandoned
+	VariantRule // won, lost or drawn by a rule specific to the variant (e.g. racing kings)
+)
+
+func (t Termination) String() string {
+	switch t {
+	case Unterminated:
+		return "Unterminated"
+	case Checkmate:
+		return "Checkmate"
+	case Stalemate:
+		return "Stalemate"
+	case FiftyMoveRule:
+		return "Fifty move rule"
+	case Repetition:
+		return "Repetition"
+	case InsufficientMaterial:
+		return "Insufficient material"
+	case Resignation:
+		return "Resignation"
+	case Timeout:
+		return "Timeout"
+	case Agreement:
+		return "Agreement"
+	case Abandoned:
+		return "Abandoned"
+	case VariantRule:
+		return "Variant rule"
+	default:
+		return "Unknown"
+	}
+}
+
+// who (if anyone) won the game
+type Outcome int64
+
+const (
+	NoOutcome Outcome = iota // game still in progress or abandoned
+	WhiteWon
+	BlackWon
+	Drawn
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case WhiteWon:
+		return "White won"
+	case BlackWon:
+		return "Black won"
+	case Drawn:
+		return "Draw"
+	default:
+		return "No outcome"
+	}
+}
+
+// returns the winning colour, the second return value is false if there is no winner
+func (o Outcome) Winner() (Colour, bool) {
+	switch o {
+	case WhiteWon:
+		return White, true
+	case BlackWon:
+		return Black, true
+	default:
+		return White, false
+	}
+}
+
+type Result struct {
+	Outcome     Outcome
+	Termination Termination
+	Reason      string // human readable detail, e.g. "5-fold repetition" or "White resigned"
+}
+
+func winFor(colour Colour, termination Termination, reason string) Result {
+	if colour == White {
+		return Result{Outcome: WhiteWon, Termination: termination, Reason: reason}
+	}
+	return Result{Outcome: BlackWon, Termination: termination, Reason: reason}
+}
+
+func drawBy(termination Termination, reason string) Result {
+	return Result{Outcome: Drawn, Termination: termination, Reason: reason}
+}
+
+// returns true if the game ended in a draw
+func (r Result) Draw() bool {
+	return r.Outcome == Drawn
+}
+
+// returns the winning colour, the second return value is false if there is no winner
+func (r Result) Winner() (Colour, bool) {
+	return r.Outcome.Winner()
+}
+
+// returns the result as written in PGN, "1-0", "0-1", "1/2-1/2" or "*" if there is no outcome
+func (r Result) PGN() string {
+	switch r.Outcome {
+	case WhiteWon:
+		return "1-0"
+	case BlackWon:
+		return "0-1"
+	case Drawn:
+		return "1/2-1/2"
+	default:
+		return "*"
+	}
+}
+
+func (r Result) String() string {
+	if r.Reason == "" {
+		return fmt.Sprintf("%v (%v)", r.Outcome, r.Termination)
+	}
+	return fmt.Sprintf("%v (%v)", r.Outcome, r.Reason)
+}
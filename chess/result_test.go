@@ -0,0 +1,31 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestResult_PGN(t *testing.T) {
+	cases := map[string]Result{
+		"1-0":     winFor(White, Checkmate, "Black is in mate"),
+		"0-1":     winFor(Black, Resignation, "White resigned"),
+		"1/2-1/2": drawBy(Stalemate, "Stale mate"),
+		"*":       {Termination: Abandoned},
+	}
+	for expected, result := range cases {
+		if result.PGN() != expected {
+			t.Errorf("Expected %v to be written as %v in PGN, got %v", result, expected, result.PGN())
+		}
+	}
+}
+
+func TestResult_Winner_is_optional(t *testing.T) {
+	if _, ok := drawBy(Agreement, "Draw agreed").Winner(); ok {
+		t.Errorf("Expected a draw to have no winner")
+	}
+	if winner, ok := winFor(Black, Checkmate, "White is in mate").Winner(); !ok || winner != Black {
+		t.Errorf("Expected Black to be the winner")
+	}
+	if !drawBy(Repetition, "3-fold repetition").Draw() {
+		t.Errorf("Expected repetition to be a draw")
+	}
+}
@@ -1,29 +1,25 @@
 package chess
 
 import (
-	"errors"
 	"fmt"
 )
 
 func moveRook(targetColumn string, targetRow int, b *Board, p *Piece, dryRun bool) (*MoveResult, error) {
-	if p.Type != rook {
+	if p.Type != Rook {
 		return nil, fmt.Errorf("MoveRook called on piece of type %v", p.Type)
 	}
 	if !p.InPlay {
-		return nil, errors.New("Piece is not in play")
-	}
-	if !dryRun && !p.MoveIsLegal(targetColumn, targetRow, b) {
-		return nil, fmt.Errorf("move is not legal")
+		return nil, illegalMove(PieceNotInPlay, "Piece is not in play")
 	}
 	if !p.moveIsStraight(targetColumn, targetRow) {
-		return nil, fmt.Errorf("rooks can only move straight")
+		return nil, illegalMove(InvalidPieceMove, "rooks can only move straight")
 	}
 	if _, err := p.moveJumpsOverPieces(targetColumn, targetRow, b); err != nil {
 		return nil, err
 	}
 	occupied, pieceAtTarget := b.GetPieceAtSquare(targetColumn, targetRow)
 	if occupied && !p.enemyTo(pieceAtTarget) {
-		return nil, fmt.Errorf("%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
+		return nil, illegalMove(SquareOccupied, "%v %v cant move to square %v%v, it is occupied by %v %v", p.Colour, p.Type, targetColumn, targetRow, pieceAtTarget.Colour, pieceAtTarget.Type)
 	} else if occupied && p.enemyTo(pieceAtTarget) {
 		if !dryRun {
 			p.takeAt(targetColumn, targetRow, pieceAtTarget, b)
@@ -7,7 +7,7 @@ import (
 
 func TestMoveRook_should_be_able_to_move_straight_if_not_blocked(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -18,8 +18,8 @@ func TestMoveRook_should_be_able_to_move_straight_if_not_blocked(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 4}, Square{Column: "A", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "A", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -51,7 +51,7 @@ func TestMoveRook_should_be_able_to_move_straight_if_not_blocked(t *testing.T) {
 
 func TestMoveRook_can_not_move_to_square_occupied_by_friendly(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -62,8 +62,8 @@ func TestMoveRook_can_not_move_to_square_occupied_by_friendly(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 4}, Square{Column: "A", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "A", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -83,7 +83,7 @@ func TestMoveRook_can_not_move_to_square_occupied_by_friendly(t *testing.T) {
 
 func TestMoveRook_cant_jump_over_pieces(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
 	// bP  bP  bP  bP  ..  bP  bP  bP
@@ -93,8 +93,8 @@ func TestMoveRook_cant_jump_over_pieces(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 4}, Square{Column: "A", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "A", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -116,7 +116,7 @@ func TestMoveRook_cant_jump_over_pieces(t *testing.T) {
 
 func TestMoveRook_can_not_move_diagonally(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
 	// bP  bP  bP  bP  bp  bP  bP  bP
@@ -126,8 +126,8 @@ func TestMoveRook_can_not_move_diagonally(t *testing.T) {
 	// ..  ..  ..  ..  ..  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// WR  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 4}, Square{Column: "A", Row: 5}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "A", Row: 5}}
 	moves := []Move{whiteMove1, whiteMove2}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -147,7 +147,7 @@ func TestMoveRook_can_not_move_diagonally(t *testing.T) {
 
 func TestMoveRook_can_move_horizontally(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -158,9 +158,9 @@ func TestMoveRook_can_move_horizontally(t *testing.T) {
 	// WR  ..  ..  ..  ..  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// ..  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 4}, Square{Column: "A", Row: 5}}
-	whitMove3 := Move{Square{Column: "A", Row: 1}, Square{Column: "A", Row: 3}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "A", Row: 5}}
+	whitMove3 := Move{From: Square{Column: "A", Row: 1}, To: Square{Column: "A", Row: 3}}
 	moves := []Move{whiteMove1, whiteMove2, whitMove3}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -192,7 +192,7 @@ func TestMoveRook_can_move_horizontally(t *testing.T) {
 
 func TestGetValidRookMoves(t *testing.T) {
 	defer quiet()()
-	board := newBoard()
+	board := NewBoard()
 
 	// CREATE START SCENARIO (move pawn out of the way to test the rook)
 	// BR  BN  BB  BQ  BK  BB  BN  BR
@@ -203,9 +203,9 @@ func TestGetValidRookMoves(t *testing.T) {
 	// WR  ..  ..  ..  ..  ..  ..  ..
 	// ..  wP  wP  wP  wP  wP  wP  wP
 	// ..  WN  WB  WQ  WK  WB  WN  WR
-	whiteMove1 := Move{Square{Column: "A", Row: 2}, Square{Column: "A", Row: 4}}
-	whiteMove2 := Move{Square{Column: "A", Row: 4}, Square{Column: "A", Row: 5}}
-	whitMove3 := Move{Square{Column: "A", Row: 1}, Square{Column: "A", Row: 3}}
+	whiteMove1 := Move{From: Square{Column: "A", Row: 2}, To: Square{Column: "A", Row: 4}}
+	whiteMove2 := Move{From: Square{Column: "A", Row: 4}, To: Square{Column: "A", Row: 5}}
+	whitMove3 := Move{From: Square{Column: "A", Row: 1}, To: Square{Column: "A", Row: 3}}
 	moves := []Move{whiteMove1, whiteMove2, whitMove3}
 	scenarioPrepError := prepScenario(moves, board)
 	if scenarioPrepError != nil {
@@ -0,0 +1,173 @@
+package chess
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	sanMovePattern = regexp.MustCompile(`^([NBRQK])?([a-h])?([1-8])?x?([a-h])([1-8])(?:=?([NBRQ]))?$`)
+	sanDropPattern = regexp.MustCompile(`^([PNBRQ])?@([a-h])([1-8])$`)
+	sanPieceTypes  = map[string]PieceType{"": Pawn, "P": Pawn, "N": Knight, "B": Bishop, "R": Rook, "Q": Queen, "K": King}
+)
+
+// returns the legal move for colour written in standard algebraic notation (SAN), e.g. e4, Nbd7, exd6, O-O, e8=Q
+// or N@f3 for a drop. Pawns always promote to queens, so other promotions are rejected
+func (b *Board) ParseSAN(colour Colour, san string) (Move, error) {
+	notation := strings.TrimRight(strings.TrimSpace(san), "+#!?")
+	legalMoves := b.LegalMovesFor(colour)
+
+	switch notation {
+	case "O-O", "0-0", "O-O-O", "0-0-0":
+		move, err := b.CastlingMove(colour, len(notation) == 3)
+		if err != nil {
+			return Move{}, fmt.Errorf("invalid move %q: %w", san, err)
+		}
+		if _, ok := legalMoves[move]; !ok {
+			return Move{}, fmt.Errorf("invalid move %q: castling is not legal", san)
+		}
+		return move, nil
+	}
+
+	if match := sanDropPattern.FindStringSubmatch(notation); match != nil {
+		row, _ := strconv.Atoi(match[3])
+		move := NewDrop(sanPieceTypes[match[1]], Square{strings.ToUpper(match[2]), row})
+		if _, ok := legalMoves[move]; !ok {
+			return Move{}, fmt.Errorf("invalid move %q: the drop is not legal", san)
+		}
+		return move, nil
+	}
+
+	match := sanMovePattern.FindStringSubmatch(notation)
+	if match == nil {
+		return Move{}, fmt.Errorf("invalid move %q: not in standard algebraic notation", san)
+	}
+	pieceType := sanPieceTypes[match[1]]
+	fromColumn, fromRow := strings.ToUpper(match[2]), match[3]
+	row, _ := strconv.Atoi(match[5])
+	to := Square{strings.ToUpper(match[4]), row}
+	if match[6] != "" && match[6] != "Q" {
+		return Move{}, fmt.Errorf("invalid move %q: pawns always promote to a queen", san)
+	}
+
+	candidates := []Move{}
+	for move := range legalMoves {
+		if move.Kind != PieceMove || move.To != to {
+			continue
+		}
+		if fromColumn != "" && move.From.Column != fromColumn {
+			continue
+		}
+		if fromRow != "" && strconv.Itoa(move.From.Row) != fromRow {
+			continue
+		}
+		if _, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row); piece.Type != pieceType {
+			continue
+		}
+		candidates = append(candidates, move)
+	}
+	switch len(candidates) {
+	case 0:
+		return Move{}, fmt.Errorf("invalid move %q: no %v can move to %v%v", san, pieceType, to.Column, to.Row)
+	case 1:
+		return candidates[0], nil
+	default:
+		return Move{}, fmt.Errorf("invalid move %q: more than one %v can move to %v%v", san, pieceType, to.Column, to.Row)
+	}
+}
+
+// returns the legal move for colour in standard algebraic notation (SAN), e.g. e4, Nbd7, exd6, O-O, e8=Q+ or N@f3#
+func (b *Board) MoveToSAN(colour Colour, move Move) (string, error) {
+	legalMoves := b.LegalMovesFor(colour)
+	if _, ok := legalMoves[move]; !ok {
+		return "", fmt.Errorf("%v is not a legal move for %v", move, colour)
+	}
+	san := ""
+	if move.Kind == Drop {
+		san = sanLetter(move.Piece) + "@" + sanSquare(move.To)
+	} else {
+		_, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row)
+		san = b.pieceMoveToSAN(piece, move, legalMoves)
+	}
+
+	after := b.Clone()
+	if _, err := after.makeMove(move, colour); err != nil {
+		return "", err
+	}
+	opponent := opponentOf(colour)
+	if after.IsCheck(opponent) {
+		if len(after.LegalMovesFor(opponent)) == 0 {
+			return san + "#", nil
+		}
+		return san + "+", nil
+	}
+	return san, nil
+}
+
+// returns the SAN of a move of the piece without the check or mate suffix
+func (b *Board) pieceMoveToSAN(piece *Piece, move Move, legalMoves map[Move]*MoveResult) string {
+	if piece.Type == King {
+		if side, ok := castlingSideFor(piece, move.To.Column, move.To.Row, b); ok {
+			if side == kingside {
+				return "O-O"
+			}
+			return "O-O-O"
+		}
+	}
+	occupied, _ := b.GetPieceAtSquare(move.To.Column, move.To.Row)
+	capture := occupied || (piece.Type == Pawn && move.From.Column != move.To.Column) // en passant captures an empty square
+	if piece.Type == Pawn {
+		san := ""
+		if capture {
+			san = strings.ToLower(move.From.Column) + "x"
+		}
+		san += sanSquare(move.To)
+		if move.To.Row == 1 || move.To.Row == 8 {
+			san += "=Q"
+		}
+		return san
+	}
+
+	// only the column or the row of the piece is added when that is enough to tell it apart from the others
+	ambiguous, sameColumn, sameRow := false, false, false
+	for other := range legalMoves {
+		if other.Kind != PieceMove || other.To != move.To || other.From == move.From {
+			continue
+		}
+		if _, otherPiece := b.GetPieceAtSquare(other.From.Column, other.From.Row); otherPiece.Type != piece.Type {
+			continue
+		}
+		ambiguous = true
+		sameColumn = sameColumn || other.From.Column == move.From.Column
+		sameRow = sameRow || other.From.Row == move.From.Row
+	}
+	san := sanLetter(piece.Type)
+	switch {
+	case ambiguous && !sameColumn:
+		san += strings.ToLower(move.From.Column)
+	case ambiguous && !sameRow:
+		san += strconv.Itoa(move.From.Row)
+	case ambiguous:
+		san += sanSquare(move.From)
+	}
+	if capture {
+		san += "x"
+	}
+	return san + sanSquare(move.To)
+}
+
+// returns the upper case letter of the piece type, an empty string for pawns
+func sanLetter(pieceType PieceType) string {
+	for letter, t := range sanPieceTypes {
+		if t == pieceType && letter != "P" {
+			return letter
+		}
+	}
+	return ""
+}
+
+func sanSquare(square Square) string {
+	return strings.ToLower(square.Column) + strconv.Itoa(square.Row)
+}
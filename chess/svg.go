@@ -0,0 +1,133 @@
+package chess
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	svgLightSquare   = "#f0d9b5"
+	svgDarkSquare    = "#b58863"
+	svgLastMove      = "#cdd26a"
+	svgCheck         = "#e04040"
+	svgAnnotation    = "#15781b"
+	svgDefaultSquare = 45
+)
+
+// an arrow drawn from the centre of one square to the centre of another
+type Arrow struct {
+	From Square
+	To   Square
+}
+
+// draws the board as an SVG image with coordinates, the last move and a king in check highlighted and optional
+// arrows and circles. Use Render to get the image or use it as a BoardVisualizer to write every state to Writer
+type SVGRenderer struct {
+	Writer     io.Writer // where VisualizeState writes the images, os.Stdout if nil
+	SquareSize int       // in pixels, 45 if zero
+	Flipped    bool      // black at the bottom
+	Arrows     []Arrow
+	Circles    []Square
+}
+
+func (r *SVGRenderer) VisualizeState(b *Board) {
+	writer := r.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+	fmt.Fprintln(writer, r.Render(b))
+}
+
+// returns the board as an SVG document
+func (r *SVGRenderer) Render(b *Board) string {
+	size := r.squareSize()
+	margin := size / 2
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v">`, margin+8*size, 8*size+margin, margin+8*size, 8*size+margin)
+	svg.WriteString("\n")
+	fmt.Fprintf(&svg, `<defs><marker id="arrowhead" viewBox="0 0 10 10" refX="5" refY="5" markerWidth="3" markerHeight="3" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="%v"/></marker></defs>`, svgAnnotation)
+	svg.WriteString("\n")
+
+	previousMove, hasPreviousMove := b.PreviousMove()
+	for _, square := range b.Squares {
+		x, y := r.squareOrigin(b, square)
+		fill := svgLightSquare
+		if (b.getColumnIndex(square.Column)+square.Row)%2 == 1 {
+			fill = svgDarkSquare
+		}
+		fmt.Fprintf(&svg, `<rect x="%v" y="%v" width="%v" height="%v" fill="%v"/>`, x, y, size, size, fill)
+		svg.WriteString("\n")
+		if hasPreviousMove && (square == previousMove.To || (previousMove.Kind == PieceMove && square == previousMove.From)) {
+			fmt.Fprintf(&svg, `<rect class="last-move" x="%v" y="%v" width="%v" height="%v" fill="%v" fill-opacity="0.6"/>`, x, y, size, size, svgLastMove)
+			svg.WriteString("\n")
+		}
+	}
+
+	for _, colour := range []Colour{White, Black} {
+		king := b.getKing(colour)
+		if king.Type != King || !king.InPlay {
+			continue
+		}
+		if isCheck, _ := b.kingIsInCheck(colour); isCheck {
+			x, y := r.squareOrigin(b, king.CurrentSquare)
+			fmt.Fprintf(&svg, `<circle class="check" cx="%v" cy="%v" r="%v" fill="%v" fill-opacity="0.7"/>`, x+size/2, y+size/2, size/2, svgCheck)
+			svg.WriteString("\n")
+		}
+	}
+
+	for _, pieces := range [][]Piece{b.WhitePieces, b.BlackPieces} {
+		for _, piece := range pieces {
+			if !piece.InPlay {
+				continue
+			}
+			x, y := r.squareOrigin(b, piece.CurrentSquare)
+			fmt.Fprintf(&svg, `<text class="piece" x="%v" y="%v" font-size="%v" text-anchor="middle" dominant-baseline="central">%v</text>`, x+size/2, y+size/2, size*4/5, piece.GetAbbreveation())
+			svg.WriteString("\n")
+		}
+	}
+
+	for _, square := range r.Circles {
+		x, y := r.squareOrigin(b, square)
+		fmt.Fprintf(&svg, `<circle class="annotation" cx="%v" cy="%v" r="%v" fill="none" stroke="%v" stroke-width="%v" stroke-opacity="0.8"/>`, x+size/2, y+size/2, size/2-size/16, svgAnnotation, size/10)
+		svg.WriteString("\n")
+	}
+	for _, arrow := range r.Arrows {
+		fromX, fromY := r.squareOrigin(b, arrow.From)
+		toX, toY := r.squareOrigin(b, arrow.To)
+		fmt.Fprintf(&svg, `<line class="annotation" x1="%v" y1="%v" x2="%v" y2="%v" stroke="%v" stroke-width="%v" stroke-opacity="0.8" marker-end="url(#arrowhead)"/>`, fromX+size/2, fromY+size/2, toX+size/2, toY+size/2, svgAnnotation, size/6)
+		svg.WriteString("\n")
+	}
+
+	// coordinates in the margin, letters below the board and numbers to the left of it
+	for i, column := range b.columns {
+		x, _ := r.squareOrigin(b, Square{column, 1})
+		fmt.Fprintf(&svg, `<text class="coordinate" x="%v" y="%v" font-size="%v" text-anchor="middle" dominant-baseline="central">%v</text>`, x+size/2, 8*size+margin/2, margin*3/5, strings.ToLower(b.columns[i]))
+		svg.WriteString("\n")
+	}
+	for row := 1; row <= 8; row++ {
+		_, y := r.squareOrigin(b, Square{"A", row})
+		fmt.Fprintf(&svg, `<text class="coordinate" x="%v" y="%v" font-size="%v" text-anchor="middle" dominant-baseline="central">%v</text>`, margin/2, y+size/2, margin*3/5, row)
+		svg.WriteString("\n")
+	}
+	svg.WriteString("</svg>")
+	return svg.String()
+}
+
+func (r *SVGRenderer) squareSize() int {
+	if r.SquareSize <= 0 {
+		return svgDefaultSquare
+	}
+	return r.SquareSize
+}
+
+// returns the top left corner of the square in the image
+func (r *SVGRenderer) squareOrigin(b *Board, square Square) (int, int) {
+	size := r.squareSize()
+	columnIndex := b.getColumnIndex(strings.ToUpper(square.Column))
+	if r.Flipped {
+		return size/2 + (7-columnIndex)*size, (square.Row - 1) * size
+	}
+	return size/2 + columnIndex*size, (8 - square.Row) * size
+}
@@ -0,0 +1,107 @@
+package chess
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// returns the number of elements with the given name and class, failing the test if the SVG is not valid XML
+func countSVGElements(t *testing.T, svg string, name string, class string) int {
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	count := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return count
+		}
+		if err != nil {
+			t.Fatalf("Expected valid SVG, got %v", err)
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != name {
+			continue
+		}
+		for _, attr := range element.Attr {
+			if attr.Name.Local == "class" && attr.Value == class {
+				count++
+			}
+		}
+		if class == "" {
+			count++
+		}
+	}
+}
+
+func TestSVGRenderer_starting_position(t *testing.T) {
+	svg := (&SVGRenderer{}).Render(NewBoard())
+	if !strings.HasPrefix(svg, "<svg") {
+		t.Errorf("Expected an svg element, got %q", svg[:20])
+	}
+	if n := countSVGElements(t, svg, "text", "piece"); n != 32 {
+		t.Errorf("Expected 32 pieces, got %v", n)
+	}
+	if n := countSVGElements(t, svg, "text", "coordinate"); n != 16 {
+		t.Errorf("Expected 16 coordinates, got %v", n)
+	}
+	if n := countSVGElements(t, svg, "rect", "last-move"); n != 0 {
+		t.Errorf("Expected no last move highlight before the first move, got %v", n)
+	}
+}
+
+func TestSVGRenderer_highlights_last_move_and_check(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if err := playMoves(game, []Move{
+		{From: Square{"E", 2}, To: Square{"E", 4}},
+		{From: Square{"F", 7}, To: Square{"F", 6}},
+		{From: Square{"D", 1}, To: Square{"H", 5}},
+	}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	svg := (&SVGRenderer{}).Render(game.Board)
+	if n := countSVGElements(t, svg, "rect", "last-move"); n != 2 {
+		t.Errorf("Expected the from and to squares of the last move to be highlighted, got %v", n)
+	}
+	if n := countSVGElements(t, svg, "circle", "check"); n != 1 {
+		t.Errorf("Expected the king in check to be highlighted, got %v", n)
+	}
+	// the black king on E8 is in the top row, the fifth column after the 22 pixel margin
+	if !strings.Contains(svg, `class="check" cx="224" cy="22"`) {
+		t.Errorf("Expected the check highlight on E8")
+	}
+}
+
+func TestSVGRenderer_flipped_and_annotations(t *testing.T) {
+	renderer := &SVGRenderer{
+		SquareSize: 40,
+		Flipped:    true,
+		Arrows:     []Arrow{{From: Square{"E", 2}, To: Square{"E", 4}}},
+		Circles:    []Square{{"D", 5}},
+	}
+	svg := renderer.Render(NewBoard())
+	if n := countSVGElements(t, svg, "line", "annotation"); n != 1 {
+		t.Errorf("Expected one arrow, got %v", n)
+	}
+	if n := countSVGElements(t, svg, "circle", "annotation"); n != 1 {
+		t.Errorf("Expected one circle, got %v", n)
+	}
+	// with black at the bottom E2 is in the second row from the top and the fifth column from the right
+	if !strings.Contains(svg, `x1="160" y1="60" x2="160" y2="140"`) {
+		t.Errorf("Expected the arrow to be flipped, got %v", svg)
+	}
+}
+
+func TestSVGRenderer_is_a_BoardVisualizer(t *testing.T) {
+	defer quiet()()
+	var out bytes.Buffer
+	var visualizer BoardVisualizer = &SVGRenderer{Writer: &out}
+	white := &scriptedPlayer{colour: White, moves: []Move{{From: Square{"E", 2}, To: Square{"E", 4}}}}
+	game := NewGame(white, &scriptedPlayer{colour: Black}, visualizer)
+	game.Start()
+	if n := strings.Count(out.String(), "<svg"); n < 2 {
+		t.Errorf("Expected an image for each state, got %v", n)
+	}
+}
@@ -0,0 +1,1087 @@
+package chess
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// the first bytes of Syzygy WDL (.rtbw) and DTZ (.rtbz) files
+var (
+	syzygyWDLMagic = []byte{0x71, 0xe8, 0x23, 0x5d}
+	syzygyDTZMagic = []byte{0xd7, 0x66, 0x0c, 0xa5}
+)
+
+var errSyzygyCorrupt = errors.New("the Syzygy table is corrupt")
+
+// the flags of a compressed table, all but the last one are only used by DTZ tables
+const (
+	syzygySTM         = 1 // the side to move the DTZ table stores
+	syzygyMapped      = 2 // the values are indices into the DTZ map
+	syzygyWinPlies    = 4 // wins are stored in plies instead of moves
+	syzygyLossPlies   = 8 // losses are stored in plies instead of moves
+	syzygyWide        = 16
+	syzygySingleValue = 128 // every position has the same value
+)
+
+// the Syzygy endgame tablebases (.rtbw and .rtbz files) found in one or more directories. The tables are found by
+// name, e.g. KQvK.rtbw, and read into memory the first time a position of theirs is probed. As in the reference
+// probing code the captures, and for DTZ the pawn moves, are searched before a table is probed, because the tables
+// do not store the positions where such a move is best. So probing needs the WDL tables of the endings the captures
+// lead to, and DTZ needs the WDL table of the ending too. Pawns only promote to queens, as in the rest of this
+// package
+type Syzygy struct {
+	wdl       map[string]string // the paths of the tables by material, e.g. KQvK
+	dtz       map[string]string
+	maxPieces int
+	mu        sync.Mutex
+	tables    map[string]*syzygyTable // the tables read so far by path
+}
+
+// finds the tables in the directories, which are separated like PATH (':' or ';' on Windows) as in the
+// SyzygyPath option of UCI engines
+func OpenSyzygy(path string) (*Syzygy, error) {
+	s := &Syzygy{wdl: map[string]string{}, dtz: map[string]string{}, tables: map[string]*syzygyTable{}}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			extension := strings.ToLower(filepath.Ext(entry.Name()))
+			tables, magic := s.wdl, syzygyWDLMagic
+			switch extension {
+			case ".rtbw":
+			case ".rtbz":
+				tables, magic = s.dtz, syzygyDTZMagic
+			default:
+				continue
+			}
+			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			white, black, ok := strings.Cut(name, "v")
+			if !ok || !validSyzygyMaterial(white) || !validSyzygyMaterial(black) || len(white)+len(black) > syzygyMaxPieces {
+				continue // not a table, e.g. a renamed file
+			}
+			file := filepath.Join(dir, entry.Name())
+			if err := checkSyzygyMagic(file, magic); err != nil {
+				return nil, err
+			}
+			tables[name] = file
+			if pieces := len(white) + len(black); pieces > s.maxPieces {
+				s.maxPieces = pieces
+			}
+		}
+	}
+	return s, nil
+}
+
+// returns whether the side of a table name is a king followed by pieces in the order QRBNP, e.g. KRP
+func validSyzygyMaterial(side string) bool {
+	if !strings.HasPrefix(side, "K") {
+		return false
+	}
+	previous := 1
+	for _, letter := range side[1:] {
+		order := strings.IndexRune("KQRBNP", letter)
+		if order < previous {
+			return false
+		}
+		previous = order
+	}
+	return true
+}
+
+func checkSyzygyMagic(file string, magic []byte) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(f, header); err != nil || !bytes.Equal(header, magic) {
+		return fmt.Errorf("%v is not a Syzygy table", file)
+	}
+	return nil
+}
+
+// returns the number of pieces of the largest table, kings included
+func (s *Syzygy) MaxPieces() int {
+	return s.maxPieces
+}
+
+// returns the names of the tables found, e.g. KQvK, WDL and DTZ tables alike
+func (s *Syzygy) Tables() []string {
+	names := []string{}
+	for name := range s.wdl {
+		names = append(names, name)
+	}
+	for name := range s.dtz {
+		if _, ok := s.wdl[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Syzygy) ProbeWDL(b *Board, colour Colour) (WDL, error) {
+	if !inTablebase(s, b) {
+		return WDLDraw, ErrNotInTablebase
+	}
+	wdl, _, err := s.search(b, colour, false)
+	return wdl, err
+}
+
+// returns the distance to zeroing in plies, a capture, pawn move or mate ends it. It is -1 when colour is mated and
+// 100 plies longer for cursed wins and blessed losses. Wins are rounded up by a ply when the table stores moves
+func (s *Syzygy) ProbeDTZ(b *Board, colour Colour) (int, error) {
+	if !inTablebase(s, b) {
+		return 0, ErrNotInTablebase
+	}
+	return s.probeDTZ(b, colour)
+}
+
+func (s *Syzygy) probeDTZ(b *Board, colour Colour) (int, error) {
+	wdl, zeroing, err := s.search(b, colour, true)
+	if err != nil || wdl == WDLDraw {
+		return 0, err // DTZ tables do not store draws
+	}
+	if zeroing {
+		return dtzBeforeZeroing(wdl), nil
+	}
+	table, err := s.table(b, true)
+	if err != nil {
+		return 0, err
+	}
+	dtz, stored, err := table.probe(b, colour, wdl)
+	if err != nil {
+		return 0, err
+	}
+	if stored {
+		if wdl == WDLCursedWin || wdl == WDLBlessedLoss {
+			dtz += 100
+		}
+		return dtz * sign(int(wdl)), nil
+	}
+	// the table stores the other side to move, so the move that keeps the outcome the longest or shortest is searched
+	opponent := opponentOf(colour)
+	best := 0xFFFF
+	for move, result := range b.LegalMovesFor(colour) {
+		_, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row)
+		zeroing := result.Action == Take || piece.Type == Pawn
+		child := b.Clone()
+		if _, err := child.makeMove(move, colour); err != nil {
+			return 0, err
+		}
+		if zeroing {
+			// the distance before the move, the position after it only tells whether it keeps the outcome
+			childWDL, _, err := s.search(child, opponent, false)
+			if err != nil {
+				return 0, err
+			}
+			dtz = -dtzBeforeZeroing(childWDL)
+		} else {
+			childDTZ, err := s.probeDTZ(child, opponent)
+			if err != nil {
+				return 0, err
+			}
+			dtz = -childDTZ
+		}
+		if dtz == 1 && child.IsCheck(opponent) && len(child.LegalMovesFor(opponent)) == 0 {
+			best = 1 // mates
+		}
+		if !zeroing {
+			dtz += sign(dtz)
+		}
+		if dtz < best && sign(dtz) == sign(int(wdl)) {
+			best = dtz
+		}
+	}
+	if best == 0xFFFF {
+		return -1, nil // mated
+	}
+	return best, nil
+}
+
+// the distance to zeroing of a position where a zeroing move keeps the outcome
+func dtzBeforeZeroing(wdl WDL) int {
+	switch wdl {
+	case WDLWin:
+		return 1
+	case WDLCursedWin:
+		return 101
+	case WDLBlessedLoss:
+		return -101
+	case WDLLoss:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// returns the outcome for colour to move. The captures, and with zeroing also the pawn moves, are searched before
+// the table is probed, as it stores any value where one of them is best (e.g. when an en passant capture wins). The
+// second return value is whether a zeroing move is best, then the DTZ table can not be probed
+func (s *Syzygy) search(b *Board, colour Colour, zeroing bool) (WDL, bool, error) {
+	moves := b.LegalMovesFor(colour)
+	best, searched := WDLLoss, 0
+	for move, result := range moves {
+		if result.Action != Take {
+			if _, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row); !zeroing || piece.Type != Pawn {
+				continue
+			}
+		}
+		searched++
+		child := b.Clone()
+		if _, err := child.makeMove(move, colour); err != nil {
+			return WDLDraw, false, err
+		}
+		value, _, err := s.search(child, opponentOf(colour), false)
+		if err != nil {
+			return WDLDraw, false, err
+		}
+		if value = -value; value > best {
+			best = value
+			if value >= WDLWin {
+				return value, true, nil
+			}
+		}
+	}
+	noMoreMoves := searched > 0 && searched == len(moves)
+	value := best
+	if !noMoreMoves {
+		var err error
+		if value, err = s.probeWDLTable(b, colour); err != nil {
+			return WDLDraw, false, err
+		}
+	}
+	if best >= value {
+		return best, best > WDLDraw || noMoreMoves, nil
+	}
+	return value, false, nil
+}
+
+func (s *Syzygy) probeWDLTable(b *Board, colour Colour) (WDL, error) {
+	if pieceCount(b) == 2 {
+		return WDLDraw, nil // only the kings
+	}
+	table, err := s.table(b, false)
+	if err != nil {
+		return WDLDraw, err
+	}
+	value, _, err := table.probe(b, colour, WDLDraw)
+	return WDL(value), err
+}
+
+// returns the table of the position, read from its file the first time. Tables are named with the strong side
+// first
+func (s *Syzygy) table(b *Board, dtz bool) (*syzygyTable, error) {
+	paths := s.wdl
+	if dtz {
+		paths = s.dtz
+	}
+	white, black := tablebaseMaterial(b)
+	name := white + "v" + black
+	path, ok := paths[name]
+	if !ok {
+		name = black + "v" + white
+		if path, ok = paths[name]; !ok {
+			return nil, ErrNotInTablebase
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if table, ok := s.tables[path]; ok {
+		return table, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	table := newSyzygyTable(name, dtz)
+	if err := table.read(data); err != nil {
+		return nil, fmt.Errorf("%v: %w", filepath.Base(path), err)
+	}
+	s.tables[path] = table
+	return table, nil
+}
+
+// the most pieces a Syzygy table can have
+const syzygyMaxPieces = 7
+
+// a WDL or DTZ table of an ending. Positions with pawns are stored in a table per file (a to d) of the leading
+// pawn, WDL tables of endings that are not symmetric store both sides to move and DTZ tables only one
+type syzygyTable struct {
+	dtz             bool
+	white           string // the material of the strong side, e.g. KQ
+	black           string
+	pieceCount      int
+	hasPawns        bool
+	hasUniquePieces bool   // a side has a piece (not the king) it has only one of
+	pawnCount       [2]int // of the leading side and the other one, the side with fewer pawns leads
+	sides           int
+	pairs           [2][4]*syzygyPairs // by side to move and file
+	data            []byte
+	dtzMap          int // offset of the DTZ map in data
+}
+
+// a compressed table: the positions are indexed in groups of pieces and their values are stored in blocks of
+// Huffman coded symbols, each of which stands for one or more values
+type syzygyPairs struct {
+	flags           byte
+	pieces          [syzygyMaxPieces]int // the order of the pieces, which defines the groups
+	groupLen        [syzygyMaxPieces + 1]int
+	groupIdx        [syzygyMaxPieces + 1]uint64
+	blockSize       int
+	span            uint64 // every span values there is an entry in the sparse index
+	numBlocks       int
+	blockLengthSize int
+	sparseIndexSize uint64
+	minSymLen       int // the value itself in single value tables
+	lowestSym       []uint64
+	base64          []uint64 // the lowest code of each symbol length, left aligned
+	symlen          []int    // the number of values a symbol stands for minus one
+	btree           [][2]int // the left and right symbols a symbol stands for, the value of a leaf is on the left
+	sparseIndex     int      // offsets in the table data
+	blockLengths    int
+	blocks          int
+	mapIdx          [4]int // the lists of the DTZ map for wins, losses, cursed wins and blessed losses
+}
+
+func newSyzygyTable(name string, dtz bool) *syzygyTable {
+	white, black, _ := strings.Cut(name, "v")
+	t := &syzygyTable{dtz: dtz, white: white, black: black, pieceCount: len(white) + len(black), sides: 1}
+	whitePawns, blackPawns := strings.Count(white, "P"), strings.Count(black, "P")
+	t.hasPawns = whitePawns+blackPawns > 0
+	for _, side := range []string{white, black} {
+		for _, letter := range "QRBNP" {
+			if strings.Count(side, string(letter)) == 1 {
+				t.hasUniquePieces = true
+			}
+		}
+	}
+	t.pawnCount = [2]int{whitePawns, blackPawns}
+	if blackPawns > 0 && (whitePawns == 0 || blackPawns < whitePawns) {
+		t.pawnCount = [2]int{blackPawns, whitePawns}
+	}
+	if !dtz && white != black {
+		t.sides = 2
+	}
+	return t
+}
+
+func (t *syzygyTable) files() int {
+	if t.hasPawns {
+		return 4
+	}
+	return 1
+}
+
+// reads the layout of the table: the pieces and groups, the Huffman codes and where the sparse index, the block
+// lengths and the blocks are
+func (t *syzygyTable) read(data []byte) error {
+	magic := syzygyWDLMagic
+	if t.dtz {
+		magic = syzygyDTZMagic
+	}
+	if len(data) < 5 || !bytes.Equal(data[:4], magic) {
+		return errors.New("not a Syzygy table")
+	}
+	t.data = data
+	r := &syzygyReader{data: data, pos: 4}
+	flags := r.byte()
+	if flags&2 != 0 != t.hasPawns || flags&1 != 0 != (t.white != t.black) {
+		return errors.New("the table does not have the material of its name")
+	}
+	bothPawns := t.hasPawns && t.pawnCount[1] > 0
+	for f := 0; f < t.files(); f++ {
+		first, second := r.byte(), byte(0xff)
+		if bothPawns {
+			second = r.byte()
+		}
+		order := [2][2]int{{int(first & 0xf), int(second & 0xf)}, {int(first >> 4), int(second >> 4)}}
+		for i := 0; i < t.sides; i++ {
+			t.pairs[i][f] = &syzygyPairs{}
+		}
+		for k := 0; k < t.pieceCount; k++ {
+			code := r.byte()
+			t.pairs[0][f].pieces[k] = int(code & 0xf)
+			if t.sides == 2 {
+				t.pairs[1][f].pieces[k] = int(code >> 4)
+			}
+		}
+		for i := 0; i < t.sides; i++ {
+			if err := t.setGroups(t.pairs[i][f], order[i], f); err != nil {
+				return err
+			}
+		}
+	}
+	r.align(2)
+	for f := 0; f < t.files(); f++ {
+		for i := 0; i < t.sides; i++ {
+			if err := r.sizes(t.pairs[i][f]); err != nil {
+				return err
+			}
+		}
+	}
+	if t.dtz {
+		t.readDTZMap(r)
+	}
+	for f := 0; f < t.files(); f++ {
+		for i := 0; i < t.sides; i++ {
+			t.pairs[i][f].sparseIndex = r.pos
+			r.skip(int(t.pairs[i][f].sparseIndexSize) * 6)
+		}
+	}
+	for f := 0; f < t.files(); f++ {
+		for i := 0; i < t.sides; i++ {
+			t.pairs[i][f].blockLengths = r.pos
+			r.skip(t.pairs[i][f].blockLengthSize * 2)
+		}
+	}
+	for f := 0; f < t.files(); f++ {
+		for i := 0; i < t.sides; i++ {
+			r.align(64)
+			t.pairs[i][f].blocks = r.pos
+			r.skip(t.pairs[i][f].numBlocks * t.pairs[i][f].blockSize)
+		}
+	}
+	if r.err != nil || r.pos > len(data) {
+		return errors.New("the table is truncated")
+	}
+	return nil
+}
+
+// sets the groups the pieces are indexed in and where each group starts in the index. The kings and a unique piece
+// (or only the kings) lead without pawns, the leading pawns with pawns. The order tells where the leading group and
+// the remaining pawns are in the index
+func (t *syzygyTable) setGroups(p *syzygyPairs, order [2]int, file int) error {
+	n, firstLen := 0, 2
+	if t.hasPawns {
+		firstLen = 0
+	} else if t.hasUniquePieces {
+		firstLen = 3
+	}
+	p.groupLen[n] = 1
+	for i := 1; i < t.pieceCount; i++ {
+		if firstLen--; firstLen > 0 || p.pieces[i] == p.pieces[i-1] {
+			p.groupLen[n]++
+		} else {
+			n++
+			p.groupLen[n] = 1
+		}
+	}
+	n++
+	p.groupLen[n] = 0
+	bothPawns := t.hasPawns && t.pawnCount[1] > 0
+	next := 1
+	if bothPawns {
+		next = 2
+	}
+	freeSquares := 64 - p.groupLen[0]
+	if bothPawns {
+		freeSquares -= p.groupLen[1]
+	}
+	index := uint64(1)
+	for k := 0; next < n || k == order[0] || k == order[1]; k++ {
+		if p.groupLen[0] > 5 || (k > syzygyMaxPieces+1 && k != order[0] && k != order[1]) {
+			return errSyzygyCorrupt
+		}
+		switch {
+		case k == order[0]: // the leading pawns or pieces
+			p.groupIdx[0] = index
+			switch {
+			case t.hasPawns:
+				index *= syzygyLeadPawnsSize[p.groupLen[0]][file]
+			case t.hasUniquePieces:
+				index *= 31332
+			default:
+				index *= 462
+			}
+		case k == order[1]: // the remaining pawns
+			p.groupIdx[1] = index
+			index *= syzygyBinomial[p.groupLen[1]][48-p.groupLen[0]]
+		default: // the remaining pieces
+			if next >= n || p.groupLen[next] > 5 || freeSquares < 0 {
+				return errSyzygyCorrupt
+			}
+			p.groupIdx[next] = index
+			index *= syzygyBinomial[p.groupLen[next]][freeSquares]
+			freeSquares -= p.groupLen[next]
+			next++
+		}
+	}
+	p.groupIdx[n] = index
+	return nil
+}
+
+// the DTZ map turns the stored values into distances, with a list per file for each outcome
+func (t *syzygyTable) readDTZMap(r *syzygyReader) {
+	t.dtzMap = r.pos
+	for f := 0; f < t.files(); f++ {
+		p := t.pairs[0][f]
+		if p.flags&syzygyMapped == 0 {
+			continue
+		}
+		for i := 0; i < 4; i++ {
+			if p.flags&syzygyWide != 0 {
+				r.align(2)
+				p.mapIdx[i] = (r.pos-t.dtzMap)/2 + 1
+				r.skip(2 * int(r.u16()))
+			} else {
+				p.mapIdx[i] = r.pos - t.dtzMap + 1
+				r.skip(int(r.byte()))
+			}
+		}
+	}
+	r.align(2)
+}
+
+// returns the value of the position with colour to move, the outcome for WDL tables and the distance for DTZ
+// tables, which need the outcome. The second return value is false when a DTZ table stores the other side to move
+func (t *syzygyTable) probe(b *Board, colour Colour, wdl WDL) (int, bool, error) {
+	p, index, stored := t.index(syzygyPieces(b), colour)
+	if !stored {
+		return 0, false, nil
+	}
+	value, err := p.decompress(t.data, index)
+	if err != nil {
+		return 0, false, err
+	}
+	if !t.dtz {
+		return value - 2, true, nil
+	}
+	value, err = t.mapScore(p, value, wdl)
+	return value, err == nil, err
+}
+
+// returns the distance in plies of a value of the DTZ table, for the outcome of the position
+func (t *syzygyTable) mapScore(p *syzygyPairs, value int, wdl WDL) (int, error) {
+	if p.flags&syzygyMapped != 0 {
+		list := p.mapIdx[[]int{1, 3, 0, 2, 0}[wdl+2]]
+		if p.flags&syzygyWide != 0 {
+			offset := t.dtzMap + 2*(list+value)
+			if offset+2 > len(t.data) {
+				return 0, errSyzygyCorrupt
+			}
+			value = int(binary.LittleEndian.Uint16(t.data[offset:]))
+		} else {
+			offset := t.dtzMap + list + value
+			if offset >= len(t.data) {
+				return 0, errSyzygyCorrupt
+			}
+			value = int(t.data[offset])
+		}
+	}
+	if (wdl == WDLWin && p.flags&syzygyWinPlies == 0) || (wdl == WDLLoss && p.flags&syzygyLossPlies == 0) ||
+		wdl == WDLCursedWin || wdl == WDLBlessedLoss {
+		value *= 2 // stored in moves
+	}
+	return value + 1, nil
+}
+
+// returns the pieces by square (0 is a1, 63 is h8) as Syzygy codes them: 1 to 6 for the white pawn, knight,
+// bishop, rook, queen and king, 9 to 14 for the black ones and 0 for an empty square
+func syzygyPieces(b *Board) [64]int {
+	var pieces [64]int
+	for _, side := range [][]Piece{b.WhitePieces, b.BlackPieces} {
+		for _, piece := range side {
+			if !piece.InPlay {
+				continue
+			}
+			code := syzygyPieceCodes[piece.Type]
+			if piece.Colour == Black {
+				code += 8
+			}
+			pieces[b.getColumnIndex(piece.CurrentSquare.Column)+8*(piece.CurrentSquare.Row-1)] = code
+		}
+	}
+	return pieces
+}
+
+var syzygyPieceCodes = map[PieceType]int{Pawn: 1, Knight: 2, Bishop: 3, Rook: 4, Queen: 5, King: 6}
+
+// returns the material of a side of the pieces as tables name it, e.g. KRP, colour is 0 for white and 8 for black
+func syzygyMaterial(pieces [64]int, colour int) string {
+	var text strings.Builder
+	for _, code := range []int{6, 5, 4, 3, 2, 1} {
+		for _, piece := range pieces {
+			if piece == code+colour {
+				text.WriteByte("PNBRQK"[code-1])
+			}
+		}
+	}
+	return text.String()
+}
+
+// returns the compressed table of the position with colour to move and the index of the position in it. The
+// board is mirrored when black has the material of the strong side, or for symmetric endings when black is to
+// move, and then mirrored and flipped so the leading piece is in the a1-d1-d4 triangle, or the leading pawn on
+// the files a to d. It returns false when the DTZ table stores the other side to move
+func (t *syzygyTable) index(board [64]int, colour Colour) (*syzygyPairs, uint64, bool) {
+	flip := (t.white == t.black && colour == Black) || syzygyMaterial(board, 0) != t.white
+	flipColour, flipSquares, stm := 0, 0, int(colour)
+	if flip {
+		flipColour, flipSquares, stm = 8, 56, stm^1
+	}
+	var squares, pieces [syzygyMaxPieces]int
+	size, leadPawns, file := 0, 0, 0
+	var lead [64]bool
+	if t.hasPawns {
+		// the pawns of the leading side, the one most towards the edge and the first rank leads
+		pawn := t.pairs[0][0].pieces[0] ^ flipColour
+		for square, piece := range board {
+			if piece == pawn && size < syzygyMaxPieces {
+				squares[size], lead[square] = square^flipSquares, true
+				size++
+			}
+		}
+		leadPawns = size
+		for i := 1; i < leadPawns; i++ {
+			if syzygyMapPawns[squares[i]] > syzygyMapPawns[squares[0]] {
+				squares[0], squares[i] = squares[i], squares[0]
+			}
+		}
+		if file = squares[0] & 7; file > 3 {
+			file = 7 - file
+		}
+	}
+	if t.dtz && int(t.pairs[0][file].flags&syzygySTM) != stm && (t.white != t.black || t.hasPawns) {
+		return nil, 0, false
+	}
+	for square, piece := range board {
+		if piece != 0 && !lead[square] && size < syzygyMaxPieces {
+			squares[size], pieces[size] = square^flipSquares, piece^flipColour
+			size++
+		}
+	}
+	p := t.pairs[stm%t.sides][file]
+	// the pieces in the order of the table
+	for i := leadPawns; i < size-1; i++ {
+		for j := i + 1; j < size; j++ {
+			if p.pieces[i] == pieces[j] {
+				pieces[i], pieces[j] = pieces[j], pieces[i]
+				squares[i], squares[j] = squares[j], squares[i]
+				break
+			}
+		}
+	}
+	if squares[0]&7 > 3 {
+		for i := 0; i < size; i++ {
+			squares[i] ^= 7
+		}
+	}
+	var index uint64
+	if t.hasPawns {
+		index = syzygyLeadPawnIdx[leadPawns][squares[0]]
+		others := squares[1:leadPawns]
+		sort.SliceStable(others, func(i, j int) bool { return syzygyMapPawns[others[i]] < syzygyMapPawns[others[j]] })
+		for i := 1; i < leadPawns; i++ {
+			index += syzygyBinomial[i][syzygyMapPawns[squares[i]]]
+		}
+	} else {
+		if squares[0]>>3 > 3 {
+			for i := 0; i < size; i++ {
+				squares[i] ^= 56
+			}
+		}
+		// the first piece of the leading group off the a1-h8 diagonal goes below it
+		for i := 0; i < p.groupLen[0]; i++ {
+			if syzygyDiagonal(squares[i]) == 0 {
+				continue
+			}
+			if syzygyDiagonal(squares[i]) > 0 {
+				for j := i; j < size; j++ {
+					squares[j] = (squares[j]>>3 | squares[j]<<3) & 63
+				}
+			}
+			break
+		}
+		if t.hasUniquePieces {
+			index = syzygyUniqueIndex(squares[0], squares[1], squares[2])
+		} else {
+			index = uint64(syzygyMapKK[syzygyMapA1D1D4[squares[0]]][squares[1]])
+		}
+	}
+	index *= p.groupIdx[0]
+	// the other groups by their squares in ascending order, leaving out the squares of the groups before them
+	start, remainingPawns := p.groupLen[0], t.hasPawns && t.pawnCount[1] > 0
+	for next := 1; p.groupLen[next] != 0; next++ {
+		group := squares[start : start+p.groupLen[next]]
+		sort.Ints(group)
+		var n uint64
+		for i, square := range group {
+			adjust := 0
+			for _, before := range squares[:start] {
+				if square > before {
+					adjust++
+				}
+			}
+			if remainingPawns {
+				adjust += 8
+			}
+			n += syzygyBinomial[i+1][square-adjust]
+		}
+		remainingPawns = false
+		index += n * p.groupIdx[next]
+		start += p.groupLen[next]
+	}
+	return p, index, true
+}
+
+// returns the index of three unique leading pieces, the first in the a1-d1-d4 triangle
+func syzygyUniqueIndex(first int, second int, third int) uint64 {
+	adjust1, adjust2 := 0, 0
+	if second > first {
+		adjust1 = 1
+	}
+	if third > first {
+		adjust2++
+	}
+	if third > second {
+		adjust2++
+	}
+	switch {
+	case syzygyDiagonal(first) != 0:
+		return uint64((syzygyMapA1D1D4[first]*63+second-adjust1)*62 + third - adjust2)
+	case syzygyDiagonal(second) != 0:
+		return uint64((6*63+(first>>3)*28+syzygyMapB1H1H7[second])*62 + third - adjust2)
+	case syzygyDiagonal(third) != 0:
+		return uint64(6*63*62 + 4*28*62 + (first>>3)*7*28 + ((second>>3)-adjust1)*28 + syzygyMapB1H1H7[third])
+	default:
+		return uint64(6*63*62 + 4*28*62 + 4*7*28 + (first>>3)*7*6 + ((second>>3)-adjust1)*6 + (third >> 3) - adjust2)
+	}
+}
+
+// returns how far the square is above the a1-h8 diagonal, negative below it
+func syzygyDiagonal(square int) int {
+	return square>>3 - square&7
+}
+
+// returns the value at the index: the block that has it is found from the sparse index, then the symbols of the
+// block are decoded until the one that stands for it, which is expanded into its pair of symbols until a value
+func (p *syzygyPairs) decompress(data []byte, index uint64) (int, error) {
+	if p.flags&syzygySingleValue != 0 {
+		return p.minSymLen, nil
+	}
+	k := index / p.span
+	if k >= p.sparseIndexSize {
+		return 0, errSyzygyCorrupt
+	}
+	entry := p.sparseIndex + int(k)*6
+	block := int(binary.LittleEndian.Uint32(data[entry:]))
+	offset := int(binary.LittleEndian.Uint16(data[entry+4:]))
+	// the entry is for the value in the middle of the span
+	offset += int(index%p.span) - int(p.span/2)
+	blockLength := func(block int) (int, error) {
+		if block < 0 || block >= p.blockLengthSize {
+			return 0, errSyzygyCorrupt
+		}
+		return int(binary.LittleEndian.Uint16(data[p.blockLengths+2*block:])), nil
+	}
+	for offset < 0 {
+		block--
+		length, err := blockLength(block)
+		if err != nil {
+			return 0, err
+		}
+		offset += length + 1
+	}
+	for {
+		length, err := blockLength(block)
+		if err != nil {
+			return 0, err
+		}
+		if offset <= length {
+			break
+		}
+		offset -= length + 1
+		block++
+	}
+	if block >= p.numBlocks {
+		return 0, errSyzygyCorrupt
+	}
+	position := p.blocks + block*p.blockSize
+	buffer, bits := syzygyBigEndian(data, position, 8), 64
+	position += 8
+	var symbol int
+	for {
+		length := 0 // above the shortest
+		for length < len(p.base64)-1 && buffer < p.base64[length] {
+			length++
+		}
+		symbol = int((buffer-p.base64[length])>>(64-length-p.minSymLen)) + int(p.lowestSym[length])
+		if symbol >= len(p.symlen) {
+			return 0, errSyzygyCorrupt
+		}
+		if offset < p.symlen[symbol]+1 {
+			break
+		}
+		offset -= p.symlen[symbol] + 1
+		length += p.minSymLen
+		buffer <<= length
+		if bits -= length; bits <= 32 {
+			bits += 32
+			buffer |= syzygyBigEndian(data, position, 4) << (64 - bits)
+			position += 4
+		}
+	}
+	for p.symlen[symbol] != 0 {
+		left := p.btree[symbol][0]
+		if offset < p.symlen[left]+1 {
+			symbol = left
+		} else {
+			offset -= p.symlen[left] + 1
+			symbol = p.btree[symbol][1]
+		}
+	}
+	return p.btree[symbol][0], nil
+}
+
+// reads n bytes at the position as a big endian number, bytes past the end of the data are 0
+func syzygyBigEndian(data []byte, position int, n int) uint64 {
+	var value uint64
+	for i := 0; i < n; i++ {
+		value <<= 8
+		if position+i < len(data) {
+			value |= uint64(data[position+i])
+		}
+	}
+	return value
+}
+
+// reads the header of a table, reading past the end sets err
+type syzygyReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *syzygyReader) skip(n int) {
+	r.pos += n
+	if r.pos > len(r.data) {
+		r.err = errors.New("the table is truncated")
+	}
+}
+
+func (r *syzygyReader) byte() byte {
+	if r.skip(1); r.err != nil {
+		return 0
+	}
+	return r.data[r.pos-1]
+}
+
+func (r *syzygyReader) u16() uint16 {
+	if r.skip(2); r.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(r.data[r.pos-2:])
+}
+
+func (r *syzygyReader) u32() uint32 {
+	if r.skip(4); r.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(r.data[r.pos-4:])
+}
+
+func (r *syzygyReader) align(n int) {
+	if rest := r.pos % n; rest != 0 {
+		r.skip(n - rest)
+	}
+}
+
+// reads the sizes of a compressed table and its Huffman code: the lowest symbol of each code length, shorter codes
+// have higher symbols, and the pair of symbols each symbol stands for
+func (r *syzygyReader) sizes(p *syzygyPairs) error {
+	p.flags = r.byte()
+	if p.flags&syzygySingleValue != 0 {
+		p.minSymLen = int(r.byte())
+		return r.err
+	}
+	size := p.groupIdx[0]
+	for i := 0; i < len(p.groupLen); i++ {
+		if p.groupLen[i] == 0 {
+			size = p.groupIdx[i]
+			break
+		}
+	}
+	blockSizeBits, spanBits := r.byte(), r.byte()
+	if blockSizeBits < 3 || blockSizeBits > 24 || spanBits < 1 || spanBits > 24 {
+		return errSyzygyCorrupt
+	}
+	p.blockSize, p.span = 1<<blockSizeBits, 1<<spanBits
+	p.sparseIndexSize = (size + p.span - 1) / p.span
+	padding := int(r.byte())
+	p.numBlocks = int(r.u32())
+	p.blockLengthSize = p.numBlocks + padding
+	maxSymLen, minSymLen := int(r.byte()), int(r.byte())
+	if minSymLen < 1 || maxSymLen < minSymLen || maxSymLen > 32 {
+		return errSyzygyCorrupt
+	}
+	p.minSymLen = minSymLen
+	lengths := maxSymLen - minSymLen + 1
+	p.lowestSym, p.base64 = make([]uint64, lengths), make([]uint64, lengths)
+	for i := range p.lowestSym {
+		p.lowestSym[i] = uint64(r.u16())
+	}
+	for i := lengths - 2; i >= 0; i-- {
+		if p.lowestSym[i] < p.lowestSym[i+1] {
+			return errSyzygyCorrupt
+		}
+		p.base64[i] = (p.base64[i+1] + p.lowestSym[i] - p.lowestSym[i+1]) / 2
+	}
+	for i := range p.base64 {
+		p.base64[i] <<= 64 - i - p.minSymLen
+	}
+	symbols := int(r.u16())
+	p.btree, p.symlen = make([][2]int, symbols), make([]int, symbols)
+	for i := range p.btree {
+		lr := []byte{r.byte(), r.byte(), r.byte()}
+		p.btree[i] = [2]int{int(lr[1]&0xf)<<8 | int(lr[0]), int(lr[2])<<4 | int(lr[1]>>4)}
+	}
+	if symbols%2 == 1 {
+		r.byte()
+	}
+	if r.err != nil {
+		return r.err
+	}
+	visited := make([]bool, symbols)
+	for symbol := range p.symlen {
+		if !visited[symbol] {
+			length, err := p.setSymlen(symbol, visited)
+			if err != nil {
+				return err
+			}
+			p.symlen[symbol] = length
+		}
+	}
+	return nil
+}
+
+// returns the number of values minus one the symbol stands for, symbols that stand for a value have no right symbol
+func (p *syzygyPairs) setSymlen(symbol int, visited []bool) (int, error) {
+	visited[symbol] = true
+	left, right := p.btree[symbol][0], p.btree[symbol][1]
+	if right == 0xfff {
+		return 0, nil
+	}
+	for _, child := range []int{left, right} {
+		if child >= len(p.symlen) {
+			return 0, errSyzygyCorrupt
+		}
+		if !visited[child] {
+			length, err := p.setSymlen(child, visited)
+			if err != nil {
+				return 0, err
+			}
+			p.symlen[child] = length
+		} else if p.btree[child][1] != 0xfff && p.symlen[child] == 0 {
+			return 0, errSyzygyCorrupt // a cycle
+		}
+	}
+	return p.symlen[left] + p.symlen[right] + 1, nil
+}
+
+// the tables of the Syzygy index, as in the reference probing code
+var (
+	syzygyMapPawns      [64]int // the squares a2 to h7 by how many squares the other pawns have when it leads
+	syzygyMapB1H1H7     [64]int // the squares below the a1-h8 diagonal
+	syzygyMapA1D1D4     [64]int // the squares of the a1-d1-d4 triangle, the diagonal last
+	syzygyMapKK         [10][64]int
+	syzygyBinomial      [7][64]uint64 // the ways to choose k of n squares by k and n
+	syzygyLeadPawnIdx   [6][64]uint64
+	syzygyLeadPawnsSize [6][4]uint64 // by the number of leading pawns and the file of the leading one
+)
+
+func init() {
+	code := 0
+	for square := 0; square < 64; square++ {
+		if syzygyDiagonal(square) < 0 {
+			syzygyMapB1H1H7[square] = code
+			code++
+		}
+	}
+	code = 0
+	diagonal := []int{}
+	for square := 0; square <= 27; square++ {
+		if square&7 > 3 {
+			continue
+		}
+		if syzygyDiagonal(square) < 0 {
+			syzygyMapA1D1D4[square] = code
+			code++
+		} else if syzygyDiagonal(square) == 0 {
+			diagonal = append(diagonal, square)
+		}
+	}
+	for _, square := range diagonal {
+		syzygyMapA1D1D4[square] = code
+		code++
+	}
+	// the 462 ways to place two kings apart with the first in the triangle and, when it is on the diagonal, the
+	// second not above it. Both on the diagonal come last
+	code = 0
+	bothOnDiagonal := [][2]int{}
+	for idx := 0; idx < 10; idx++ {
+		for first := 0; first <= 27; first++ {
+			if syzygyMapA1D1D4[first] != idx || (idx == 0 && first != 1) || first&7 > 3 {
+				continue
+			}
+			for second := 0; second < 64; second++ {
+				fileDistance, rankDistance := first&7-second&7, first>>3-second>>3
+				switch {
+				case fileDistance >= -1 && fileDistance <= 1 && rankDistance >= -1 && rankDistance <= 1:
+				case syzygyDiagonal(first) == 0 && syzygyDiagonal(second) > 0:
+				case syzygyDiagonal(first) == 0 && syzygyDiagonal(second) == 0:
+					bothOnDiagonal = append(bothOnDiagonal, [2]int{idx, second})
+				default:
+					syzygyMapKK[idx][second] = code
+					code++
+				}
+			}
+		}
+	}
+	for _, kings := range bothOnDiagonal {
+		syzygyMapKK[kings[0]][kings[1]] = code
+		code++
+	}
+	syzygyBinomial[0][0] = 1
+	for n := 1; n < 64; n++ {
+		for k := 0; k < len(syzygyBinomial) && k <= n; k++ {
+			if k > 0 {
+				syzygyBinomial[k][n] += syzygyBinomial[k-1][n-1]
+			}
+			if k < n {
+				syzygyBinomial[k][n] += syzygyBinomial[k][n-1]
+			}
+		}
+	}
+	available := 47
+	for leadPawns := 1; leadPawns <= 5; leadPawns++ {
+		for file := 0; file < 4; file++ {
+			var idx uint64
+			for rank := 1; rank <= 6; rank++ {
+				square := file + 8*rank
+				if leadPawns == 1 {
+					syzygyMapPawns[square] = available
+					syzygyMapPawns[square^7] = available - 1
+					available -= 2
+				}
+				syzygyLeadPawnIdx[leadPawns][square] = idx
+				idx += syzygyBinomial[leadPawns-1][syzygyMapPawns[square]]
+			}
+			syzygyLeadPawnsSize[leadPawns][file] = idx
+		}
+	}
+}
@@ -0,0 +1,759 @@
+package chess
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// writes a table in the Syzygy format with the values positions gives: the outcome for WDL tables and the signed
+// distance to zeroing in plies for DTZ tables, which store white to move only. The pieces are in the order of the
+// name with the leading pawns first, a few frequent pairs of values get a symbol of their own and the symbols get
+// canonical Huffman codes. Positions that are not given are stored as 0
+func writeSyzygyTable(name string, dtz bool, mapped bool, positions func(visit func(board [64]int, colour Colour, value int))) ([]byte, error) {
+	t := newSyzygyTable(name, dtz)
+	codes := []int{}
+	for i, side := range []string{t.white, t.black} {
+		for _, letter := range side {
+			codes = append(codes, strings.IndexRune("PNBRQK", letter)+1+8*i)
+		}
+	}
+	whitePawns, blackPawns := strings.Count(t.white, "P"), strings.Count(t.black, "P")
+	leading, order := 0, [2]int{0, 0xf}
+	if blackPawns > 0 && (whitePawns == 0 || blackPawns < whitePawns) {
+		leading = 8
+	}
+	if t.hasPawns {
+		sort.SliceStable(codes, func(i, j int) bool {
+			rank := func(code int) int {
+				switch code {
+				case 1 + leading:
+					return 0
+				case 1 + 8 - leading:
+					return 1
+				}
+				return 2
+			}
+			return rank(codes[i]) < rank(codes[j])
+		})
+		if t.pawnCount[1] > 0 {
+			order[1] = 1
+		}
+	}
+	flags := byte(0)
+	if dtz {
+		flags = syzygyWinPlies | syzygyLossPlies
+		if mapped {
+			flags |= syzygyMapped
+		}
+	}
+	values := [2][4][]int{}
+	for f := 0; f < t.files(); f++ {
+		for i := 0; i < t.sides; i++ {
+			p := &syzygyPairs{flags: flags}
+			copy(p.pieces[:], codes)
+			if err := t.setGroups(p, order, f); err != nil {
+				return nil, err
+			}
+			t.pairs[i][f] = p
+			size := p.groupIdx[0]
+			for k := range p.groupLen {
+				if p.groupLen[k] == 0 {
+					size = p.groupIdx[k]
+					break
+				}
+			}
+			values[i][f] = make([]int, size)
+		}
+	}
+	maps := [4][2][]int{} // by file the stored distances of wins and losses
+	positions(func(board [64]int, colour Colour, value int) {
+		p, index, stored := t.index(board, colour)
+		if !stored {
+			return
+		}
+		for f := 0; f < t.files(); f++ {
+			for i := 0; i < t.sides; i++ {
+				if t.pairs[i][f] != p {
+					continue
+				}
+				if !dtz {
+					values[i][f][index] = value + 2
+					continue
+				}
+				stored, list := 0, 0
+				switch {
+				case value > 0:
+					stored = value - 1
+				case value < 0:
+					stored, list = -value-1, 1
+				}
+				if mapped && value != 0 {
+					found := false
+					for position, distance := range maps[f][list] {
+						if distance == stored {
+							stored, found = position, true
+							break
+						}
+					}
+					if !found {
+						maps[f][list] = append(maps[f][list], stored)
+						stored = len(maps[f][list]) - 1
+					}
+				}
+				values[i][f][index] = stored
+			}
+		}
+	})
+
+	var out bytes.Buffer
+	magic, header := syzygyWDLMagic, byte(0)
+	if dtz {
+		magic = syzygyDTZMagic
+	}
+	if t.white != t.black {
+		header |= 1
+	}
+	if t.hasPawns {
+		header |= 2
+	}
+	out.Write(magic)
+	out.WriteByte(header)
+	for f := 0; f < t.files(); f++ {
+		out.WriteByte(byte(order[0] | order[0]<<4))
+		if t.hasPawns && t.pawnCount[1] > 0 {
+			out.WriteByte(byte(order[1] | order[1]<<4))
+		}
+		for _, code := range codes {
+			out.WriteByte(byte(code | code<<4))
+		}
+	}
+	align := func(n int) {
+		for out.Len()%n != 0 {
+			out.WriteByte(0)
+		}
+	}
+	align(2)
+	compressed := []syzygyCompressed{}
+	for f := 0; f < t.files(); f++ {
+		for i := 0; i < t.sides; i++ {
+			c, err := compressSyzygyValues(values[i][f], flags)
+			if err != nil {
+				return nil, err
+			}
+			compressed = append(compressed, c)
+			out.Write(c.sizes)
+		}
+	}
+	if dtz {
+		for f := 0; f < t.files() && mapped; f++ {
+			for _, list := range [][]int{maps[f][0], maps[f][1], nil, nil} {
+				if len(list) > 255 {
+					return nil, fmt.Errorf("%v distances do not fit the map", len(list))
+				}
+				out.WriteByte(byte(len(list)))
+				for _, distance := range list {
+					out.WriteByte(byte(distance))
+				}
+			}
+		}
+		align(2)
+	}
+	for _, c := range compressed {
+		out.Write(c.sparseIndex)
+	}
+	for _, c := range compressed {
+		out.Write(c.blockLengths)
+	}
+	for _, c := range compressed {
+		align(64)
+		out.Write(c.blocks)
+	}
+	return out.Bytes(), nil
+}
+
+type syzygyCompressed struct {
+	sizes        []byte
+	sparseIndex  []byte
+	blockLengths []byte
+	blocks       []byte
+}
+
+// compresses the values of a table: the most frequent pairs of symbols are combined into new symbols a few times,
+// then the symbols get canonical Huffman codes, longer codes have lower symbols, and are packed into blocks of 64
+// bytes
+func compressSyzygyValues(values []int, flags byte) (syzygyCompressed, error) {
+	const blockSizeBits, spanBits = 6, 8
+	single := true
+	for _, value := range values {
+		single = single && value == values[0]
+	}
+	if single {
+		return syzygyCompressed{sizes: []byte{flags | syzygySingleValue, byte(values[0])}}, nil
+	}
+	type symbol struct{ left, right, values int }
+	symbols, leaves := []symbol{}, map[int]int{}
+	sequence := make([]int, len(values))
+	for i, value := range values {
+		id, ok := leaves[value]
+		if !ok {
+			id = len(symbols)
+			symbols = append(symbols, symbol{left: value, right: 0xfff, values: 1})
+			leaves[value] = id
+		}
+		sequence[i] = id
+	}
+	for round := 0; round < 32; round++ {
+		counts := map[[2]int]int{}
+		for i := 0; i+1 < len(sequence); i++ {
+			counts[[2]int{sequence[i], sequence[i+1]}]++
+		}
+		best, bestCount := [2]int{}, 0
+		for pair, count := range counts {
+			if symbols[pair[0]].values+symbols[pair[1]].values > 256 {
+				continue
+			}
+			if count > bestCount || (count == bestCount && (pair[0] < best[0] || (pair[0] == best[0] && pair[1] < best[1]))) {
+				best, bestCount = pair, count
+			}
+		}
+		if bestCount < 16 {
+			break
+		}
+		symbols = append(symbols, symbol{left: best[0], right: best[1], values: symbols[best[0]].values + symbols[best[1]].values})
+		paired := sequence[:0]
+		for i := 0; i < len(sequence); i++ {
+			if i+1 < len(sequence) && sequence[i] == best[0] && sequence[i+1] == best[1] {
+				paired = append(paired, len(symbols)-1)
+				i++
+			} else {
+				paired = append(paired, sequence[i])
+			}
+		}
+		sequence = paired
+	}
+
+	frequencies := make([]int, len(symbols))
+	for _, s := range sequence {
+		frequencies[s]++
+	}
+	used := 0
+	for _, frequency := range frequencies {
+		if frequency > 0 {
+			used++
+		}
+	}
+	if used == 1 { // a code needs two symbols
+		for s := range frequencies {
+			if frequencies[s] == 0 {
+				frequencies[s] = 1
+				break
+			}
+		}
+	}
+	type node struct {
+		weight, id int
+		symbols    []int
+	}
+	nodes := []node{}
+	for s, frequency := range frequencies {
+		if frequency > 0 {
+			nodes = append(nodes, node{frequency, s, []int{s}})
+		}
+	}
+	lengths := make([]int, len(symbols))
+	for next := len(symbols); len(nodes) > 1; next++ {
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].weight < nodes[j].weight || (nodes[i].weight == nodes[j].weight && nodes[i].id < nodes[j].id)
+		})
+		merged := node{nodes[0].weight + nodes[1].weight, next, append(append([]int{}, nodes[0].symbols...), nodes[1].symbols...)}
+		for _, s := range merged.symbols {
+			lengths[s]++
+		}
+		nodes = append(nodes[2:], merged)
+	}
+	order := make([]int, len(symbols))
+	for s := range order {
+		order[s] = s
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return lengths[order[i]] > lengths[order[j]] && lengths[order[j]] > 0 || lengths[order[j]] == 0 && lengths[order[i]] > 0
+	})
+	ids := make([]int, len(symbols))
+	for id, s := range order {
+		ids[s] = id
+	}
+	minLen, maxLen := 64, 0
+	counts := map[int]int{}
+	for _, length := range lengths {
+		if length > 0 {
+			counts[length]++
+			if length < minLen {
+				minLen = length
+			}
+			if length > maxLen {
+				maxLen = length
+			}
+		}
+	}
+	if maxLen > 32 {
+		return syzygyCompressed{}, fmt.Errorf("a code of %v bits is too long", maxLen)
+	}
+	lowest := make([]int, maxLen-minLen+1)
+	base := make([]uint64, maxLen+1)
+	for length := maxLen; length > minLen; length-- {
+		lowest[length-1-minLen] = lowest[length-minLen] + counts[length]
+		base[length-1] = (base[length] + uint64(counts[length])) / 2
+	}
+
+	var blocks []byte
+	var blockLengths []int
+	block, bits, blockValues := make([]byte, 1<<blockSizeBits), 0, 0
+	flush := func() {
+		blocks = append(blocks, block...)
+		blockLengths = append(blockLengths, blockValues)
+		block, bits, blockValues = make([]byte, 1<<blockSizeBits), 0, 0
+	}
+	for _, s := range sequence {
+		length := lengths[s]
+		if bits+length > 8<<blockSizeBits || blockValues+symbols[s].values > 65536 {
+			flush()
+		}
+		code := base[length] + uint64(ids[s]-lowest[length-minLen])
+		for i := length - 1; i >= 0; i-- {
+			if code>>i&1 == 1 {
+				block[bits/8] |= 0x80 >> (bits % 8)
+			}
+			bits++
+		}
+		blockValues += symbols[s].values
+	}
+	flush()
+
+	sizes := []byte{flags, blockSizeBits, spanBits, 0}
+	sizes = binary.LittleEndian.AppendUint32(sizes, uint32(len(blockLengths)))
+	sizes = append(sizes, byte(maxLen), byte(minLen))
+	for _, symbol := range lowest {
+		sizes = binary.LittleEndian.AppendUint16(sizes, uint16(symbol))
+	}
+	sizes = binary.LittleEndian.AppendUint16(sizes, uint16(len(symbols)))
+	for _, s := range order {
+		left, right := symbols[s].left, symbols[s].right
+		if right != 0xfff {
+			left, right = ids[left], ids[right]
+		}
+		sizes = append(sizes, byte(left), byte(left>>8&0xf|right<<4&0xf0), byte(right>>4))
+	}
+	if len(symbols)%2 == 1 {
+		sizes = append(sizes, 0)
+	}
+
+	c := syzygyCompressed{sizes: sizes}
+	for _, length := range blockLengths {
+		c.blockLengths = binary.LittleEndian.AppendUint16(c.blockLengths, uint16(length-1))
+	}
+	span := 1 << spanBits
+	for k := 0; k*span < len(values); k++ {
+		value, block, start := k*span+span/2, 0, 0
+		for block < len(blockLengths)-1 && start+blockLengths[block] <= value {
+			start += blockLengths[block]
+			block++
+		}
+		if value-start > 0xffff {
+			return syzygyCompressed{}, fmt.Errorf("the sparse index can not point %v values into a block", value-start)
+		}
+		c.sparseIndex = binary.LittleEndian.AppendUint32(c.sparseIndex, uint32(block))
+		c.sparseIndex = binary.LittleEndian.AppendUint16(c.sparseIndex, uint16(value-start))
+	}
+	c.blocks = blocks
+	return c, nil
+}
+
+// visits the positions of a solver table with white as the strong side, with the value valueOf returns for them
+func solverPositions(table *endgameTable, valueOf func(index int, strongToMove bool) int) func(visit func(board [64]int, colour Colour, value int)) {
+	return func(visit func(board [64]int, colour Colour, value int)) {
+		position := solverPosition{table: table, squares: make([]int, 2+len(table.pieces))}
+		for index := range table.dtm {
+			strongToMove := table.position(index, position.squares)
+			if !position.possible(strongToMove) {
+				continue
+			}
+			var board [64]int
+			board[position.squares[0]], board[position.squares[1]] = 6, 14
+			for i, pieceType := range table.pieces {
+				board[position.squares[2+i]] = syzygyPieceCodes[pieceType]
+			}
+			colour := White
+			if !strongToMove {
+				colour = Black
+			}
+			visit(board, colour, valueOf(index, strongToMove))
+		}
+	}
+}
+
+// returns the outcome of the positions of a solver table for the side to move
+func solverWDL(table *endgameTable) func(index int, strongToMove bool) int {
+	return func(index int, strongToMove bool) int {
+		switch {
+		case table.dtm[index] == 0:
+			return 0
+		case strongToMove:
+			return 2
+		default:
+			return -2
+		}
+	}
+}
+
+// returns the distance to zeroing of KPvK positions by the index of the solver table, positive when white is to
+// move and wins, negative when black is to move and loses and 0 for draws. It is found by retrograde analysis from
+// the outcomes of the solver: a win is 1 when a pawn move keeps it and a king move to the longest loss otherwise
+func kpkDistances(kpk *endgameTable, kqk *endgameTable) []int {
+	distances := make([]int, len(kpk.dtm))
+	position := solverPosition{table: kpk, squares: make([]int, 3)}
+	child := make([]int, 3)
+	index := func(squares []int, strongToMove bool) int { return kpk.index(squares, strongToMove) }
+	// the squares a king can go to that the other king, the pawn (when it is black) and the other pieces leave free
+	kingMoves := func(king int, other int, pawn int, black bool) []int {
+		moves := []int{}
+		for target := 0; target < 64; target++ {
+			if kingAttacks[king]&(1<<target) == 0 || kingAttacks[other]&(1<<target) != 0 || target == pawn {
+				continue
+			}
+			if black && attacks(Pawn, pawn, target, 0) {
+				continue
+			}
+			moves = append(moves, target)
+		}
+		return moves
+	}
+	for changed, level := true, 1; changed; level++ {
+		changed = false
+		for i := range distances {
+			strongToMove := kpk.position(i, position.squares)
+			if distances[i] != 0 || kpk.dtm[i] == 0 || !position.possible(strongToMove) {
+				continue
+			}
+			king, weakKing, pawn := position.squares[0], position.squares[1], position.squares[2]
+			distance := 0
+			if strongToMove {
+				if level == 1 {
+					for _, to := range []int{pawn + 8, pawn + 16} {
+						if to == pawn+16 && (pawn >= 16 || distance == 1 || king == pawn+8 || weakKing == pawn+8) {
+							continue
+						}
+						if to == king || to == weakKing {
+							continue
+						}
+						if to >= 56 {
+							if kqk.dtm[kqk.index([]int{king, weakKing, to}, false)] != 0 {
+								distance = 1
+							}
+						} else if kpk.dtm[index([]int{king, weakKing, to}, false)] != 0 {
+							distance = 1
+						}
+					}
+				} else {
+					for _, to := range kingMoves(king, weakKing, pawn, false) {
+						copy(child, []int{to, weakKing, pawn})
+						if distances[index(child, false)] == -(level - 1) {
+							distance = level
+						}
+					}
+				}
+			} else {
+				longest, all := 0, true
+				for _, to := range kingMoves(weakKing, king, pawn, true) {
+					copy(child, []int{king, to, pawn})
+					d := distances[index(child, true)]
+					if d <= 0 || d >= level {
+						all = false
+						break
+					}
+					if d > longest {
+						longest = d
+					}
+				}
+				if all && longest == level-1 {
+					distance = -level
+				}
+			}
+			if distance != 0 {
+				distances[i], changed = distance, true
+			}
+		}
+	}
+	return distances
+}
+
+// the fixtures in testdata/syzygy, written again when UPDATE_TESTDATA is set. KQvK and KRvK come from the endgame
+// solver, where the distance to zeroing is the distance to mate, and KPvK from its outcomes and kpkDistances. The
+// DTZ table of KRvK maps its distances
+func syzygyFixtures(t *testing.T) map[string][]byte {
+	solver := NewEndgameSolver()
+	fixtures := map[string][]byte{}
+	write := func(file string, dtz bool, mapped bool, positions func(visit func(board [64]int, colour Colour, value int))) {
+		data, err := writeSyzygyTable(strings.TrimSuffix(file, filepath.Ext(file)), dtz, mapped, positions)
+		if err != nil {
+			t.Fatalf("Failed to write %v, %v", file, err)
+		}
+		fixtures[file] = data
+	}
+	for _, name := range []string{"KQvK", "KRvK", "KPvK"} {
+		table, err := solver.table(name)
+		if err != nil {
+			t.Fatalf("Failed to solve %v, %v", name, err)
+		}
+		write(name+".rtbw", false, false, solverPositions(table, solverWDL(table)))
+		distance := func(index int, strongToMove bool) int {
+			if strongToMove && table.dtm[index] != 0 {
+				return int(table.dtm[index]) - 1
+			}
+			return 0
+		}
+		if name == "KPvK" {
+			kqk, err := solver.table("KQvK")
+			if err != nil {
+				t.Fatalf("Failed to solve KQvK, %v", err)
+			}
+			distances := kpkDistances(table, kqk)
+			distance = func(index int, strongToMove bool) int { return distances[index] }
+		}
+		write(name+".rtbz", true, name == "KRvK", solverPositions(table, distance))
+	}
+	return fixtures
+}
+
+func TestSyzygyFixtures(t *testing.T) {
+	if testing.Short() {
+		t.Skip("solves the endings")
+	}
+	for file, data := range syzygyFixtures(t) {
+		path := filepath.Join("testdata", "syzygy", file)
+		if os.Getenv("UPDATE_TESTDATA") != "" {
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatalf("Failed to update testdata, %v", err)
+			}
+		}
+		committed, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read testdata, %v", err)
+		}
+		if !bytes.Equal(committed, data) {
+			t.Errorf("Expected %v to hold the table syzygyFixtures writes", path)
+		}
+	}
+}
+
+// mirrors the position of a FEN so black has the pieces of white and the other side is to move
+func mirrorFEN(fen string) string {
+	fields := strings.Fields(fen)
+	ranks := strings.Split(fields[0], "/")
+	for i, j := 0, len(ranks)-1; i < j; i, j = i+1, j-1 {
+		ranks[i], ranks[j] = ranks[j], ranks[i]
+	}
+	swapped := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return r
+	}, strings.Join(ranks, "/"))
+	side := "w"
+	if fields[1] == "w" {
+		side = "b"
+	}
+	return swapped + " " + side + " - - 0 1"
+}
+
+// a sample of the positions of each fixture, also with the colours swapped, is probed and compared with the solver
+func TestSyzygy_probes_the_fixtures(t *testing.T) {
+	tablebase, err := OpenSyzygy(filepath.Join("testdata", "syzygy"))
+	if err != nil {
+		t.Fatalf("Failed to open the tables, %v", err)
+	}
+	solver := NewEndgameSolver()
+	step := 1499
+	if testing.Short() {
+		step = 7919
+	}
+	for _, name := range []string{"KQvK", "KRvK", "KPvK"} {
+		table, err := solver.table(name)
+		if err != nil {
+			t.Fatalf("Failed to solve %v, %v", name, err)
+		}
+		var distances []int
+		if name == "KPvK" {
+			kqk, _ := solver.table("KQvK")
+			distances = kpkDistances(table, kqk)
+		}
+		position := solverPosition{table: table, squares: make([]int, 3)}
+		probed := 0
+		for index := 0; index < len(table.dtm); index += step {
+			strongToMove := table.position(index, position.squares)
+			if !position.possible(strongToMove) {
+				continue
+			}
+			probed++
+			fen := solverFEN(table, position.squares, strongToMove)
+			wdl := WDL(solverWDL(table)(index, strongToMove))
+			dtz := 0
+			switch {
+			case distances != nil:
+				dtz = distances[index]
+			case wdl == WDLWin:
+				dtz = int(table.dtm[index]) - 1
+			case wdl == WDLLoss:
+				if dtz = 1 - int(table.dtm[index]); dtz == 0 {
+					dtz = -1 // mated
+				}
+			}
+			for _, fen := range []string{fen, mirrorFEN(fen)} {
+				board, err := ParseFEN(fen)
+				if err != nil {
+					t.Fatalf("Failed to parse %q, %v", fen, err)
+				}
+				if got, err := tablebase.ProbeWDL(board.Board, board.NextToMove); err != nil || got != wdl {
+					t.Errorf("%v: expected a %v, got %v (%v)", fen, wdl, got, err)
+				}
+				if got, err := tablebase.ProbeDTZ(board.Board, board.NextToMove); err != nil || got != dtz {
+					t.Errorf("%v: expected a distance to zeroing of %v, got %v (%v)", fen, dtz, got, err)
+				}
+			}
+		}
+		if probed < 20 {
+			t.Errorf("Expected to probe more than %v positions of %v", probed, name)
+		}
+	}
+}
+
+func TestSyzygy_probe(t *testing.T) {
+	tablebase, err := OpenSyzygy(filepath.Join("testdata", "syzygy"))
+	if err != nil {
+		t.Fatalf("Failed to open the tables, %v", err)
+	}
+	tests := []struct {
+		fen string
+		wdl WDL
+		dtz int
+	}{
+		{"7k/8/5K2/8/8/8/8/6Q1 w - - 0 1", WDLWin, 1},
+		{"6q1/8/8/8/8/5k2/8/7K b - - 0 1", WDLWin, 1},
+		{"7k/6Q1/5K2/8/8/8/8/8 b - - 0 1", WDLLoss, -1}, // mated
+		{"7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", WDLDraw, 0},  // stalemate
+		{"8/8/8/8/8/2k5/1Q6/4K3 b - - 0 1", WDLDraw, 0}, // the queen is taken
+		{"k7/8/1K6/8/8/8/8/7R w - - 0 1", WDLWin, 1},
+		{"8/4P3/4K3/8/8/8/8/k7 w - - 0 1", WDLWin, 1},     // the pawn moves at once
+		{"k7/8/8/8/8/8/P7/K7 w - - 0 1", WDLDraw, 0},      // the rook pawn does not win against a king in front of it
+		{"8/8/8/8/8/3k4/3P4/3K4 b - - 0 1", WDLDraw, 0},   // the pawn is taken
+		{"8/8/8/8/8/k7/8/K7 w - - 0 1", WDLDraw, 0},       // only the kings
+		{"8/8/8/8/4k3/8/8/KN6 w - - 0 1", WDLDraw, -1000}, // no KNvK table
+	}
+	for _, test := range tests {
+		position, err := ParseFEN(test.fen)
+		if err != nil {
+			t.Fatalf("Failed to parse %q, %v", test.fen, err)
+		}
+		wdl, err := tablebase.ProbeWDL(position.Board, position.NextToMove)
+		if test.dtz == -1000 {
+			if !errors.Is(err, ErrNotInTablebase) {
+				t.Errorf("Expected %q not to be in the tablebase, got %v (%v)", test.fen, wdl, err)
+			}
+			continue
+		}
+		if err != nil || wdl != test.wdl {
+			t.Errorf("Expected %q to be a %v, got %v (%v)", test.fen, test.wdl, wdl, err)
+		}
+		if dtz, err := tablebase.ProbeDTZ(position.Board, position.NextToMove); err != nil || dtz != test.dtz {
+			t.Errorf("Expected %q to have a distance to zeroing of %v, got %v (%v)", test.fen, test.dtz, dtz, err)
+		}
+	}
+}
+
+func TestSyzygy_player_mates_with_the_rook(t *testing.T) {
+	defer quiet()()
+	tablebase, err := OpenSyzygy(filepath.Join("testdata", "syzygy"))
+	if err != nil {
+		t.Fatalf("Failed to open the tables, %v", err)
+	}
+	white, black := NewTablebasePlayer(tablebase, nil), NewTablebasePlayer(tablebase, nil)
+	game, err := NewGameFromFEN(white, black, &noopVisualizer{}, "8/8/8/3k4/8/8/8/R3K3 w - - 0 1")
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	result := game.Start()
+	if result.Outcome != WhiteWon || result.Termination != Checkmate {
+		t.Errorf("Expected white to mate, got %v", result)
+	}
+	if len(game.History) > 2*16 {
+		t.Errorf("Expected a mate in at most 16 moves, took %v plies", len(game.History))
+	}
+}
+
+func TestOpenSyzygy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatalf("Failed to write %v, %v", name, err)
+		}
+	}
+	fixture, err := os.ReadFile(filepath.Join("testdata", "syzygy", "KQvK.rtbw"))
+	if err != nil {
+		t.Fatalf("Failed to read testdata, %v", err)
+	}
+	write("KQvK.rtbw", fixture[:len(fixture)/2])
+	write("KRvK.rtbz", append(append([]byte{}, syzygyDTZMagic...), 0, 0))
+	write("readme.txt", []byte("not a table"))
+	tablebase, err := OpenSyzygy(dir)
+	if err != nil {
+		t.Fatalf("Failed to open the tables, %v", err)
+	}
+	if names := tablebase.Tables(); len(names) != 2 || names[0] != "KQvK" || names[1] != "KRvK" || tablebase.MaxPieces() != 3 {
+		t.Errorf("Expected KQvK and KRvK with 3 pieces, got %v with %v", names, tablebase.MaxPieces())
+	}
+	position, _ := ParseFEN("8/8/8/8/8/2k5/8/1Q2K3 b - - 0 1")
+	if _, err := tablebase.ProbeWDL(position.Board, position.NextToMove); err == nil || errors.Is(err, ErrNotInTablebase) {
+		t.Errorf("Expected a truncated table to be reported, got %v", err)
+	}
+	position, _ = ParseFEN("8/8/8/8/8/2k5/1B6/4K3 b - - 0 1")
+	if _, err := tablebase.ProbeWDL(position.Board, position.NextToMove); !errors.Is(err, ErrNotInTablebase) {
+		t.Errorf("Expected KBvK not to be in the tablebase, got %v", err)
+	}
+
+	write("KPvK.rtbw", []byte{1, 2, 3, 4})
+	if _, err := OpenSyzygy(dir); err == nil {
+		t.Errorf("Expected a table with the wrong header to be rejected")
+	}
+}
+
+// the index of two kings has 462 values, one for each way to place them apart with the first in the a1-d1-d4
+// triangle
+func TestSyzygy_king_index(t *testing.T) {
+	seen := map[int]bool{}
+	for first := 0; first < 64; first++ {
+		if first&7 > 3 || syzygyDiagonal(first) > 0 {
+			continue
+		}
+		for second := 0; second < 64; second++ {
+			if kingAttacks[first]&(1<<second) != 0 || first == second || (syzygyDiagonal(first) == 0 && syzygyDiagonal(second) > 0) {
+				continue
+			}
+			seen[syzygyMapKK[syzygyMapA1D1D4[first]][second]] = true
+		}
+	}
+	if len(seen) != 462 || !seen[0] || !seen[461] {
+		t.Errorf("Expected the kings to be indexed 0 to 461, got %v values", len(seen))
+	}
+	if syzygyMapPawns[8] != 47 || syzygyMapPawns[15] != 46 || syzygyMapPawns[9] != 35 || syzygyLeadPawnsSize[2][0] != 6*47-30 {
+		t.Errorf("Unexpected pawn index, a2 %v, h2 %v, b2 %v", syzygyMapPawns[8], syzygyMapPawns[15], syzygyMapPawns[9])
+	}
+}
@@ -0,0 +1,263 @@
+package chess
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// the outcome of a position with perfect play, from the view of the side to move. A cursed win is a win that the
+// fifty-move rule turns into a draw and a blessed loss a loss it saves
+type WDL int
+
+const (
+	WDLLoss        WDL = -2
+	WDLBlessedLoss WDL = -1
+	WDLDraw        WDL = 0
+	WDLCursedWin   WDL = 1
+	WDLWin         WDL = 2
+)
+
+func (w WDL) String() string {
+	switch w {
+	case WDLLoss:
+		return "loss"
+	case WDLBlessedLoss:
+		return "blessed loss"
+	case WDLDraw:
+		return "draw"
+	case WDLCursedWin:
+		return "cursed win"
+	case WDLWin:
+		return "win"
+	default:
+		return fmt.Sprintf("WDL(%d)", int(w))
+	}
+}
+
+// endgame tablebases of standard chess, for positions with at most MaxPieces pieces (kings included) and no
+// castling rights
+type Tablebase interface {
+	MaxPieces() int
+	// returns the outcome for colour to move
+	ProbeWDL(b *Board, colour Colour) (WDL, error)
+	// returns the distance to zeroing, the number of plies to the next capture or pawn move with perfect play.
+	// Positive when colour to move wins, negative when it loses and 0 for draws
+	ProbeDTZ(b *Board, colour Colour) (int, error)
+}
+
+// optional interface a Tablebase can implement when it knows the distance to mate, which BestTablebaseMove then
+// uses to mate as fast as possible
+type DTMTablebase interface {
+	// returns the number of plies to mate with perfect play, positive when colour to move wins and negative when it
+	// loses. It is 0 for draws and when colour is mated
+	ProbeDTM(b *Board, colour Colour) (int, error)
+}
+
+var ErrNotInTablebase = errors.New("the position is not in the tablebase")
+
+// tablebases that are probed in turn, the first one that has the position answers
+type Tablebases []Tablebase
+
+func (tbs Tablebases) MaxPieces() int {
+	pieces := 0
+	for _, tb := range tbs {
+		if tb.MaxPieces() > pieces {
+			pieces = tb.MaxPieces()
+		}
+	}
+	return pieces
+}
+
+func (tbs Tablebases) ProbeWDL(b *Board, colour Colour) (WDL, error) {
+	err := ErrNotInTablebase
+	for _, tb := range tbs {
+		var wdl WDL
+		if wdl, err = tb.ProbeWDL(b, colour); err == nil {
+			return wdl, nil
+		}
+	}
+	return WDLDraw, err
+}
+
+func (tbs Tablebases) ProbeDTZ(b *Board, colour Colour) (int, error) {
+	err := ErrNotInTablebase
+	for _, tb := range tbs {
+		var dtz int
+		if dtz, err = tb.ProbeDTZ(b, colour); err == nil {
+			return dtz, nil
+		}
+	}
+	return 0, err
+}
+
+// probes the tablebases that know the distance to mate
+func (tbs Tablebases) ProbeDTM(b *Board, colour Colour) (int, error) {
+	err := ErrNotInTablebase
+	for _, tb := range tbs {
+		if dtmTablebase, ok := tb.(DTMTablebase); ok {
+			var dtm int
+			if dtm, err = dtmTablebase.ProbeDTM(b, colour); err == nil {
+				return dtm, nil
+			}
+		}
+	}
+	return 0, err
+}
+
+// returns the number of pieces on the board, kings included
+func pieceCount(b *Board) int {
+	count := 0
+	for _, pieces := range [][]Piece{b.WhitePieces, b.BlackPieces} {
+		for _, piece := range pieces {
+			if piece.InPlay {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// the pieces in the order tablebases name them, e.g. KQvK and KRPvKR
+var tablebasePieceOrder = []PieceType{King, Queen, Rook, Bishop, Knight, Pawn}
+
+var tablebasePieceLetters = map[PieceType]string{King: "K", Queen: "Q", Rook: "R", Bishop: "B", Knight: "N", Pawn: "P"}
+
+// returns the material of white and black as tablebases name it, e.g. "KRP" and "KR"
+func tablebaseMaterial(b *Board) (white string, black string) {
+	letters := func(pieces []Piece) string {
+		var text strings.Builder
+		for _, pieceType := range tablebasePieceOrder {
+			for _, piece := range pieces {
+				if piece.InPlay && piece.Type == pieceType {
+					text.WriteString(tablebasePieceLetters[pieceType])
+				}
+			}
+		}
+		return text.String()
+	}
+	return letters(b.WhitePieces), letters(b.BlackPieces)
+}
+
+// returns whether the tablebase may have the position, it has few enough pieces and no castling rights
+func inTablebase(tb Tablebase, b *Board) bool {
+	return pieceCount(b) <= tb.MaxPieces() && b.castlingRightsFEN(false) == "-"
+}
+
+// returns the best move for colour according to the tablebase and the outcome it keeps. Mates are played at once,
+// wins are converted by the shortest way to mate, or to the next capture or pawn move when the tablebase does not
+// know the distance to mate, and losses are drawn out as long as possible. Without distances any move that keeps
+// the outcome is played
+func BestTablebaseMove(tb Tablebase, b *Board, colour Colour) (Move, WDL, error) {
+	if !inTablebase(tb, b) {
+		return Move{}, WDLDraw, ErrNotInTablebase
+	}
+	dtmTablebase, hasDTM := tb.(DTMTablebase)
+	opponent := opponentOf(colour)
+	var best Move
+	bestWDL, bestDistance := WDLLoss-1, 0
+	for move, result := range b.LegalMovesFor(colour) {
+		_, piece := b.GetPieceAtSquare(move.From.Column, move.From.Row)
+		zeroing := result.Action == Take || piece.Type == Pawn
+		child := b.Clone()
+		if _, err := child.makeMove(move, colour); err != nil {
+			return Move{}, WDLDraw, err
+		}
+		if len(child.LegalMovesFor(opponent)) == 0 {
+			if child.IsCheck(opponent) {
+				return move, WDLWin, nil
+			}
+			if bestWDL < WDLDraw {
+				best, bestWDL, bestDistance = move, WDLDraw, 0
+			}
+			continue
+		}
+		childWDL, err := tb.ProbeWDL(child, opponent)
+		if err != nil {
+			return Move{}, WDLDraw, err
+		}
+		wdl := -childWDL
+		// the plies to mate, or else to the next zeroing move, which is now for a zeroing move
+		distance, known := 0, false
+		if hasDTM && wdl != WDLDraw {
+			if dtm, err := dtmTablebase.ProbeDTM(child, opponent); err == nil {
+				distance, known = dtm, true
+			}
+		}
+		if !known && !zeroing && wdl != WDLDraw {
+			if dtz, err := tb.ProbeDTZ(child, opponent); err == nil {
+				distance = dtz
+			}
+		}
+		if distance < 0 {
+			distance = -distance
+		}
+		better := wdl > bestWDL
+		if wdl == bestWDL {
+			switch {
+			case wdl > WDLDraw:
+				better = distance < bestDistance
+			case wdl < WDLDraw:
+				better = distance > bestDistance
+			}
+		}
+		if better {
+			best, bestWDL, bestDistance = move, wdl, distance
+		}
+	}
+	if bestWDL < WDLLoss {
+		return Move{}, WDLDraw, fmt.Errorf("%v has no legal moves", colour)
+	}
+	return best, bestWDL, nil
+}
+
+// a Player that plays perfect endgames from a tablebase and lets the inner player move in the positions the
+// tablebase does not have. Only standard chess is played from the tablebase
+type TablebasePlayer struct {
+	Tablebase Tablebase
+	Inner     Player
+}
+
+func NewTablebasePlayer(tb Tablebase, inner Player) *TablebasePlayer {
+	return &TablebasePlayer{Tablebase: tb, Inner: inner}
+}
+
+// returns whether the tablebase may have the current position of the game
+func (p *TablebasePlayer) covers(g *Game) bool {
+	return g.Variant().Name() == (Standard{}).Name() && inTablebase(p.Tablebase, g.Board)
+}
+
+func (p *TablebasePlayer) PickMove(g *Game) (*Move, error) {
+	if p.covers(g) {
+		if move, _, err := BestTablebaseMove(p.Tablebase, g.Board, g.NextToMove); err == nil {
+			return &move, nil
+		}
+	}
+	if p.Inner == nil {
+		return nil, errors.New("the position is not in the tablebase and there is no player to take over")
+	}
+	return p.Inner.PickMove(g)
+}
+
+// accepts draws unless the tablebase says the position is won, otherwise the inner player answers and draws are
+// declined if it can not
+func (p *TablebasePlayer) RespondToDrawOffer(g *Game, offeredBy Colour) bool {
+	if p.covers(g) {
+		if wdl, err := p.Tablebase.ProbeWDL(g.Board, g.NextToMove); err == nil {
+			if g.NextToMove == offeredBy {
+				wdl = -wdl
+			}
+			return wdl < WDLWin
+		}
+	}
+	if responder, ok := p.Inner.(DrawOfferResponder); ok {
+		return responder.RespondToDrawOffer(g, offeredBy)
+	}
+	return false
+}
+
+func (p *TablebasePlayer) HandleIllegalMove(g *Game, move Move, err error) {
+	if handler, ok := p.Inner.(IllegalMoveHandler); ok {
+		handler.HandleIllegalMove(g, move, err)
+	}
+}
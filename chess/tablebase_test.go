@@ -0,0 +1,86 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+// a tablebase for up to four pieces that counts a position as won for the side with a queen when the other side
+// has no rook and drawn otherwise
+type queenTablebase struct{}
+
+func (queenTablebase) MaxPieces() int { return 4 }
+
+func (queenTablebase) ProbeWDL(b *Board, colour Colour) (WDL, error) {
+	white, black := tablebaseMaterial(b)
+	own, other := white, black
+	if colour == Black {
+		own, other = black, white
+	}
+	hasQueen := func(side string) bool { return side == "KQ" || side == "KQR" }
+	switch {
+	case hasQueen(own) && other == "K":
+		return WDLWin, nil
+	case hasQueen(other) && own == "K":
+		return WDLLoss, nil
+	default:
+		return WDLDraw, nil
+	}
+}
+
+func (queenTablebase) ProbeDTZ(b *Board, colour Colour) (int, error) {
+	return 0, ErrNotInTablebase
+}
+
+func TestBestTablebaseMove(t *testing.T) {
+	tests := []struct {
+		fen      string
+		expected Move
+		wdl      WDL
+	}{
+		// mates at once
+		{"7k/8/5K2/8/8/8/8/6Q1 w - - 0 1", Move{From: Square{"G", 1}, To: Square{"G", 7}}, WDLWin},
+		// takes the rook that stands in the way of the win
+		{"4k3/8/8/8/8/8/r7/Q3K3 w - - 0 1", Move{From: Square{"A", 1}, To: Square{"A", 2}}, WDLWin},
+		// takes the queen to draw
+		{"8/8/8/8/8/2k5/1Q6/4K3 b - - 0 1", Move{From: Square{"C", 3}, To: Square{"B", 2}}, WDLDraw},
+	}
+	for _, test := range tests {
+		position, err := ParseFEN(test.fen)
+		if err != nil {
+			t.Fatalf("Failed to parse %q, %v", test.fen, err)
+		}
+		move, wdl, err := BestTablebaseMove(queenTablebase{}, position.Board, position.NextToMove)
+		if err != nil || move != test.expected || wdl != test.wdl {
+			t.Errorf("Expected %v (%v) in %q, got %v (%v, %v)", test.expected, test.wdl, test.fen, move, wdl, err)
+		}
+	}
+}
+
+func TestBestTablebaseMove_outside_the_tablebase(t *testing.T) {
+	position, _ := ParseFEN(StartingPositionFEN)
+	if _, _, err := BestTablebaseMove(queenTablebase{}, position.Board, White); !errors.Is(err, ErrNotInTablebase) {
+		t.Errorf("Expected the starting position not to be in the tablebase, got %v", err)
+	}
+}
+
+func TestTablebasePlayer(t *testing.T) {
+	defer quiet()()
+	inner := &scriptedPlayer{colour: White, moves: []Move{{From: Square{"E", 2}, To: Square{"E", 4}}}}
+	player := NewTablebasePlayer(queenTablebase{}, inner)
+	game := NewGame(player, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if move, err := player.PickMove(game); err != nil || *move != (Move{From: Square{"E", 2}, To: Square{"E", 4}}) {
+		t.Errorf("Expected the inner player to move from the start, got %v (%v)", move, err)
+	}
+
+	game, err := NewGameFromFEN(player, &scriptedPlayer{colour: Black}, &noopVisualizer{}, "7k/8/5K2/8/8/8/8/6Q1 w - - 0 1")
+	if err != nil {
+		t.Fatalf("Failed to create game, %v", err)
+	}
+	if move, err := player.PickMove(game); err != nil || *move != (Move{From: Square{"G", 1}, To: Square{"G", 7}}) {
+		t.Errorf("Expected the tablebase to mate, got %v (%v)", move, err)
+	}
+	if player.RespondToDrawOffer(game, Black) {
+		t.Errorf("Expected a draw offer to be declined in a won position")
+	}
+}
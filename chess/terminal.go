@@ -0,0 +1,167 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ANSI escape codes used by TerminalRenderer
+const (
+	ansiReset          = "\x1b[0m"
+	ansiLightSquare    = "\x1b[48;5;223m"
+	ansiDarkSquare     = "\x1b[48;5;137m"
+	ansiLastMoveSquare = "\x1b[48;5;143m"
+	ansiCheckSquare    = "\x1b[48;5;167m"
+	ansiTargetSquare   = "\x1b[48;5;108m"
+	ansiSelectedSquare = "\x1b[48;5;74m"
+	ansiCursorSquare   = "\x1b[48;5;220m"
+	ansiWhitePiece     = "\x1b[1;97m"
+	ansiBlackPiece     = "\x1b[1;30m"
+)
+
+// renders the board as text for a terminal with a-h and 1-8 labels, the last move and a king in check highlighted.
+// Without colours the last move is marked with [ ], a king in check with ( ), targets with * *, the selected square
+// with { } and the cursor with > <. In ASCII pieces are written as FEN letters (upper case for white) instead of
+// unicode glyphs
+type TerminalRenderer struct {
+	Flipped  bool // black at the bottom
+	ASCII    bool // for terminals without unicode
+	NoColour bool // for terminals without ANSI colours
+	// for interactive use, e.g. a piece is selected and its legal moves are shown
+	Cursor   *Square
+	Selected *Square
+	Targets  []Square
+}
+
+// how a square is highlighted, a higher value wins when a square has several
+type squareHighlight int
+
+const (
+	noHighlight squareHighlight = iota
+	lastMoveHighlight
+	checkHighlight
+	targetHighlight
+	selectedHighlight
+	cursorHighlight
+)
+
+// returns the board as lines of text, ending with a newline
+func (r *TerminalRenderer) Render(b *Board) string {
+	highlights := r.highlights(b)
+
+	rows, columns := []int{8, 7, 6, 5, 4, 3, 2, 1}, append([]string{}, b.columns...)
+	if r.Flipped {
+		rows, columns = []int{1, 2, 3, 4, 5, 6, 7, 8}, []string{"H", "G", "F", "E", "D", "C", "B", "A"}
+	}
+	var text strings.Builder
+	for _, row := range rows {
+		fmt.Fprintf(&text, "%v ", row)
+		for _, column := range columns {
+			square := Square{column, row}
+			text.WriteString(r.renderSquare(b, square, highlights[square]))
+		}
+		text.WriteString("\n")
+	}
+	text.WriteString("  ")
+	for _, column := range columns {
+		fmt.Fprintf(&text, " %v ", strings.ToLower(column))
+	}
+	text.WriteString("\n")
+	return text.String()
+}
+
+// returns the highlighted squares
+func (r *TerminalRenderer) highlights(b *Board) map[Square]squareHighlight {
+	highlights := map[Square]squareHighlight{}
+	mark := func(square Square, highlight squareHighlight) {
+		square.Column = strings.ToUpper(square.Column)
+		if highlight > highlights[square] {
+			highlights[square] = highlight
+		}
+	}
+	if previousMove, ok := b.PreviousMove(); ok {
+		mark(previousMove.To, lastMoveHighlight)
+		if previousMove.Kind == PieceMove {
+			mark(previousMove.From, lastMoveHighlight)
+		}
+	}
+	for _, colour := range []Colour{White, Black} {
+		king := b.getKing(colour)
+		if king.Type != King || !king.InPlay {
+			continue
+		}
+		if isCheck, _ := b.kingIsInCheck(colour); isCheck {
+			mark(king.CurrentSquare, checkHighlight)
+		}
+	}
+	for _, target := range r.Targets {
+		mark(target, targetHighlight)
+	}
+	if r.Selected != nil {
+		mark(*r.Selected, selectedHighlight)
+	}
+	if r.Cursor != nil {
+		mark(*r.Cursor, cursorHighlight)
+	}
+	return highlights
+}
+
+// returns the square as three characters, the piece (or a dot) in the middle
+func (r *TerminalRenderer) renderSquare(b *Board, square Square, highlight squareHighlight) string {
+	occupied, piece := b.GetPieceAtSquare(square.Column, square.Row)
+	symbol := "."
+	if occupied {
+		symbol = r.pieceSymbol(piece)
+	}
+	if r.NoColour {
+		switch highlight {
+		case lastMoveHighlight:
+			return "[" + symbol + "]"
+		case checkHighlight:
+			return "(" + symbol + ")"
+		case targetHighlight:
+			return "*" + symbol + "*"
+		case selectedHighlight:
+			return "{" + symbol + "}"
+		case cursorHighlight:
+			return ">" + symbol + "<"
+		default:
+			return " " + symbol + " "
+		}
+	}
+	background := ansiLightSquare
+	if (b.getColumnIndex(square.Column)+square.Row)%2 == 1 {
+		background = ansiDarkSquare
+	}
+	switch highlight {
+	case lastMoveHighlight:
+		background = ansiLastMoveSquare
+	case checkHighlight:
+		background = ansiCheckSquare
+	case targetHighlight:
+		background = ansiTargetSquare
+	case selectedHighlight:
+		background = ansiSelectedSquare
+	case cursorHighlight:
+		background = ansiCursorSquare
+	}
+	if !occupied {
+		symbol = " "
+	}
+	foreground := ansiBlackPiece
+	if occupied && piece.Colour == White {
+		foreground = ansiWhitePiece
+	}
+	return background + foreground + " " + symbol + " " + ansiReset
+}
+
+// the same (filled) glyph is used for both colours when the colour of the piece is shown with ANSI colours
+func (r *TerminalRenderer) pieceSymbol(piece *Piece) string {
+	if r.ASCII {
+		return piece.fenLetter()
+	}
+	if r.NoColour {
+		return piece.GetAbbreveation()
+	}
+	return (&Piece{Type: piece.Type, Colour: Black}).GetAbbreveation()
+}
@@ -0,0 +1,83 @@
+package chess
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTerminalRenderer_ASCII_without_colours(t *testing.T) {
+	renderer := &TerminalRenderer{ASCII: true, NoColour: true}
+	expected := "" +
+		"8  r  n  b  q  k  b  n  r \n" +
+		"7  p  p  p  p  p  p  p  p \n" +
+		"6  .  .  .  .  .  .  .  . \n" +
+		"5  .  .  .  .  .  .  .  . \n" +
+		"4  .  .  .  .  .  .  .  . \n" +
+		"3  .  .  .  .  .  .  .  . \n" +
+		"2  P  P  P  P  P  P  P  P \n" +
+		"1  R  N  B  Q  K  B  N  R \n" +
+		"   a  b  c  d  e  f  g  h \n"
+	if got := renderer.Render(NewBoard()); got != expected {
+		t.Errorf("Expected\n%v\ngot\n%v", expected, got)
+	}
+}
+
+func TestTerminalRenderer_flipped_with_last_move_and_check(t *testing.T) {
+	defer quiet()()
+	game := NewGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{})
+	if err := playMoves(game, []Move{
+		{From: Square{"F", 2}, To: Square{"F", 3}},
+		{From: Square{"E", 7}, To: Square{"E", 5}},
+		{From: Square{"G", 2}, To: Square{"G", 4}},
+		{From: Square{"D", 8}, To: Square{"H", 4}},
+	}); err != nil {
+		t.Fatalf("Failed to move, %v", err)
+	}
+	lines := strings.Split((&TerminalRenderer{Flipped: true, ASCII: true, NoColour: true}).Render(game.Board), "\n")
+	if lines[0] != "1  R  N  B (K) Q  B  N  R " {
+		t.Errorf("Expected row 1 at the top with the king in check, got %q", lines[0])
+	}
+	if lines[3] != "4 [q] P  .  .  .  .  .  . " {
+		t.Errorf("Expected the queen on H4 to be marked as the last move, got %q", lines[3])
+	}
+	if lines[7] != "8  r  n  b  k [.] b  n  r " {
+		t.Errorf("Expected D8 to be marked as the last move, got %q", lines[7])
+	}
+	if lines[8] != "   h  g  f  e  d  c  b  a " {
+		t.Errorf("Expected flipped file labels, got %q", lines[8])
+	}
+}
+
+func TestTerminalRenderer_colours(t *testing.T) {
+	text := (&TerminalRenderer{}).Render(NewBoard())
+	if !strings.Contains(text, ansiLightSquare) || !strings.Contains(text, ansiDarkSquare) {
+		t.Errorf("Expected light and dark squares")
+	}
+	// A1 is a dark square with a white rook
+	if !strings.Contains(text, "1 "+ansiDarkSquare+ansiWhitePiece+" ♜ "+ansiReset) {
+		t.Errorf("Expected a white rook on a dark square on A1, got %q", text)
+	}
+	if strings.Contains(text, ansiLastMoveSquare) || strings.Contains(text, ansiCheckSquare) {
+		t.Errorf("Expected no highlights in the starting position")
+	}
+}
+
+func TestTerminalRenderer_cursor_selection_and_targets(t *testing.T) {
+	renderer := &TerminalRenderer{
+		ASCII:    true,
+		NoColour: true,
+		Cursor:   &Square{"E", 4},
+		Selected: &Square{"E", 2},
+		Targets:  []Square{{"E", 3}, {"E", 4}},
+	}
+	lines := strings.Split(renderer.Render(NewBoard()), "\n")
+	if lines[4] != "4  .  .  .  . >.< .  .  . " {
+		t.Errorf("Expected the cursor on E4 (over the target), got %q", lines[4])
+	}
+	if lines[5] != "3  .  .  .  . *.* .  .  . " {
+		t.Errorf("Expected E3 to be a target, got %q", lines[5])
+	}
+	if lines[6] != "2  P  P  P  P {P} P  P  P " {
+		t.Errorf("Expected E2 to be selected, got %q", lines[6])
+	}
+}
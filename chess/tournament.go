@@ -0,0 +1,517 @@
+package chess
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type TournamentFormat int
+
+const (
+	RoundRobin TournamentFormat = iota // everyone plays everyone, Rounds is the number of cycles
+	Swiss                              // entrants with the same score play each other, no one plays the same opponent twice
+)
+
+func (f TournamentFormat) String() string {
+	if f == Swiss {
+		return "Swiss"
+	}
+	return "Round robin"
+}
+
+type TournamentOptions struct {
+	Format TournamentFormat
+	// the number of rounds of a Swiss tournament or the number of cycles of a round robin (2 for a double round
+	// robin, where the colours of the first cycle are reversed), 1 if not set
+	Rounds   int
+	Parallel int // the number of games played at the same time, 1 if not set
+	// creates each game, a standard game from the starting position if nil
+	NewGame func(white Player, black Player, visualizer BoardVisualizer) (*Game, error)
+	PGN     io.Writer // every game is written to it when not nil, round by round
+	Event   string    // the Event tag of the games
+}
+
+// a game of a tournament, White and Black are the numbers of the entrants in the order they were registered.
+// Black is -1 when White has a bye, which is worth a point. A game that ended without an outcome, e.g. because a
+// player failed, is not finished and counts for neither entrant
+type TournamentGame struct {
+	Round  int
+	White  int
+	Black  int
+	Result Result
+}
+
+// returns whether the game ended without an outcome, it is left out of the points and tie breaks
+func (g TournamentGame) unfinished() bool {
+	return g.Black != -1 && g.Result.Outcome == NoOutcome
+}
+
+// returns the points of the entrant in the game, false if the entrant did not play it or it is not finished
+func (g TournamentGame) points(entrant int) (float64, bool) {
+	switch {
+	case g.unfinished():
+		return 0, false
+	case g.Black == -1 && g.White == entrant:
+		return 1, true
+	case g.Result.Outcome == Drawn && (g.White == entrant || g.Black == entrant):
+		return 0.5, true
+	case g.Result.Outcome == WhiteWon && g.White == entrant, g.Result.Outcome == BlackWon && g.Black == entrant:
+		return 1, true
+	case g.White == entrant || g.Black == entrant:
+		return 0, true
+	}
+	return 0, false
+}
+
+// returns the opponent of the entrant in the game, -1 for a bye
+func (g TournamentGame) opponent(entrant int) int {
+	if g.White == entrant {
+		return g.Black
+	}
+	return g.White
+}
+
+type entrant struct {
+	name      string
+	newPlayer PlayerFactory
+}
+
+// plays a round robin or Swiss tournament between registered players, e.g. to compare versions of a bot
+type Tournament struct {
+	Options  TournamentOptions
+	Games    []TournamentGame // the games played so far, round by round
+	entrants []entrant
+}
+
+func NewTournament(options TournamentOptions) *Tournament {
+	return &Tournament{Options: options}
+}
+
+// adds a player to the tournament, names must be unique. Every game gets a new player from the factory
+func (t *Tournament) Register(name string, newPlayer PlayerFactory) error {
+	if newPlayer == nil {
+		return fmt.Errorf("%v has no player factory", name)
+	}
+	for _, e := range t.entrants {
+		if e.name == name {
+			return fmt.Errorf("%v is already registered", name)
+		}
+	}
+	t.entrants = append(t.entrants, entrant{name: name, newPlayer: newPlayer})
+	return nil
+}
+
+// plays all rounds of the tournament, the games of a round are played in parallel
+func (t *Tournament) Run() error {
+	if len(t.entrants) < 2 {
+		return fmt.Errorf("a tournament needs at least two players, got %v", len(t.entrants))
+	}
+	rounds := t.Options.Rounds
+	if rounds < 1 {
+		rounds = 1
+	}
+	if t.Options.Format == Swiss {
+		for round := 1; round <= rounds; round++ {
+			if err := t.playRound(round, t.swissPairings()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	cycle := t.roundRobinPairings()
+	for c := 0; c < rounds; c++ {
+		for i, pairings := range cycle {
+			if c%2 == 1 { // the colours are reversed in every other cycle
+				reversed := make([][2]int, len(pairings))
+				for j, pairing := range pairings {
+					reversed[j] = [2]int{pairing[1], pairing[0]}
+					if pairing[1] == -1 {
+						reversed[j] = pairing
+					}
+				}
+				pairings = reversed
+			}
+			if err := t.playRound(c*len(cycle)+i+1, pairings); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// plays the pairings (white, black) of the round and writes the games to the PGN writer in the order of the pairings
+func (t *Tournament) playRound(round int, pairings [][2]int) error {
+	games := make([]*Game, len(pairings))
+	newGame := t.Options.NewGame
+	if newGame == nil {
+		newGame = newStandardGame
+	}
+	err := playInParallel(len(pairings), t.Options.Parallel, func(i int) error {
+		white, black := pairings[i][0], pairings[i][1]
+		if black == -1 {
+			return nil
+		}
+		game, err := newGame(t.entrants[white].newPlayer(White), t.entrants[black].newPlayer(Black), &NullVisualizer{})
+		if err != nil {
+			return fmt.Errorf("round %v, %v - %v: %w", round, t.entrants[white].name, t.entrants[black].name, err)
+		}
+		game.Start()
+		games[i] = game
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, pairing := range pairings {
+		played := TournamentGame{Round: round, White: pairing[0], Black: pairing[1], Result: Result{Outcome: WhiteWon, Reason: "bye"}}
+		if games[i] != nil {
+			played.Result = games[i].Result()
+			if t.Options.PGN != nil {
+				tags := map[string]string{
+					"Event": t.Options.Event,
+					"Round": strconv.Itoa(round),
+					"White": t.entrants[pairing[0]].name,
+					"Black": t.entrants[pairing[1]].name,
+				}
+				if err := WritePGN(t.Options.PGN, games[i], tags); err != nil {
+					return err
+				}
+			}
+		}
+		t.Games = append(t.Games, played)
+	}
+	return nil
+}
+
+// returns the rounds of one cycle of a round robin with the circle method, the last entrant stays in place while
+// the others rotate. With an odd number of entrants the one paired with the empty seat gets a bye
+func (t *Tournament) roundRobinPairings() [][][2]int {
+	seats := []int{}
+	for i := range t.entrants {
+		seats = append(seats, i)
+	}
+	if len(seats)%2 == 1 {
+		seats = append(seats, -1)
+	}
+	colours := newColourHistory(len(t.entrants))
+	rounds := [][][2]int{}
+	for round := 0; round < len(seats)-1; round++ {
+		pairings := [][2]int{}
+		for i := 0; i < len(seats)/2; i++ {
+			a, b := seats[i], seats[len(seats)-1-i]
+			switch {
+			case a == -1:
+				pairings = append(pairings, [2]int{b, -1})
+			case b == -1:
+				pairings = append(pairings, [2]int{a, -1})
+			default:
+				pairings = append(pairings, colours.assign(a, b, round%2 == 0))
+			}
+		}
+		rounds = append(rounds, pairings)
+		// keep the last seat and rotate the others one step
+		rotated := append([]int{seats[len(seats)-2]}, seats[:len(seats)-2]...)
+		seats = append(rotated, seats[len(seats)-1])
+	}
+	return rounds
+}
+
+// returns the pairings of the next Swiss round. Entrants are ranked by points and then by the order they were
+// registered, the lowest ranked entrant without a bye gets one when the number is odd, and each entrant is paired
+// with the highest ranked entrant it has not played, backtracking when that leaves others without an opponent
+func (t *Tournament) swissPairings() [][2]int {
+	points := make([]float64, len(t.entrants))
+	played := map[[2]int]bool{}
+	hadBye := map[int]bool{}
+	colours := newColourHistory(len(t.entrants))
+	for _, game := range t.Games {
+		if game.Black == -1 {
+			hadBye[game.White] = true
+		} else {
+			played[[2]int{game.White, game.Black}], played[[2]int{game.Black, game.White}] = true, true
+			colours.add(game.White, game.Black)
+		}
+		for _, e := range []int{game.White, game.Black} {
+			if p, ok := game.points(e); ok && e >= 0 {
+				points[e] += p
+			}
+		}
+	}
+	ranked := []int{}
+	for i := range t.entrants {
+		ranked = append(ranked, i)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return points[ranked[i]] > points[ranked[j]] })
+
+	pairings := [][2]int{}
+	if len(ranked)%2 == 1 {
+		bye := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !hadBye[ranked[i]] {
+				bye = i
+				break
+			}
+		}
+		pairings = append(pairings, [2]int{ranked[bye], -1})
+		ranked = append(ranked[:bye:bye], ranked[bye+1:]...)
+	}
+	pairs, ok := pairWithoutRematches(ranked, played)
+	if !ok { // everyone has played everyone, rematches can not be avoided
+		pairs = [][2]int{}
+		for i := 0; i < len(ranked); i += 2 {
+			pairs = append(pairs, [2]int{ranked[i], ranked[i+1]})
+		}
+	}
+	for _, pair := range pairs {
+		pairings = append(pairings, colours.assign(pair[0], pair[1], true))
+	}
+	// the bye is listed last, like in the round robin
+	if len(pairings) > 0 && pairings[0][1] == -1 {
+		pairings = append(pairings[1:], pairings[0])
+	}
+	return pairings
+}
+
+// pairs the first entrant with the highest ranked one it has not played and recurses on the rest
+func pairWithoutRematches(ranked []int, played map[[2]int]bool) ([][2]int, bool) {
+	if len(ranked) == 0 {
+		return [][2]int{}, true
+	}
+	first := ranked[0]
+	for i := 1; i < len(ranked); i++ {
+		if played[[2]int{first, ranked[i]}] {
+			continue
+		}
+		rest := append(append([]int{}, ranked[1:i]...), ranked[i+1:]...)
+		if pairs, ok := pairWithoutRematches(rest, played); ok {
+			return append([][2]int{{first, ranked[i]}}, pairs...), true
+		}
+	}
+	return nil, false
+}
+
+// the colours the entrants have played, used to give everyone white and black equally often
+type colourHistory struct {
+	balance []int    // games with white minus games with black
+	last    []Colour // the colour of the last game
+	played  []bool
+}
+
+func newColourHistory(entrants int) *colourHistory {
+	return &colourHistory{balance: make([]int, entrants), last: make([]Colour, entrants), played: make([]bool, entrants)}
+}
+
+func (c *colourHistory) add(white int, black int) {
+	c.balance[white]++
+	c.balance[black]--
+	c.last[white], c.last[black] = White, Black
+	c.played[white], c.played[black] = true, true
+}
+
+// returns the pair as (white, black) and remembers the colours. White goes to the one who has had white less often,
+// then to the one who had black last, otherwise to a if firstIsWhite
+func (c *colourHistory) assign(a int, b int, firstIsWhite bool) [2]int {
+	aWhite := firstIsWhite
+	switch {
+	case c.balance[a] != c.balance[b]:
+		aWhite = c.balance[a] < c.balance[b]
+	case c.played[a] && c.played[b] && c.last[a] != c.last[b]:
+		aWhite = c.last[a] == Black
+	}
+	if aWhite {
+		c.add(a, b)
+		return [2]int{a, b}
+	}
+	c.add(b, a)
+	return [2]int{b, a}
+}
+
+// returns the number of games that ended without an outcome, they are left out of the standings
+func (t *Tournament) Unfinished() int {
+	unfinished := 0
+	for _, game := range t.Games {
+		if game.unfinished() {
+			unfinished++
+		}
+	}
+	return unfinished
+}
+
+// the score of an entrant, Points include byes
+type Standing struct {
+	Entrant         int // the number of the entrant in the order they were registered
+	Name            string
+	Points          float64
+	Games           int
+	Wins            int
+	Draws           int
+	Losses          int
+	Byes            int
+	Unfinished      int     // games that ended without an outcome, they are not counted in Games
+	SonnebornBerger float64 // the points of the opponents beaten plus half the points of the opponents drawn
+	Buchholz        float64 // the points of all opponents, byes do not count
+}
+
+// returns the standings, best first. Ties are broken by Sonneborn-Berger and then Buchholz in a round robin, and
+// by Buchholz and then Sonneborn-Berger in a Swiss tournament
+func (t *Tournament) Standings() []Standing {
+	standings := make([]Standing, len(t.entrants))
+	for i, e := range t.entrants {
+		standings[i] = Standing{Entrant: i, Name: e.name}
+	}
+	for _, game := range t.Games {
+		if game.Black == -1 {
+			standings[game.White].Points++
+			standings[game.White].Byes++
+			continue
+		}
+		if game.unfinished() {
+			standings[game.White].Unfinished++
+			standings[game.Black].Unfinished++
+			continue
+		}
+		for _, e := range []int{game.White, game.Black} {
+			points, _ := game.points(e)
+			standings[e].Points += points
+			standings[e].Games++
+			switch points {
+			case 1:
+				standings[e].Wins++
+			case 0.5:
+				standings[e].Draws++
+			default:
+				standings[e].Losses++
+			}
+		}
+	}
+	for _, game := range t.Games {
+		if game.Black == -1 || game.unfinished() {
+			continue
+		}
+		for _, e := range []int{game.White, game.Black} {
+			points, _ := game.points(e)
+			opponentPoints := standings[game.opponent(e)].Points
+			standings[e].Buchholz += opponentPoints
+			standings[e].SonnebornBerger += points * opponentPoints
+		}
+	}
+	tieBreaks := func(s Standing) [2]float64 {
+		if t.Options.Format == Swiss {
+			return [2]float64{s.Buchholz, s.SonnebornBerger}
+		}
+		return [2]float64{s.SonnebornBerger, s.Buchholz}
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		a, b := tieBreaks(standings[i]), tieBreaks(standings[j])
+		if a[0] != b[0] {
+			return a[0] > b[0]
+		}
+		return a[1] > b[1]
+	})
+	return standings
+}
+
+// returns the crosstable with the entrants in the order of the standings. In a round robin each cell holds the
+// results against the entrant of that column (1, = or 0, * for unfinished games), in a Swiss tournament each round
+// has a column with the rank of the opponent, the colour and the result, e.g. 3w1
+func (t *Tournament) Crosstable() string {
+	standings := t.Standings()
+	rank := map[int]int{}
+	nameWidth := len("Name")
+	for i, s := range standings {
+		rank[s.Entrant] = i + 1
+		if len(s.Name) > nameWidth {
+			nameWidth = len(s.Name)
+		}
+	}
+	symbol := func(game TournamentGame, entrant int) string {
+		if game.unfinished() {
+			return "*"
+		}
+		points, _ := game.points(entrant)
+		switch points {
+		case 1:
+			return "1"
+		case 0.5:
+			return "="
+		default:
+			return "0"
+		}
+	}
+
+	columns := []string{}
+	cells := make([][]string, len(standings))
+	if t.Options.Format == Swiss {
+		rounds := 0
+		for _, game := range t.Games {
+			if game.Round > rounds {
+				rounds = game.Round
+			}
+		}
+		for round := 1; round <= rounds; round++ {
+			columns = append(columns, strconv.Itoa(round))
+		}
+		for i, s := range standings {
+			cells[i] = make([]string, rounds)
+			for _, game := range t.Games {
+				switch {
+				case game.White != s.Entrant && game.Black != s.Entrant:
+					continue
+				case game.Black == -1:
+					cells[i][game.Round-1] = "bye"
+				case game.White == s.Entrant:
+					cells[i][game.Round-1] = fmt.Sprintf("%vw%v", rank[game.Black], symbol(game, s.Entrant))
+				default:
+					cells[i][game.Round-1] = fmt.Sprintf("%vb%v", rank[game.White], symbol(game, s.Entrant))
+				}
+			}
+		}
+	} else {
+		for i := range standings {
+			columns = append(columns, strconv.Itoa(i+1))
+		}
+		for i, s := range standings {
+			cells[i] = make([]string, len(standings))
+			cells[i][i] = "x"
+			for _, game := range t.Games {
+				if (game.White == s.Entrant || game.Black == s.Entrant) && game.Black != -1 {
+					cells[i][rank[game.opponent(s.Entrant)]-1] += symbol(game, s.Entrant)
+				}
+			}
+		}
+	}
+	width := 1
+	for i := range cells {
+		for _, cell := range cells[i] {
+			if len(cell) > width {
+				width = len(cell)
+			}
+		}
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%3v  %-*v", "#", nameWidth, "Name")
+	for _, column := range columns {
+		fmt.Fprintf(&text, " %*v", width, column)
+	}
+	fmt.Fprintf(&text, " %6v %6v %6v\n", "Points", "SB", "Buch")
+	for i, s := range standings {
+		fmt.Fprintf(&text, "%3v  %-*v", i+1, nameWidth, s.Name)
+		for _, cell := range cells[i] {
+			fmt.Fprintf(&text, " %*v", width, cell)
+		}
+		fmt.Fprintf(&text, " %6v %6v %6v\n", formatPoints(s.Points), formatPoints(s.SonnebornBerger), formatPoints(s.Buchholz))
+	}
+	return text.String()
+}
+
+// returns points without trailing zeros, e.g. 2, 2.5 or 6.25
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
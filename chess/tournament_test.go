@@ -0,0 +1,181 @@
+package chess
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func resigningPlayer(colour Colour) Player {
+	return &scriptedPlayer{colour: colour}
+}
+
+func TestTournament_double_round_robin(t *testing.T) {
+	defer quiet()()
+	var pgn bytes.Buffer
+	tournament := NewTournament(TournamentOptions{Format: RoundRobin, Rounds: 2, Parallel: 2, PGN: &pgn, Event: "Test"})
+	for _, name := range []string{"A", "B", "C", "D"} {
+		if err := tournament.Register(name, foolsMatePlayer); err != nil {
+			t.Fatalf("Failed to register %v, %v", name, err)
+		}
+	}
+	if err := tournament.Register("A", resigningPlayer); err == nil {
+		t.Errorf("Expected a duplicate name to be rejected")
+	}
+	if err := tournament.Run(); err != nil {
+		t.Fatalf("Failed to run the tournament, %v", err)
+	}
+	if len(tournament.Games) != 12 {
+		t.Fatalf("Expected 12 games, got %v", len(tournament.Games))
+	}
+	pairings, whites := map[[2]int]int{}, map[int]int{}
+	for _, game := range tournament.Games {
+		pairings[[2]int{game.White, game.Black}]++
+		whites[game.White]++
+		if game.Round < 1 || game.Round > 6 {
+			t.Errorf("Expected rounds 1 to 6, got %v", game.Round)
+		}
+	}
+	for a := 0; a < 4; a++ {
+		if whites[a] != 3 {
+			t.Errorf("Expected entrant %v to have white 3 times, got %v", a, whites[a])
+		}
+		for b := 0; b < 4; b++ {
+			if a != b && pairings[[2]int{a, b}] != 1 {
+				t.Errorf("Expected %v to play %v with white once, got %v", a, b, pairings[[2]int{a, b}])
+			}
+		}
+	}
+	// black mates in every game
+	for _, standing := range tournament.Standings() {
+		if standing.Points != 3 || standing.Games != 6 || standing.Wins != 3 || standing.Losses != 3 {
+			t.Errorf("Expected 3 wins and 3 losses, got %+v", standing)
+		}
+	}
+	games, err := ParsePGN(&pgn)
+	if err != nil || len(games) != 12 {
+		t.Fatalf("Expected 12 games in the PGN, got %v (%v)", len(games), err)
+	}
+	if games[0].Tags["Event"] != "Test" || games[0].Tags["Round"] != "1" || games[0].Result != "0-1" {
+		t.Errorf("Unexpected first game %+v", games[0])
+	}
+}
+
+func TestTournament_round_robin_with_a_bye(t *testing.T) {
+	defer quiet()()
+	tournament := NewTournament(TournamentOptions{})
+	for _, name := range []string{"A", "B", "C"} {
+		tournament.Register(name, resigningPlayer)
+	}
+	if err := tournament.Run(); err != nil {
+		t.Fatalf("Failed to run the tournament, %v", err)
+	}
+	byes, games := map[int]int{}, 0
+	for _, game := range tournament.Games {
+		if game.Black == -1 {
+			byes[game.White]++
+		} else {
+			games++
+		}
+	}
+	if games != 3 || len(byes) != 3 {
+		t.Errorf("Expected 3 games and a bye for everyone, got %v games and byes %v", games, byes)
+	}
+}
+
+func TestTournament_standings_and_tie_breaks(t *testing.T) {
+	tournament := NewTournament(TournamentOptions{})
+	for _, name := range []string{"A", "B", "C", "D"} {
+		tournament.Register(name, resigningPlayer)
+	}
+	win, draw := Result{Outcome: WhiteWon}, Result{Outcome: Drawn}
+	tournament.Games = []TournamentGame{
+		{Round: 1, White: 0, Black: 1, Result: win},  // A beats B
+		{Round: 1, White: 2, Black: 3, Result: draw}, // C draws D
+		{Round: 2, White: 0, Black: 2, Result: draw}, // A draws C
+		{Round: 2, White: 1, Black: 3, Result: win},  // B beats D
+		{Round: 3, White: 3, Black: 0, Result: win},  // D beats A
+		{Round: 3, White: 1, Black: 2, Result: win},  // B beats C
+	}
+	standings := tournament.Standings()
+	expected := []Standing{
+		{Entrant: 1, Name: "B", Points: 2, Games: 3, Wins: 2, Losses: 1, SonnebornBerger: 2.5, Buchholz: 4},
+		{Entrant: 0, Name: "A", Points: 1.5, Games: 3, Wins: 1, Draws: 1, Losses: 1, SonnebornBerger: 2.5, Buchholz: 4.5},
+		{Entrant: 3, Name: "D", Points: 1.5, Games: 3, Wins: 1, Draws: 1, Losses: 1, SonnebornBerger: 2, Buchholz: 4.5},
+		{Entrant: 2, Name: "C", Points: 1, Games: 3, Draws: 2, Losses: 1, SonnebornBerger: 1.5, Buchholz: 5},
+	}
+	for i := range expected {
+		if standings[i] != expected[i] {
+			t.Errorf("Expected %+v at %v, got %+v", expected[i], i+1, standings[i])
+		}
+	}
+	lines := strings.Split(tournament.Crosstable(), "\n")
+	if lines[2] != "  2  A    1 x 0 =    1.5    2.5    4.5" {
+		t.Errorf("Unexpected crosstable row for A %q", lines[2])
+	}
+}
+
+func TestTournament_unfinished_games_are_left_out_of_the_standings(t *testing.T) {
+	tournament := NewTournament(TournamentOptions{})
+	for _, name := range []string{"A", "B", "C"} {
+		tournament.Register(name, resigningPlayer)
+	}
+	tournament.Games = []TournamentGame{
+		{Round: 1, White: 0, Black: 1, Result: Result{Outcome: WhiteWon}}, // A beats B
+		{Round: 1, White: 2, Black: -1, Result: Result{Outcome: WhiteWon, Reason: "bye"}},
+		{Round: 2, White: 1, Black: 2, Result: Result{}}, // B - C is aborted
+		{Round: 2, White: 0, Black: -1, Result: Result{Outcome: WhiteWon, Reason: "bye"}},
+	}
+	if tournament.Unfinished() != 1 {
+		t.Errorf("Expected 1 unfinished game, got %v", tournament.Unfinished())
+	}
+	standings := tournament.Standings()
+	expected := []Standing{
+		{Entrant: 0, Name: "A", Points: 2, Games: 1, Wins: 1, Byes: 1},
+		{Entrant: 2, Name: "C", Points: 1, Byes: 1, Unfinished: 1},
+		{Entrant: 1, Name: "B", Points: 0, Games: 1, Losses: 1, Unfinished: 1, Buchholz: 2},
+	}
+	for i := range expected {
+		if standings[i] != expected[i] {
+			t.Errorf("Expected %+v at %v, got %+v", expected[i], i+1, standings[i])
+		}
+	}
+	lines := strings.Split(tournament.Crosstable(), "\n")
+	if !strings.HasPrefix(lines[3], "  3  B    0 * x") {
+		t.Errorf("Expected the aborted game to be marked *, got %q", lines[3])
+	}
+}
+
+func TestTournament_swiss(t *testing.T) {
+	defer quiet()()
+	tournament := NewTournament(TournamentOptions{Format: Swiss, Rounds: 3, Parallel: 2})
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		tournament.Register(name, foolsMatePlayer)
+	}
+	if err := tournament.Run(); err != nil {
+		t.Fatalf("Failed to run the tournament, %v", err)
+	}
+	if len(tournament.Games) != 9 {
+		t.Fatalf("Expected 2 games and a bye in each of 3 rounds, got %v", len(tournament.Games))
+	}
+	played, byes := map[[2]int]bool{}, map[int]bool{}
+	for _, game := range tournament.Games {
+		if game.Black == -1 {
+			if byes[game.White] {
+				t.Errorf("Expected no one to get two byes, %v did", game.White)
+			}
+			byes[game.White] = true
+			continue
+		}
+		if played[[2]int{game.White, game.Black}] || played[[2]int{game.Black, game.White}] {
+			t.Errorf("Expected no rematches, %v and %v played twice", game.White, game.Black)
+		}
+		played[[2]int{game.White, game.Black}] = true
+	}
+	if len(byes) != 3 {
+		t.Errorf("Expected 3 different byes, got %v", byes)
+	}
+	if table := tournament.Crosstable(); !strings.Contains(table, "bye") {
+		t.Errorf("Expected the byes in the crosstable, got\n%v", table)
+	}
+}
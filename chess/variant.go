@@ -0,0 +1,79 @@
+package chess
+
+// the rules of a chess variant, consulted by Game and Board on top of the standard piece movement
+type Variant interface {
+	Name() string
+	// returns a board with the starting position of the variant
+	NewBoard() *Board
+	// returns true if a king may not be left in check (false makes the king an ordinary piece that can be taken)
+	KingIsRoyal() bool
+	// returns an error if the variant does not allow the move, moves the pieces can not make anyway should be let through
+	AllowMove(b *Board, p *Piece, move Move) error
+	// called after a move has been made, p is the piece that moved (for special effects, e.g. explosions)
+	AfterMove(b *Board, p *Piece, move Move, result *MoveResult)
+	// returns the result and true if the game is over according to the variant
+	GameOver(g *Game) (Result, bool)
+}
+
+// optional interface a Variant can implement to react to captures (e.g. explosions in atomic)
+type CaptureHandler interface {
+	AfterCapture(b *Board, capturer *Piece, captured *Piece)
+}
+
+// optional interface a Variant can implement when check works differently (e.g. atomic, where touching kings
+// can not check each other). A king is mated when it is in check and there are no legal moves
+type CheckRules interface {
+	KingIsInCheck(b *Board, colour Colour) (bool, []Piece)
+	MoveIsLegal(b *Board, p *Piece, target Square) bool
+}
+
+// returns all variants that can be played, standard chess first
+func Variants() []Variant {
+	return []Variant{Standard{}, RacingKings{}, Extinction{}, Atomic{}, Crazyhouse{}, Antichess{}, Horde{}}
+}
+
+// standard chess, variants can embed it to only override the rules that differ
+type Standard struct{}
+
+func (Standard) Name() string {
+	return "Standard"
+}
+
+func (Standard) NewBoard() *Board {
+	return NewBoard()
+}
+
+func (Standard) KingIsRoyal() bool {
+	return true
+}
+
+func (Standard) AllowMove(b *Board, p *Piece, move Move) error {
+	return nil
+}
+
+func (Standard) AfterMove(b *Board, p *Piece, move Move, result *MoveResult) {}
+
+// checkmate, stale mate and insufficient material
+func (Standard) GameOver(g *Game) (Result, bool) {
+	if g.Board.kingIsInMate(Black) {
+		return winFor(White, Checkmate, "Black is in mate"), true
+	}
+	if g.Board.kingIsInMate(White) {
+		return winFor(Black, Checkmate, "White is in mate"), true
+	}
+	if g.Board.isStaleMate(White) || g.Board.isStaleMate(Black) {
+		return drawBy(Stalemate, "Stale mate"), true
+	}
+	if g.Board.hasInsufficientMaterial() {
+		return drawBy(InsufficientMaterial, "Insufficient material"), true
+	}
+	return Result{}, false
+}
+
+// returns the opposite colour
+func opponentOf(colour Colour) Colour {
+	if colour == White {
+		return Black
+	}
+	return White
+}
@@ -0,0 +1,141 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+// creates a game of the variant starting from the given FEN
+func newVariantGameFromFEN(t *testing.T, variant Variant, fen string) *Game {
+	game := NewVariantGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, variant)
+	position, err := ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("Failed to parse FEN, %v", err)
+	}
+	game.Board = position.Board
+	game.Board.variant = variant
+	game.NextToMove = position.NextToMove
+	game.setStart()
+	return game
+}
+
+func TestStandard_game_is_unchanged(t *testing.T) {
+	defer quiet()()
+	game := NewVariantGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, Standard{})
+	if game.FEN() != StartingPositionFEN {
+		t.Errorf("Expected the standard starting position, got %v", game.FEN())
+	}
+	if moves := game.LegalMoves(); len(moves) != 20 {
+		t.Errorf("Expected 20 legal moves in the starting position, got %v", len(moves))
+	}
+}
+
+func TestRacingKings_starting_position(t *testing.T) {
+	defer quiet()()
+	game := NewVariantGame(&scriptedPlayer{colour: White}, &scriptedPlayer{colour: Black}, &noopVisualizer{}, RacingKings{})
+	expectedStateOfBoard := `
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	.  .  .  .  .  .  .  .
+	♚  ♜  ♝  ♞  ♘  ♗  ♖  ♔
+	♛  ♜  ♝  ♞  ♘  ♗  ♖  ♕
+	`
+	if err := assertExpectedBoardState(expectedStateOfBoard, game.Board); err != nil {
+		t.Errorf("Failed to assert expected board state, %v (Visible whitespace is ignored, something else differs!", err.Error())
+	}
+	if isGameOver(game) {
+		t.Errorf("Expected the game not to be over in the starting position, got %+v", game.result)
+	}
+}
+
+func TestRacingKings_giving_check_is_not_allowed(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, RacingKings{}, "8/8/8/8/8/8/k7/6RK w - - 0 1")
+	_, err := game.move(Move{From: Square{"G", 1}, To: Square{"G", 2}}, White)
+	if !errors.Is(err, ErrForbiddenByVariant) {
+		t.Errorf("Expected giving check to be forbidden, got %v", err)
+	}
+	if _, ok := game.LegalMoves()[Move{From: Square{"G", 1}, To: Square{"G", 2}}]; ok {
+		t.Errorf("Expected the checking move not to be among the legal moves")
+	}
+	if _, err := game.move(Move{From: Square{"G", 1}, To: Square{"G", 3}}, White); err != nil {
+		t.Errorf("Expected a quiet rook move to be allowed, got %v", err)
+	}
+}
+
+func TestRacingKings_white_wins_if_black_can_not_follow(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, RacingKings{}, "8/6K1/8/8/8/8/k7/8 w - - 0 1")
+	if err := playMoves(game, []Move{{From: Square{"G", 7}, To: Square{"G", 8}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if !isGameOver(game) {
+		t.Errorf("Expected the game to be over")
+	}
+	if winner, ok := game.result.Winner(); !ok || winner != White || game.result.Termination != VariantRule {
+		t.Errorf("Expected White to win, got %+v", game.result)
+	}
+}
+
+func TestRacingKings_draw_if_black_follows(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, RacingKings{}, "8/k5K1/8/8/8/8/8/8 w - - 0 1")
+	if err := playMoves(game, []Move{{From: Square{"G", 7}, To: Square{"G", 8}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if isGameOver(game) {
+		t.Errorf("Expected Black to get one more move, got %+v", game.result)
+	}
+	if err := playMoves(game, []Move{{From: Square{"A", 7}, To: Square{"A", 8}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if !isGameOver(game) || !game.result.Draw() {
+		t.Errorf("Expected a draw, got %+v", game.result)
+	}
+}
+
+func TestExtinction_taking_the_last_piece_of_a_type_wins(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Extinction{}, "4k3/8/8/8/8/8/3n4/4K3 w - - 0 1")
+	if isGameOver(game) {
+		t.Errorf("Expected the game not to be over, got %+v", game.result)
+	}
+	if err := playMoves(game, []Move{{From: Square{"E", 1}, To: Square{"D", 2}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if !isGameOver(game) || game.result.Reason != "Black has no Knights left" {
+		t.Errorf("Expected White to win by taking the last knight, got %+v", game.result)
+	}
+}
+
+func TestExtinction_promoting_the_last_pawn_loses(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Extinction{}, "4k3/P7/8/8/8/8/8/4K3 w - - 0 1")
+	if err := playMoves(game, []Move{{From: Square{"A", 7}, To: Square{"A", 8}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if !isGameOver(game) {
+		t.Errorf("Expected the game to be over")
+	}
+	if winner, ok := game.result.Winner(); !ok || winner != Black || game.result.Reason != "White has no Pawns left" {
+		t.Errorf("Expected Black to win when White promotes its last pawn, got %+v", game.result)
+	}
+}
+
+func TestExtinction_king_can_be_taken(t *testing.T) {
+	defer quiet()()
+	game := newVariantGameFromFEN(t, Extinction{}, "4k3/8/8/8/8/8/8/r3K3 w - - 0 1")
+	// moving along the attacked row is fine, there is no check
+	if err := playMoves(game, []Move{{From: Square{"E", 1}, To: Square{"D", 1}}, {From: Square{"A", 1}, To: Square{"D", 1}}}); err != nil {
+		t.Errorf("Failed to move, %v", err)
+	}
+	if !isGameOver(game) {
+		t.Errorf("Expected the game to be over")
+	}
+	if winner, ok := game.result.Winner(); !ok || winner != Black || game.result.Reason != "White has no Kings left" {
+		t.Errorf("Expected Black to win by taking the king, got %+v", game.result)
+	}
+}
@@ -20,7 +20,14 @@ func NewSimpleBot(colour chess.Colour, delayInMS int) *SimpleBot {
 
 func (bot *SimpleBot) PickMove(g *chess.Game) (*chess.Move, error) {
 	time.Sleep(time.Duration(bot.DelayInMS) * time.Millisecond) // so we can see what it is doing
-	moves := g.Board.GetAllMovesFor(bot.Colour)
+	// take the draw unless we are ahead
+	if g.CanClaimDraw() && bot.materialBalance(g.Board) <= 0 {
+		if err := g.ClaimDraw(bot.Colour); err != nil {
+			return nil, err
+		}
+		return nil, chess.ErrGameFinished
+	}
+	moves := g.LegalMoves()
 	bestMove, err := bot.Evaluate(g, moves)
 	if err != nil {
 		return nil, err
@@ -28,6 +35,49 @@ func (bot *SimpleBot) PickMove(g *chess.Game) (*chess.Move, error) {
 	return &bestMove, nil
 }
 
+// plays a random legal move, a baseline to compare other bots with
+type RandomBot struct {
+	Colour chess.Colour
+}
+
+func (bot *RandomBot) PickMove(g *chess.Game) (*chess.Move, error) {
+	move, err := pick(g.LegalMoves())
+	if err != nil {
+		return nil, err
+	}
+	return &move, nil
+}
+
+// accepts a draw offer unless the bot is ahead in material
+func (bot *SimpleBot) RespondToDrawOffer(g *chess.Game, offeredBy chess.Colour) bool {
+	return bot.materialBalance(g.Board) <= 0
+}
+
+// returns the value of the bots pieces in play minus the value of the opponents pieces in play
+func (bot *SimpleBot) materialBalance(b *chess.Board) int {
+	balance := whiteMaterialBalance(b)
+	if bot.Colour == chess.Black {
+		balance = -balance
+	}
+	return balance
+}
+
+// returns the value of whites pieces in play minus the value of blacks pieces in play
+func whiteMaterialBalance(b *chess.Board) int {
+	balance := 0
+	for _, p := range b.WhitePieces {
+		if p.InPlay {
+			balance += p.GetValue()
+		}
+	}
+	for _, p := range b.BlackPieces {
+		if p.InPlay {
+			balance -= p.GetValue()
+		}
+	}
+	return balance
+}
+
 type MoveEvaluation struct {
 	Move       chess.Move
 	MoveResult *chess.MoveResult
@@ -38,6 +88,10 @@ type MoveEvaluation struct {
 func (bot *SimpleBot) Evaluate(game *chess.Game, moves map[chess.Move]*chess.MoveResult) (chess.Move, error) {
 	var evals = make([]MoveEvaluation, 0)
 	for m, r := range moves {
+		if m.Kind == chess.Drop { // drops never take anything
+			evals = append(evals, MoveEvaluation{Move: m, MoveResult: r, Value: 0})
+			continue
+		}
 		found, p := game.Board.GetPieceAtSquare(m.From.Column, m.From.Row)
 		if !found {
 			return chess.Move{}, errors.New("could 	not find piece at square")
@@ -90,6 +144,12 @@ func (bot *SimpleBot) Evaluate(game *chess.Game, moves map[chess.Move]*chess.Mov
 		bestMove = &bm
 	}
 
+	// drops come from the legal moves of the game, no need to check them again
+	if bestMove.Kind == chess.Drop {
+		fmt.Printf("\nBot picked drop %v on %v%v\n", bestMove.Piece, bestMove.To.Column, bestMove.To.Row)
+		return *bestMove, nil
+	}
+
 	// return if legal and valid or try again
 	found, attacker := game.Board.GetPieceAtSquare(bestMove.From.Column, bestMove.From.Row)
 	if !found {
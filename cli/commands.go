@@ -0,0 +1,662 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hellgrenj/blue-panda/chess"
+)
+
+const usage = `usage: cli [command] [flags]
+
+Without a command the interactive menu is shown.
+
+commands:
+  play        play a game, e.g. play --white human --black bot
+  selfplay    let the computer play itself, e.g. selfplay --games 1000 --parallel 8
+  perft       count the positions reachable from a position, e.g. perft --fen "..." --depth 5
+  tournament  let bots play a round robin or Swiss tournament, e.g. tournament --bots simple,simple,random --format swiss
+  match       play pairs of games between two bots and estimate the Elo difference, e.g. match --a simple --b random --sprt
+  book        build an opening book from PGN files, e.g. book --depth 20 --out book.bin games.pgn
+  replay      show the games in a PGN file move by move, e.g. replay file.pgn
+  analyze     show the status, legal moves and a suggested move for a position, e.g. analyze --fen "..."
+
+run cli [command] -h to see the flags of a command`
+
+// runs the subcommand named by the first argument and returns the exit code
+func runCommand(args []string) int {
+	commands := map[string]func([]string) error{
+		"play":       playCommand,
+		"selfplay":   selfplayCommand,
+		"perft":      perftCommand,
+		"replay":     replayCommand,
+		"tournament": tournamentCommand,
+		"match":      matchCommand,
+		"book":       bookCommand,
+		"analyze":    analyzeCommand,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintln(os.Stderr, usage)
+		if args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+			return 0
+		}
+		return 2
+	}
+	if err := command(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func playCommand(args []string) error {
+	flags := flag.NewFlagSet("play", flag.ContinueOnError)
+	white := flags.String("white", "human", "the white player: human, bot or tui (full screen)")
+	black := flags.String("black", "bot", "the black player: human, bot or tui (full screen)")
+	fen := flags.String("fen", "", "start from this position instead of the starting position")
+	variantName := flags.String("variant", "Standard", "the variant to play, one of "+variantNames())
+	delay := flags.Int("delay", 1500, "milliseconds the bot waits before it moves")
+	bookFile := flags.String("book", "", "a Polyglot opening book (.bin) the bots play from while in book")
+	syzygyPath := flags.String("syzygy", "", "directories of Syzygy tables the bots play endgames from, separated like PATH")
+	solve := flags.Bool("solve", true, "let the bots solve KQvK, KRvK, KPvK and KBNvK endings in memory and play them perfectly")
+	whiteTime := flags.Duration("white-time", 0, "the time white has for the whole game, e.g. 5m, unlimited if 0")
+	blackTime := flags.Duration("black-time", 0, "the time black has for the whole game, e.g. 5m, unlimited if 0")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *whiteTime < 0 || *blackTime < 0 {
+		return fmt.Errorf("the time can not be negative")
+	}
+	extras, err := loadBotExtras(*bookFile, *syzygyPath, *solve)
+	if err != nil {
+		return err
+	}
+	variant, err := variantByName(*variantName)
+	if err != nil {
+		return err
+	}
+	whitePlayer, whiteTUI, err := newPlayer(*white, chess.White, *delay, extras)
+	if err != nil {
+		return err
+	}
+	blackPlayer, blackTUI, err := newPlayer(*black, chess.Black, *delay, extras)
+	if err != nil {
+		return err
+	}
+	if whiteTUI != nil && blackTUI != nil {
+		return fmt.Errorf("only one side can play in full screen")
+	}
+	var visualizer chess.BoardVisualizer = &CLIPrinter{Flipped: *black != "bot" && *white == "bot"}
+	tui := whiteTUI
+	if blackTUI != nil {
+		tui = blackTUI
+	}
+	if tui != nil {
+		visualizer = tui
+	}
+	game, err := newGame(whitePlayer, blackPlayer, visualizer, variant, *fen)
+	if err != nil {
+		return err
+	}
+	game.SetTimeControl(*whiteTime, *blackTime)
+	if tui != nil {
+		tui.game = game
+	}
+	playGame(game)
+	return nil
+}
+
+func selfplayCommand(args []string) error {
+	flags := flag.NewFlagSet("selfplay", flag.ContinueOnError)
+	games := flags.Int("games", 100, "the number of games to play")
+	parallel := flags.Int("parallel", runtime.NumCPU(), "the number of games to play at the same time")
+	fen := flags.String("fen", "", "start every game from this position instead of the starting position")
+	variantName := flags.String("variant", "Standard", "the variant to play, one of "+variantNames())
+	pgn := flags.String("pgn", "selfplay.pgn", "the file the games are written to, none if empty")
+	bookFile := flags.String("book", "", "a Polyglot opening book (.bin) the bots play from while in book")
+	syzygyPath := flags.String("syzygy", "", "directories of Syzygy tables the bots play endgames from, separated like PATH")
+	solve := flags.Bool("solve", true, "let the bots solve KQvK, KRvK, KPvK and KBNvK endings in memory and play them perfectly")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	extras, err := loadBotExtras(*bookFile, *syzygyPath, *solve)
+	if err != nil {
+		return err
+	}
+	variant, err := variantByName(*variantName)
+	if err != nil {
+		return err
+	}
+	if _, err := newGame(nil, nil, &chess.NullVisualizer{}, variant, *fen); err != nil {
+		return err // fail before the output is silenced
+	}
+	stats, err := runSelfplay(*games, *parallel, *pgn, extras, func(white chess.Player, black chess.Player, visualizer chess.BoardVisualizer) (*chess.Game, error) {
+		return newGame(white, black, visualizer, variant, *fen)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Print(stats)
+	return nil
+}
+
+// plays games of SimpleBot against itself, with the book and tablebase if there are any, and writes them to the PGN file unless it
+// is empty
+func runSelfplay(games int, parallel int, pgnFile string, extras botExtras, newGame func(chess.Player, chess.Player, chess.BoardVisualizer) (*chess.Game, error)) (chess.BatchStats, error) {
+	options := chess.BatchOptions{
+		Games:    games,
+		Parallel: parallel,
+		White:    extras.wrap(func(colour chess.Colour) chess.Player { return NewSimpleBot(colour, 0) }),
+		Black:    extras.wrap(func(colour chess.Colour) chess.Player { return NewSimpleBot(colour, 0) }),
+		NewGame:  newGame,
+		Tags:     map[string]string{"Event": "blue-panda selfplay", "White": "SimpleBot", "Black": "SimpleBot", "Date": time.Now().Format("2006.01.02")},
+	}
+	if pgnFile != "" {
+		f, err := os.Create(pgnFile)
+		if err != nil {
+			return chess.BatchStats{}, err
+		}
+		defer f.Close()
+		options.PGN = f
+	}
+	restore := silenceOutput() // the bots and the games print as they play
+	stats, err := chess.RunBatch(options)
+	restore()
+	return stats, err
+}
+
+func tournamentCommand(args []string) error {
+	flags := flag.NewFlagSet("tournament", flag.ContinueOnError)
+	bots := flags.String("bots", "simple,simple,random,random", "comma separated bots to register: simple or random")
+	format := flags.String("format", "roundrobin", "roundrobin or swiss")
+	rounds := flags.Int("rounds", 1, "the number of rounds of a Swiss tournament or cycles of a round robin")
+	parallel := flags.Int("parallel", runtime.NumCPU(), "the number of games to play at the same time")
+	pgn := flags.String("pgn", "tournament.pgn", "the file the games are written to, none if empty")
+	bookFile := flags.String("book", "", "a Polyglot opening book (.bin) the bots play from while in book")
+	syzygyPath := flags.String("syzygy", "", "directories of Syzygy tables the bots play endgames from, separated like PATH")
+	solve := flags.Bool("solve", true, "let the bots solve KQvK, KRvK, KPvK and KBNvK endings in memory and play them perfectly")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	extras, err := loadBotExtras(*bookFile, *syzygyPath, *solve)
+	if err != nil {
+		return err
+	}
+	options := chess.TournamentOptions{Rounds: *rounds, Parallel: *parallel, Event: "blue-panda tournament"}
+	switch *format {
+	case "roundrobin":
+		options.Format = chess.RoundRobin
+	case "swiss":
+		options.Format = chess.Swiss
+	default:
+		return fmt.Errorf("unknown format %q, use roundrobin or swiss", *format)
+	}
+	if *pgn != "" {
+		f, err := os.Create(*pgn)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		options.PGN = f
+	}
+	tournament := chess.NewTournament(options)
+	for i, kind := range strings.Split(*bots, ",") {
+		kind = strings.TrimSpace(kind)
+		factory, err := botFactory(kind, extras)
+		if err != nil {
+			return err
+		}
+		if err := tournament.Register(fmt.Sprintf("%v %v", kind, i+1), factory); err != nil {
+			return err
+		}
+	}
+	restore := silenceOutput() // the bots and the games print as they play
+	err = tournament.Run()
+	restore()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v, %v games", options.Format, len(tournament.Games))
+	if unfinished := tournament.Unfinished(); unfinished > 0 {
+		fmt.Printf(", %v not finished and left out of the standings", unfinished)
+	}
+	fmt.Printf("\n\n%v", tournament.Crosstable())
+	return nil
+}
+
+func matchCommand(args []string) error {
+	flags := flag.NewFlagSet("match", flag.ContinueOnError)
+	a := flags.String("a", "simple", "the first bot: simple or random")
+	b := flags.String("b", "random", "the second bot: simple or random")
+	pairs := flags.Int("pairs", 100, "the most pairs of games to play, each opening is played with both colours")
+	parallel := flags.Int("parallel", runtime.NumCPU(), "the number of pairs to play at the same time")
+	openings := flags.String("openings", "", "a file with one FEN per line to start the pairs from, the starting position if empty")
+	sprt := flags.Bool("sprt", false, "stop as soon as the SPRT accepts one of its hypotheses")
+	elo0 := flags.Float64("elo0", 0, "the Elo difference of H0 of the SPRT")
+	elo1 := flags.Float64("elo1", 10, "the Elo difference of H1 of the SPRT")
+	alpha := flags.Float64("alpha", 0.05, "the chance the SPRT accepts H1 when H0 is true")
+	beta := flags.Float64("beta", 0.05, "the chance the SPRT accepts H0 when H1 is true")
+	pgn := flags.String("pgn", "match.pgn", "the file the games are written to, none if empty")
+	bookFile := flags.String("book", "", "a Polyglot opening book (.bin) the bots play from while in book")
+	syzygyPath := flags.String("syzygy", "", "directories of Syzygy tables the bots play endgames from, separated like PATH")
+	solve := flags.Bool("solve", true, "let the bots solve KQvK, KRvK, KPvK and KBNvK endings in memory and play them perfectly")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	extras, err := loadBotExtras(*bookFile, *syzygyPath, *solve)
+	if err != nil {
+		return err
+	}
+	factoryA, err := botFactory(*a, extras)
+	if err != nil {
+		return err
+	}
+	factoryB, err := botFactory(*b, extras)
+	if err != nil {
+		return err
+	}
+	options := chess.MatchOptions{Pairs: *pairs, Parallel: *parallel, Names: [2]string{*a + " (a)", *b + " (b)"}}
+	if *sprt {
+		options.SPRT = &chess.SPRT{Elo0: *elo0, Elo1: *elo1, Alpha: *alpha, Beta: *beta}
+	}
+	if *openings != "" {
+		data, err := os.ReadFile(*openings)
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				options.Openings = append(options.Openings, line)
+			}
+		}
+	}
+	if *pgn != "" {
+		f, err := os.Create(*pgn)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		options.PGN = f
+	}
+	restore := silenceOutput() // the bots and the games print as they play
+	result, err := chess.PlayMatch(factoryA, factoryB, options)
+	restore()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v vs %v\n%v", *a, *b, result)
+	return nil
+}
+
+// builds an opening book from the games in PGN files and writes it as Polyglot or JSON
+func bookCommand(args []string) error {
+	flags := flag.NewFlagSet("book", flag.ContinueOnError)
+	depth := flags.Int("depth", 16, "the number of plies of each game that are added")
+	minGames := flags.Int("min-games", 1, "leave out moves played in fewer games")
+	out := flags.String("out", "book.bin", "the file the book is written to")
+	format := flags.String("format", "polyglot", "the format of the book, polyglot or json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: cli book [flags] file.pgn...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("book needs at least one PGN file")
+	}
+	if *format != "polyglot" && *format != "json" {
+		return fmt.Errorf("unknown format %q, use polyglot or json", *format)
+	}
+	builder := chess.NewBookBuilder(chess.BookBuilderOptions{MaxPly: *depth, MinGames: *minGames})
+	for _, path := range flags.Args() {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		games, err := chess.ParsePGN(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%v: %w", path, err)
+		}
+		skipped, err := builder.AddGames(games)
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "%v: skipped %v of %v games, the first because of %v\n", path, skipped, len(games), err)
+		}
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if *format == "json" {
+		err = builder.WriteJSON(f)
+	} else {
+		err = builder.WritePolyglot(f)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote the book of %v games to %v\n", builder.Games, *out)
+	return nil
+}
+
+// returns the factory of the bot with the given name, simple or random, playing from the book if there is one
+func botFactory(kind string, extras botExtras) (chess.PlayerFactory, error) {
+	switch kind {
+	case "simple":
+		return extras.wrap(func(colour chess.Colour) chess.Player { return NewSimpleBot(colour, 0) }), nil
+	case "random":
+		return extras.wrap(func(colour chess.Colour) chess.Player { return &RandomBot{Colour: colour} }), nil
+	default:
+		return nil, fmt.Errorf("unknown bot %q, use simple or random", kind)
+	}
+}
+
+func perftCommand(args []string) error {
+	flags := flag.NewFlagSet("perft", flag.ContinueOnError)
+	fen := flags.String("fen", "", "the position to count from, the starting position if empty")
+	depth := flags.Int("depth", 3, "the number of plies to count")
+	variantName := flags.String("variant", "Standard", "the variant, one of "+variantNames())
+	divide := flags.Bool("divide", false, "show the count below each legal move")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	variant, err := variantByName(*variantName)
+	if err != nil {
+		return err
+	}
+	game, err := newGame(nil, nil, &chess.NullVisualizer{}, variant, *fen)
+	if err != nil {
+		return err
+	}
+	started := time.Now()
+	var nodes int
+	if *divide {
+		counts := chess.PerftDivide(game.Board, game.NextToMove, *depth)
+		moves := make([]string, 0, len(counts))
+		byText := map[string]int{}
+		for move, count := range counts {
+			moves = append(moves, moveText(move))
+			byText[moveText(move)] = count
+			nodes += count
+		}
+		sort.Strings(moves)
+		for _, move := range moves {
+			fmt.Printf("%v: %v\n", move, byText[move])
+		}
+		fmt.Println()
+	} else {
+		nodes = chess.Perft(game.Board, game.NextToMove, *depth)
+	}
+	elapsed := time.Since(started)
+	fmt.Printf("perft(%v) = %v in %v (%.0f nodes/s)\n", *depth, nodes, elapsed.Round(time.Millisecond), float64(nodes)/elapsed.Seconds())
+	return nil
+}
+
+func replayCommand(args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	delay := flags.Int("delay", 1000, "milliseconds between the moves")
+	flipped := flags.Bool("flipped", false, "show the board from blacks side")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: cli replay [flags] file.pgn")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("replay needs one PGN file")
+	}
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	games, err := chess.ParsePGN(f)
+	if err != nil {
+		return err
+	}
+	renderer := newTerminalRenderer(*flipped)
+	for i, pgn := range games {
+		game, err := chess.NewGameFromPGN(nil, nil, &chess.NullVisualizer{}, pgn)
+		if err != nil {
+			return fmt.Errorf("game %v: %w", i+1, err)
+		}
+		boards, err := game.Replay()
+		if err != nil {
+			return fmt.Errorf("game %v: %w", i+1, err)
+		}
+		for ply, board := range boards {
+			clearScreen()
+			fmt.Printf("%v - %v (game %v of %v)\n\n", pgn.Tags["White"], pgn.Tags["Black"], i+1, len(games))
+			Print(board, renderer)
+			if ply > 0 {
+				dots := "."
+				if ply%2 == 0 {
+					dots = "..."
+				}
+				fmt.Printf("\n%v%v %v\n", (ply+1)/2, dots, pgn.Moves[ply-1])
+			}
+			time.Sleep(time.Duration(*delay) * time.Millisecond)
+		}
+		fmt.Printf("\nResult: %v\n", pgn.Result)
+	}
+	return nil
+}
+
+func analyzeCommand(args []string) error {
+	flags := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	fen := flags.String("fen", "", "the position to analyze, the starting position if empty")
+	variantName := flags.String("variant", "Standard", "the variant, one of "+variantNames())
+	syzygyPath := flags.String("syzygy", "", "directories of Syzygy tables to probe the position in, separated like PATH")
+	solve := flags.Bool("solve", true, "solve KQvK, KRvK, KPvK and KBNvK positions in memory")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	extras, err := loadBotExtras("", *syzygyPath, *solve)
+	if err != nil {
+		return err
+	}
+	variant, err := variantByName(*variantName)
+	if err != nil {
+		return err
+	}
+	game, err := newGame(nil, nil, &chess.NullVisualizer{}, variant, *fen)
+	if err != nil {
+		return err
+	}
+	Print(game.Board, newTerminalRenderer(game.NextToMove == chess.Black))
+	fmt.Printf("\nFEN: %v\n", game.FEN())
+	fmt.Printf("Variant: %v\n", game.Variant().Name())
+	fmt.Printf("%v to move\n", game.NextToMove)
+	fmt.Printf("Material: %+d (positive when White is ahead)\n", whiteMaterialBalance(game.Board))
+	if game.Board.IsCheck(game.NextToMove) {
+		fmt.Printf("%v is in check\n", game.NextToMove)
+	}
+	if result, over := game.Variant().GameOver(game); over {
+		fmt.Printf("Game over: %v\n", result)
+		return nil
+	}
+
+	legalMoves := game.LegalMoves()
+	moves := make([]string, 0, len(legalMoves))
+	for move := range legalMoves {
+		moves = append(moves, moveText(move))
+	}
+	sort.Strings(moves)
+	fmt.Printf("%v legal moves: %v\n", len(moves), strings.Join(moves, " "))
+
+	bot := NewSimpleBot(game.NextToMove, 0)
+	restore := silenceOutput()
+	suggestion, err := bot.Evaluate(game, legalMoves)
+	restore()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Suggested move: %v\n", moveText(suggestion))
+	if extras.tablebase != nil {
+		printTablebase(game, extras.tablebase)
+	}
+	return nil
+}
+
+// prints the outcome, the distance to mate or zeroing and the best move the tablebase has for the position
+func printTablebase(game *chess.Game, tablebase chess.Tablebase) {
+	wdl, err := tablebase.ProbeWDL(game.Board, game.NextToMove)
+	if errors.Is(err, chess.ErrNotInTablebase) {
+		return
+	}
+	if err != nil {
+		fmt.Printf("Tablebase: %v\n", err)
+		return
+	}
+	line := fmt.Sprintf("Tablebase: %v for %v", wdl, game.NextToMove)
+	mateKnown := false
+	if dtm, ok := tablebase.(chess.DTMTablebase); ok && wdl != chess.WDLDraw {
+		if plies, err := dtm.ProbeDTM(game.Board, game.NextToMove); err == nil {
+			line += fmt.Sprintf(", %v plies to mate", plies)
+			mateKnown = true
+		}
+	}
+	if dtz, err := tablebase.ProbeDTZ(game.Board, game.NextToMove); err == nil && !mateKnown {
+		line += fmt.Sprintf(", %v plies to zeroing", dtz)
+	}
+	fmt.Println(line)
+	if move, _, err := chess.BestTablebaseMove(tablebase, game.Board, game.NextToMove); err == nil {
+		fmt.Printf("Tablebase move: %v\n", moveText(move))
+	}
+}
+
+// returns the player for human, bot or tui. The TUI is also returned on its own since it is the visualizer too
+func newPlayer(kind string, colour chess.Colour, delayInMS int, extras botExtras) (chess.Player, *TUI, error) {
+	switch kind {
+	case "human":
+		return &Player{Colour: colour}, nil, nil
+	case "bot":
+		return extras.wrap(func(colour chess.Colour) chess.Player { return NewSimpleBot(colour, delayInMS) })(colour), nil, nil
+	case "tui":
+		tui, err := NewTUI(colour)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tui, tui, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown player %q, use human, bot or tui", kind)
+	}
+}
+
+// reads the Polyglot book, nil if the path is empty
+func loadBook(path string) (*chess.PolyglotBook, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return chess.ReadPolyglotBook(f)
+}
+
+// the book and tablebase the bots play from, when they are given
+type botExtras struct {
+	book      *chess.PolyglotBook
+	tablebase chess.Tablebase
+}
+
+// reads the Polyglot book and finds the Syzygy tables, either path may be empty. The endgame solver answers the
+// positions the tables do not have when solve is set
+func loadBotExtras(bookPath string, syzygyPath string, solve bool) (botExtras, error) {
+	book, err := loadBook(bookPath)
+	if err != nil {
+		return botExtras{}, err
+	}
+	extras := botExtras{book: book}
+	tablebases := chess.Tablebases{}
+	if syzygyPath != "" {
+		tablebase, err := chess.OpenSyzygy(syzygyPath)
+		if err != nil {
+			return botExtras{}, err
+		}
+		tablebases = append(tablebases, tablebase)
+	}
+	if solve {
+		tablebases = append(tablebases, chess.NewEndgameSolver())
+	}
+	switch len(tablebases) {
+	case 0:
+	case 1:
+		extras.tablebase = tablebases[0]
+	default:
+		extras.tablebase = tablebases
+	}
+	return extras, nil
+}
+
+// returns a factory of players that play from the tablebase in endgames and from the book in the opening, the
+// players of the factory move in between
+func (extras botExtras) wrap(factory chess.PlayerFactory) chess.PlayerFactory {
+	return func(colour chess.Colour) chess.Player {
+		player := factory(colour)
+		if extras.tablebase != nil {
+			player = chess.NewTablebasePlayer(extras.tablebase, player)
+		}
+		if extras.book != nil {
+			player = chess.NewBookPlayer(extras.book, player)
+		}
+		return player
+	}
+}
+
+// returns a game of the variant, starting from the FEN unless it is empty
+func newGame(white chess.Player, black chess.Player, visualizer chess.BoardVisualizer, variant chess.Variant, fen string) (*chess.Game, error) {
+	if fen == "" {
+		return chess.NewVariantGame(white, black, visualizer, variant), nil
+	}
+	return chess.NewVariantGameFromFEN(white, black, visualizer, variant, fen)
+}
+
+// returns the variant with the given name, ignoring case
+func variantByName(name string) (chess.Variant, error) {
+	for _, variant := range chess.Variants() {
+		if strings.EqualFold(variant.Name(), name) {
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown variant %q, use one of %v", name, variantNames())
+}
+
+func variantNames() string {
+	names := []string{}
+	for _, variant := range chess.Variants() {
+		names = append(names, variant.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+// sends everything printed to stdout to /dev/null and returns a function that restores it
+func silenceOutput() func() {
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	stdout := os.Stdout
+	os.Stdout = null
+	return func() {
+		os.Stdout = stdout
+		null.Close()
+	}
+}
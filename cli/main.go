@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -13,11 +14,15 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/hellgrenj/blue-panda/chess"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
 	Menu()
 }
 func Menu() {
@@ -26,7 +31,11 @@ func Menu() {
 	fmt.Println("1. Human vs Human")
 	fmt.Println("2. Human vs Computer")
 	fmt.Println("3. Computer vs Computer")
-	fmt.Println("4. 100 games of Computer vs Computer")
+	fmt.Println("4. 100 games of Computer vs Computer (played in parallel, saved as PGN)")
+	fmt.Println("5. Chess960 (Fischer Random) Human vs Computer")
+	fmt.Println("6. Chess variant Human vs Computer")
+	fmt.Println("7. Human vs Computer with odds (the computer gives the odds)")
+	fmt.Println("8. Human vs Computer in full screen (move with the arrow keys)")
 	reader := bufio.NewReader(os.Stdin)
 	gameType, _ := reader.ReadString('\n')
 	gameType = strings.TrimSpace(gameType)
@@ -46,29 +55,88 @@ func Menu() {
 			whitePlayer = NewSimpleBot(chess.White, 1500)
 			blackPlayer = &Player{Colour: chess.Black}
 		}
-		startGame(whitePlayer, blackPlayer)
+		playGame(chess.NewGame(whitePlayer, blackPlayer, &CLIPrinter{Flipped: selectedColor == chess.Black}))
 	case "3":
 		whitePlayer := NewSimpleBot(chess.White, 200)
 		blackPlayer := NewSimpleBot(chess.Black, 200)
 		startGame(whitePlayer, blackPlayer)
 	case "4":
-		whitePlayer := NewSimpleBot(chess.White, 0)
-		blackPlayer := NewSimpleBot(chess.Black, 0)
-		results := make(map[chess.Result]int)
-		for i := 0; i < 100; i++ {
-			result := startGame(whitePlayer, blackPlayer)
-			results[result]++
-		}
-		fmt.Println("\nResults:")
-		for k, v := range results {
-			if k.Draw {
-				fmt.Printf("Draw (%v): %d times\n", k.Reason, v)
-			} else {
-				fmt.Printf("%v Wins (%v): %d times\n", k.Winner, k.Reason, v)
-			}
+		fmt.Println("Playing 100 games of Computer vs Computer...")
+		stats, err := runSelfplay(100, runtime.NumCPU(), "selfplay.pgn", botExtras{}, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("\n%v\nThe games are saved in ./selfplay.pgn\n", stats)
+	case "5":
+		clearScreen()
+		selectedColor := SelectColor()
+		var whitePlayer, blackPlayer chess.Player
+		if selectedColor == chess.White {
+			whitePlayer = &Player{Colour: chess.White}
+			blackPlayer = NewSimpleBot(chess.Black, 1500)
+		} else {
+			whitePlayer = NewSimpleBot(chess.White, 1500)
+			blackPlayer = &Player{Colour: chess.Black}
+		}
+		index := rand.Intn(960)
+		game, err := chess.NewChess960Game(whitePlayer, blackPlayer, &CLIPrinter{Flipped: selectedColor == chess.Black}, index)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Chess960 starting position %v\n", index)
+		playGame(game)
+	case "6":
+		clearScreen()
+		variant := SelectVariant()
+		selectedColor := SelectColor()
+		var whitePlayer, blackPlayer chess.Player
+		if selectedColor == chess.White {
+			whitePlayer = &Player{Colour: chess.White}
+			blackPlayer = NewSimpleBot(chess.Black, 1500)
+		} else {
+			whitePlayer = NewSimpleBot(chess.White, 1500)
+			blackPlayer = &Player{Colour: chess.Black}
+		}
+		playGame(chess.NewVariantGame(whitePlayer, blackPlayer, &CLIPrinter{Flipped: selectedColor == chess.Black}, variant))
+	case "7":
+		clearScreen()
+		handicap := SelectHandicap()
+		selectedColor := SelectColor()
+		var whitePlayer, blackPlayer chess.Player
+		botColour := chess.White
+		if selectedColor == chess.White {
+			whitePlayer = &Player{Colour: chess.White}
+			blackPlayer = NewSimpleBot(chess.Black, 1500)
+			botColour = chess.Black
+		} else {
+			whitePlayer = NewSimpleBot(chess.White, 1500)
+			blackPlayer = &Player{Colour: chess.Black}
 		}
+		game, err := chess.NewHandicapGame(whitePlayer, blackPlayer, &CLIPrinter{Flipped: selectedColor == chess.Black}, handicap, botColour)
+		if err != nil {
+			log.Fatal(err)
+		}
+		playGame(game)
+	case "8":
+		clearScreen()
+		selectedColor := SelectColor()
+		tui, err := NewTUI(selectedColor)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var whitePlayer, blackPlayer chess.Player
+		if selectedColor == chess.White {
+			whitePlayer = tui
+			blackPlayer = NewSimpleBot(chess.Black, 1500)
+		} else {
+			whitePlayer = NewSimpleBot(chess.White, 1500)
+			blackPlayer = tui
+		}
+		game := chess.NewGame(whitePlayer, blackPlayer, tui)
+		tui.game = game
+		playGame(game)
 	default:
-		fmt.Println("Invalid option. You can enter 1, 2, 3 or 4. please try again")
+		fmt.Println("Invalid option. You can enter 1 to 8. please try again")
 		Menu()
 	}
 }
@@ -93,8 +161,49 @@ func SelectColor() chess.Colour {
 	}
 }
 
+func SelectVariant() chess.Variant {
+	variants := chess.Variants()
+	fmt.Println("What variant do you want to play?")
+	for i, variant := range variants {
+		fmt.Printf("%v. %v\n", i+1, variant.Name())
+	}
+	reader := bufio.NewReader(os.Stdin)
+	choice, err := reader.ReadString('\n')
+	if err != nil {
+		log.Fatal(err)
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(choice))
+	if err != nil || index < 1 || index > len(variants) {
+		fmt.Printf("Invalid option. You can enter 1 to %v. please try again\n", len(variants))
+		return SelectVariant()
+	}
+	return variants[index-1]
+}
+
+func SelectHandicap() chess.Handicap {
+	handicaps := chess.Handicaps()
+	fmt.Println("What odds do you want?")
+	for i, handicap := range handicaps {
+		fmt.Printf("%v. %v\n", i+1, handicap.Name)
+	}
+	reader := bufio.NewReader(os.Stdin)
+	choice, err := reader.ReadString('\n')
+	if err != nil {
+		log.Fatal(err)
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(choice))
+	if err != nil || index < 1 || index > len(handicaps) {
+		fmt.Printf("Invalid option. You can enter 1 to %v. please try again\n", len(handicaps))
+		return SelectHandicap()
+	}
+	return handicaps[index-1]
+}
+
 func startGame(whitePlayer chess.Player, blackPlayer chess.Player) chess.Result {
-	game := chess.NewGame(whitePlayer, blackPlayer, &CLIPrinter{})
+	return playGame(chess.NewGame(whitePlayer, blackPlayer, &CLIPrinter{}))
+}
+
+func playGame(game *chess.Game) chess.Result {
 	var gracefulStop = make(chan os.Signal, 1)
 	signal.Notify(gracefulStop, syscall.SIGTERM, syscall.SIGINT, syscall.SIGSEGV)
 	go func() {
@@ -104,12 +213,13 @@ func startGame(whitePlayer chess.Player, blackPlayer chess.Player) chess.Result
 		os.Exit(0)
 	}()
 	result := game.Start()
-	if result.Draw {
-		fmt.Printf("Draw, reason: %v\n", result.Reason)
+	if winner, ok := result.Winner(); ok {
+		fmt.Printf("%v wins! (%v) %v\n\n", winner, result.Reason, result.PGN())
 	} else {
-		fmt.Printf("%v wins! (%v)\n\n", result.Winner, result.Reason)
+		fmt.Printf("Draw, reason: %v %v\n", result.Reason, result.PGN())
 	}
 	printHistory(game)
+	saveHistoryGIF(game)
 	return result
 }
 
@@ -126,12 +236,52 @@ func printHistory(g *chess.Game) {
 	}
 }
 
+// saves the latest game as an animated GIF, one frame per move
+func saveHistoryGIF(g *chess.Game) {
+	f, err := os.Create("history.gif")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer f.Close()
+	if err := chess.WriteGIF(f, g, chess.GIFOptions{}); err != nil {
+		fmt.Println(err)
+	}
+}
+
 type CLIPrinter struct {
+	Flipped bool // black at the bottom, for when a human plays black
 }
 
 func (v *CLIPrinter) VisualizeState(b *chess.Board) {
 	clearScreen()
-	Print(b)
+	Print(b, newTerminalRenderer(v.Flipped))
+}
+
+// shows the board and the opening the game has reached
+func (v *CLIPrinter) VisualizeGame(g *chess.Game) {
+	v.VisualizeState(g.Board)
+	if opening, ok := g.Opening(); ok {
+		fmt.Printf("Opening: %v\n", opening)
+	}
+}
+
+// uses colours unless NO_COLOR is set and falls back to ASCII when the locale is not UTF-8
+func newTerminalRenderer(flipped bool) *chess.TerminalRenderer {
+	return &chess.TerminalRenderer{Flipped: flipped, ASCII: !terminalSupportsUnicode(), NoColour: os.Getenv("NO_COLOR") != ""}
+}
+
+func terminalSupportsUnicode() bool {
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			value = strings.ToUpper(value)
+			return strings.Contains(value, "UTF-8") || strings.Contains(value, "UTF8")
+		}
+	}
+	return false
 }
 
 func clearScreen() {
@@ -150,10 +300,50 @@ type Player struct {
 
 func (p *Player) PickMove(g *chess.Game) (*chess.Move, error) {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("\n%v to move: ", g.NextToMove)
+	if left, limited := g.TimeLeft(p.Colour); limited {
+		fmt.Printf("\n%v left on your clock", left.Round(time.Second))
+	}
+	fmt.Printf("\n%v to move (or O-O, O-O-O, N@f3, resign, draw, claim): ", g.NextToMove)
 	move, _ := reader.ReadString('\n')
 	move = strings.TrimSpace(move)
 
+	switch strings.ToLower(move) {
+	case "resign":
+		if err := g.Resign(p.Colour); err != nil {
+			return nil, err
+		}
+		return nil, chess.ErrGameFinished
+	case "draw":
+		if err := g.OfferDraw(p.Colour); err != nil {
+			return nil, err
+		}
+		if g.IsFinished() {
+			return nil, chess.ErrGameFinished
+		}
+		fmt.Println("draw offer declined")
+		return p.PickMove(g)
+	case "claim":
+		if err := g.ClaimDraw(p.Colour); err != nil {
+			return nil, err
+		}
+		return nil, chess.ErrGameFinished
+	case "o-o", "0-0", "o-o-o", "0-0-0":
+		kingSide := len(move) == 3
+		castlingMove, err := g.Board.CastlingMove(p.Colour, kingSide)
+		if err != nil {
+			return nil, err
+		}
+		return &castlingMove, nil
+	}
+
+	// drops (crazyhouse), e.g. N@f3 or @e4 for a pawn
+	if dropMatch := regexp.MustCompile(`^([PNBRQpnbrq]?)@([A-Ha-h])([1-8])$`).FindStringSubmatch(move); dropMatch != nil {
+		pieceTypes := map[string]chess.PieceType{"": chess.Pawn, "P": chess.Pawn, "N": chess.Knight, "B": chess.Bishop, "R": chess.Rook, "Q": chess.Queen}
+		row, _ := strconv.Atoi(dropMatch[3])
+		drop := chess.NewDrop(pieceTypes[strings.ToUpper(dropMatch[1])], chess.Square{Column: strings.ToUpper(dropMatch[2]), Row: row})
+		return &drop, nil
+	}
+
 	// input validation
 	match, _ := regexp.MatchString("[A-Ha-h][1-8] [A-Ha-h][1-8]", move)
 	if !match {
@@ -182,33 +372,95 @@ func (p *Player) PickMove(g *chess.Game) (*chess.Move, error) {
 		To:   chess.Square{Column: toColumn, Row: toRow},
 	}, nil
 }
-func Print(b *chess.Board) {
+func (p *Player) RespondToDrawOffer(g *chess.Game, offeredBy chess.Colour) bool {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("\n%v offers a draw, does %v accept? (y/n): ", offeredBy, p.Colour)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+func (p *Player) HandleIllegalMove(g *chess.Game, move chess.Move, err error) {
+	fmt.Printf("\n%v\n", friendlyMoveError(move, err))
+}
 
-	for i, square := range b.Squares {
-		if i%8 == 0 {
-			fmt.Printf("\n")
-		}
-		occupied, piece := b.GetPieceAtSquare(square.Column, square.Row)
-		if occupied {
-			fmt.Printf(" %v ", piece.GetAbbreveation())
-		} else {
-			fmt.Printf(" . ")
-		}
+// turns an error from the chess package into something a human can act on
+func friendlyMoveError(move chess.Move, err error) string {
+	var illegalMoveErr *chess.IllegalMoveError
+	if !errors.As(err, &illegalMoveErr) {
+		return fmt.Sprintf("Could not make that move: %v", err)
 	}
-	fmt.Printf("\n")
+	from := fmt.Sprintf("%v%v", move.From.Column, move.From.Row)
+	to := fmt.Sprintf("%v%v", move.To.Column, move.To.Row)
+	switch illegalMoveErr.Reason {
+	case chess.NoPiece:
+		return fmt.Sprintf("There is no piece on %v.", from)
+	case chess.NotYourPiece:
+		return fmt.Sprintf("The piece on %v belongs to your opponent.", from)
+	case chess.NotYourTurn:
+		return "It is not your turn."
+	case chess.AlreadyThere:
+		return fmt.Sprintf("The piece is already on %v, pick another square.", to)
+	case chess.OffBoard:
+		return fmt.Sprintf("%v is not on the board.", to)
+	case chess.InvalidPieceMove:
+		return fmt.Sprintf("That piece can not move from %v to %v (%v).", from, to, illegalMoveErr.Detail)
+	case chess.PathBlocked:
+		return fmt.Sprintf("There are pieces in the way between %v and %v.", from, to)
+	case chess.SquareOccupied:
+		return fmt.Sprintf("%v is occupied by one of your own pieces.", to)
+	case chess.LeavesKingInCheck:
+		return "That move would leave your king in check."
+	case chess.CastlingNotAllowed:
+		return fmt.Sprintf("You can not castle right now (%v).", illegalMoveErr.Detail)
+	case chess.KingIsInMate:
+		return "Your king is checkmated."
+	case chess.NotInPocket:
+		return "You do not have that piece in your pocket."
+	case chess.ForbiddenByVariant:
+		return fmt.Sprintf("That move is not allowed in this variant (%v).", illegalMoveErr.Detail)
+	default:
+		return fmt.Sprintf("Illegal move: %v", illegalMoveErr)
+	}
+}
+func Print(b *chess.Board, renderer *chess.TerminalRenderer) {
+	fmt.Printf("\n%v", renderer.Render(b))
 	fmt.Printf("White's captured pieces:")
 	for _, piece := range b.BlackPieces {
 		if !piece.InPlay {
-			fmt.Printf(" %v ", piece.GetAbbreveation())
+			fmt.Printf(" %v ", pieceSymbol(piece, renderer.ASCII))
 		}
 	}
 	fmt.Println("")
 	fmt.Printf("Black's captured pieces:")
 	for _, piece := range b.WhitePieces {
 		if !piece.InPlay {
-			fmt.Printf(" %v ", piece.GetAbbreveation())
+			fmt.Printf(" %v ", pieceSymbol(piece, renderer.ASCII))
 		}
 	}
 	fmt.Println("")
+	for _, colour := range []chess.Colour{chess.White, chess.Black} {
+		pocket := b.Pocket(colour)
+		if len(pocket) == 0 {
+			continue
+		}
+		fmt.Printf("%v's pocket:", colour)
+		for _, pieceType := range []chess.PieceType{chess.Queen, chess.Rook, chess.Bishop, chess.Knight, chess.Pawn} {
+			if count := pocket[pieceType]; count > 0 {
+				fmt.Printf(" %v x%v ", pieceSymbol(chess.Piece{Type: pieceType, Colour: colour}, renderer.ASCII), count)
+			}
+		}
+		fmt.Println("")
+	}
+}
 
+// returns the unicode glyph of the piece, or its letter (upper case for white) in ASCII
+func pieceSymbol(piece chess.Piece, ascii bool) string {
+	if !ascii {
+		return piece.GetAbbreveation()
+	}
+	letter := map[chess.PieceType]string{chess.Pawn: "p", chess.Knight: "n", chess.Bishop: "b", chess.Rook: "r", chess.Queen: "q", chess.King: "k"}[piece.Type]
+	if piece.Colour == chess.White {
+		return strings.ToUpper(letter)
+	}
+	return letter
 }
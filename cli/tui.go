@@ -0,0 +1,341 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hellgrenj/blue-panda/chess"
+)
+
+const tuiHelp = "arrows: move  enter/space: select  esc: cancel  f: flip  d: offer draw  c: claim draw  r or ctrl-c: resign"
+
+// a full screen terminal UI for a human player. The cursor is moved with the arrow keys, selecting a piece shows
+// its legal moves and selecting one of them makes the move. It is both the Player and the BoardVisualizer of the
+// game and puts the terminal in raw mode with stty while waiting for a move, so it needs a unix like terminal
+type TUI struct {
+	Colour      chess.Colour
+	game        *chess.Game
+	renderer    *chess.TerminalRenderer
+	cursor      chess.Square
+	selected    *chess.Square
+	targets     []chess.Square
+	status      string
+	opening     string                         // the ECO code and name of the opening, empty when it is not in the table
+	thinking    map[chess.Colour]time.Duration // the time each side has spent on its moves
+	turnColour  chess.Colour
+	turnStarted time.Time
+}
+
+// returns an error if the terminal can not be put in raw mode
+func NewTUI(colour chess.Colour) (*TUI, error) {
+	if _, err := stty("-g"); err != nil {
+		return nil, fmt.Errorf("full screen mode needs a terminal that supports stty: %w", err)
+	}
+	cursor := chess.Square{Column: "E", Row: 2}
+	if colour == chess.Black {
+		cursor.Row = 7
+	}
+	return &TUI{
+		Colour:      colour,
+		renderer:    newTerminalRenderer(colour == chess.Black),
+		cursor:      cursor,
+		thinking:    map[chess.Colour]time.Duration{},
+		turnColour:  chess.White,
+		turnStarted: time.Now(),
+	}, nil
+}
+
+// starts the clock of the side to move and draws the screen
+func (t *TUI) VisualizeState(b *chess.Board) {
+	now := time.Now()
+	t.thinking[t.turnColour] += now.Sub(t.turnStarted)
+	t.turnStarted = now
+	if t.game != nil {
+		t.turnColour = t.game.NextToMove
+	}
+	t.draw(b)
+}
+
+// keeps the game to classify its opening and draws the screen
+func (t *TUI) VisualizeGame(g *chess.Game) {
+	t.game, t.opening = g, ""
+	if opening, ok := g.Opening(); ok {
+		t.opening = opening.String()
+	}
+	t.VisualizeState(g.Board)
+}
+
+func (t *TUI) PickMove(g *chess.Game) (*chess.Move, error) {
+	t.game = g
+	restore, err := enableRawMode()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { restore() }() // restore is replaced when a draw is offered
+	for {
+		t.draw(g.Board)
+		key, err := readKey()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "up", "down", "left", "right":
+			t.moveCursor(key)
+		case "esc":
+			t.selected, t.targets = nil, nil
+		case "f":
+			t.renderer.Flipped = !t.renderer.Flipped
+		case "select":
+			if move := t.selectSquare(g); move != nil {
+				t.selected, t.targets, t.status = nil, nil, ""
+				return move, nil
+			}
+		case "d":
+			restore() // the opponent may need to answer
+			err := g.OfferDraw(t.Colour)
+			if err != nil {
+				t.status = err.Error()
+			}
+			if g.IsFinished() {
+				return nil, chess.ErrGameFinished
+			}
+			if err == nil {
+				t.status = "draw offer declined"
+			}
+			if restore, err = enableRawMode(); err != nil {
+				return nil, err
+			}
+		case "c":
+			if err := g.ClaimDraw(t.Colour); err != nil {
+				t.status = err.Error()
+				continue
+			}
+			return nil, chess.ErrGameFinished
+		case "r", "quit":
+			if err := g.Resign(t.Colour); err != nil {
+				return nil, err
+			}
+			return nil, chess.ErrGameFinished
+		}
+	}
+}
+
+func (t *TUI) HandleIllegalMove(g *chess.Game, move chess.Move, err error) {
+	t.status = friendlyMoveError(move, err)
+}
+
+// moves the cursor in the direction of the arrow on the screen, which depends on the orientation of the board
+func (t *TUI) moveCursor(key string) {
+	columns := "ABCDEFGH"
+	column, row := strings.Index(columns, t.cursor.Column), t.cursor.Row
+	step := 1
+	if t.renderer.Flipped {
+		step = -1
+	}
+	switch key {
+	case "up":
+		row += step
+	case "down":
+		row -= step
+	case "left":
+		column -= step
+	case "right":
+		column += step
+	}
+	if column >= 0 && column < 8 && row >= 1 && row <= 8 {
+		t.cursor = chess.Square{Column: string(columns[column]), Row: row}
+	}
+}
+
+// selects the piece under the cursor or, if a piece is selected, returns the move to the square under the cursor
+func (t *TUI) selectSquare(g *chess.Game) *chess.Move {
+	if t.selected != nil {
+		for _, target := range t.targets {
+			if target == t.cursor {
+				return &chess.Move{From: *t.selected, To: target}
+			}
+		}
+		if *t.selected == t.cursor {
+			t.selected, t.targets = nil, nil
+			return nil
+		}
+	}
+	found, piece := g.Board.GetPieceAtSquare(t.cursor.Column, t.cursor.Row)
+	if !found || piece.Colour != t.Colour {
+		t.status = "select one of your pieces"
+		return nil
+	}
+	targets := []chess.Square{}
+	for move := range g.LegalMoves() {
+		if move.Kind == chess.PieceMove && move.From == t.cursor {
+			targets = append(targets, move.To)
+		}
+	}
+	if len(targets) == 0 {
+		t.status = "that piece has no legal moves"
+		return nil
+	}
+	selected := t.cursor
+	t.selected, t.targets, t.status = &selected, targets, ""
+	return nil
+}
+
+// draws the board with the side panels, the status line and the help
+func (t *TUI) draw(b *chess.Board) {
+	cursor := t.cursor
+	t.renderer.Cursor, t.renderer.Selected, t.renderer.Targets = &cursor, t.selected, t.targets
+	if t.game == nil || t.game.NextToMove != t.Colour || t.game.IsFinished() {
+		t.renderer.Cursor = nil // only show the cursor when it is our turn
+	}
+	boardLines := strings.Split(strings.TrimSuffix(t.renderer.Render(b), "\n"), "\n")
+	panel := t.panel(b)
+
+	var screen strings.Builder
+	screen.WriteString("\x1b[H\x1b[2J")
+	for i := 0; i < len(boardLines) || i < len(panel); i++ {
+		line := strings.Repeat(" ", 26)
+		if i < len(boardLines) {
+			line = boardLines[i]
+		}
+		if i < len(panel) {
+			line += "    " + panel[i]
+		}
+		screen.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&screen, "\n%v\n%v\n", t.status, tuiHelp)
+	fmt.Print(strings.ReplaceAll(screen.String(), "\n", "\r\n"))
+}
+
+// returns the lines of the side panel: clocks, evaluation, captured pieces, the opening and the moves
+func (t *TUI) panel(b *chess.Board) []string {
+	clock := func(colour chess.Colour) string {
+		spent := t.thinking[colour]
+		thinking := colour == t.turnColour && (t.game == nil || !t.game.IsFinished())
+		if thinking {
+			spent += time.Since(t.turnStarted)
+		}
+		if t.game != nil {
+			if left, limited := t.game.TimeLeft(colour); limited { // counts down instead
+				spent = left
+				if thinking && t.game.NextToMove == colour {
+					spent -= time.Since(t.turnStarted)
+				}
+				if spent < 0 {
+					spent = 0
+				}
+			}
+		}
+		marker := " "
+		if t.game != nil && t.game.NextToMove == colour && !t.game.IsFinished() {
+			marker = ">"
+		}
+		return fmt.Sprintf("%v %v %02d:%02d", marker, colour, int(spent.Minutes()), int(spent.Seconds())%60)
+	}
+	captured := func(pieces []chess.Piece) string {
+		symbols := []string{}
+		for _, piece := range pieces {
+			if !piece.InPlay {
+				symbols = append(symbols, pieceSymbol(piece, t.renderer.ASCII))
+			}
+		}
+		return strings.Join(symbols, " ")
+	}
+	panel := []string{
+		clock(chess.White) + "   " + clock(chess.Black),
+		fmt.Sprintf("Evaluation: %+d (material)", whiteMaterialBalance(b)),
+		"Captured by White: " + captured(b.BlackPieces),
+		"Captured by Black: " + captured(b.WhitePieces),
+	}
+	if t.opening != "" {
+		panel = append(panel, "Opening: "+t.opening)
+	}
+	panel = append(panel, "", "Moves:")
+	if t.game == nil {
+		return panel
+	}
+	// the last moves that fit next to the board, white and black on one line
+	history := t.game.History
+	first := 0
+	if len(history) > 12 {
+		first = len(history) - 12
+		first -= first % 2
+	}
+	for i := first; i < len(history); i += 2 {
+		line := fmt.Sprintf("%3d. %-6v", i/2+1, moveText(history[i]))
+		if i+1 < len(history) {
+			line += " " + moveText(history[i+1])
+		}
+		panel = append(panel, line)
+	}
+	if t.game.IsFinished() {
+		panel = append(panel, "", t.game.Result().String())
+	}
+	return panel
+}
+
+// returns the move in coordinate notation, e.g. e2e4 or N@f3 for a drop
+func moveText(move chess.Move) string {
+	to := strings.ToLower(fmt.Sprintf("%v%v", move.To.Column, move.To.Row))
+	if move.Kind == chess.Drop {
+		return pieceSymbol(chess.Piece{Type: move.Piece, Colour: chess.White}, true) + "@" + to
+	}
+	return strings.ToLower(fmt.Sprintf("%v%v", move.From.Column, move.From.Row)) + to
+}
+
+// returns the name of the key that was pressed, arrows are returned as up, down, left and right
+func readKey() (string, error) {
+	buf := make([]byte, 8)
+	n, err := os.Stdin.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	switch key := string(buf[:n]); key {
+	case "\x1b[A":
+		return "up", nil
+	case "\x1b[B":
+		return "down", nil
+	case "\x1b[C":
+		return "right", nil
+	case "\x1b[D":
+		return "left", nil
+	case "\x1b":
+		return "esc", nil
+	case "\r", "\n", " ":
+		return "select", nil
+	case "\x03":
+		return "quit", nil
+	default:
+		return strings.ToLower(key), nil
+	}
+}
+
+// puts the terminal in raw mode (keys are read one at a time without echo) and returns a function that restores it
+func enableRawMode() (func(), error) {
+	state, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	restored := false
+	return func() {
+		if !restored {
+			stty(strings.TrimSpace(state))
+			restored = true
+		}
+	}, nil
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %v: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}